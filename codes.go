@@ -0,0 +1,26 @@
+package validator
+
+import (
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+// ErrorCodes returns middleware that namespaces validation error codes for the
+// routes it wraps, so codes such as "USR_REQUIRED" and "PAY_REQUIRED" stay
+// unique across a large API (see validate.WithErrorCodePrefix). Install it on
+// a route group, before Enforce:
+//
+//	users := app.Group("/users", validator.ErrorCodes("USR_"), validator.Enforce())
+//	payments := app.Group("/payments", validator.ErrorCodes("PAY_"), validator.Enforce())
+//
+// Enforce's error details and validate.ToFieldErrorDetails with the request
+// context pick the prefix up; EnforceConfig.CodePrefix overrides it.
+func ErrorCodes(prefix string) flash.Middleware {
+	return func(next flash.Handler) flash.Handler {
+		return func(c flash.Ctx) error {
+			ctx := validate.WithErrorCodePrefix(c.Context(), prefix)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
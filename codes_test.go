@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+func TestErrorCodes_GroupPrefix(t *testing.T) {
+	reg := NewRouteRegistry()
+	reg.ForRoute("/users", http.MethodPost, createUser{})
+	reg.ForRoute("/payments", http.MethodPost, createUser{})
+	app := flash.New()
+	app.Group("", ErrorCodes("USR_"), Enforce(EnforceConfig{Registry: reg})).POST("/users", func(c flash.Ctx) error { return nil })
+	app.Group("", ErrorCodes("PAY_"), Enforce(EnforceConfig{Registry: reg, CodePrefix: "PMT_"})).POST("/payments", func(c flash.Ctx) error { return nil })
+
+	for path, want := range map[string]string{"/users": "USR_REQUIRED", "/payments": "PMT_REQUIRED"} {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"email":"a@b.co"}`)))
+		var body struct {
+			Errors []validate.FieldErrorDetail `json:"errors"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode %s: %v", rec.Body.String(), err)
+		}
+		if len(body.Errors) != 1 || body.Errors[0].Code != want || body.Errors[0].Field != "name" || body.Errors[0].Message != "is required" {
+			t.Fatalf("%s: expected %s detail, got %+v", path, want, body.Errors)
+		}
+		if fp := validate.Fingerprint("POST "+path, "name", "required"); body.Errors[0].Fingerprint != fp {
+			t.Fatalf("%s: expected fingerprint %s, got %+v", path, fp, body.Errors[0])
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Package connectvalidate provides a connect-go interceptor that validates
+// incoming request messages with the goflash validate helpers.
+//
+// It lives in its own module so the core validator module does not pull in
+// Connect and protobuf dependencies.
+package connectvalidate
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"connectrpc.com/connect"
+	globalValidator "github.com/go-playground/validator/v10"
+	"github.com/goflash/validator/v2/validate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// Config configures the validation interceptor. The zero value is usable and
+// behaves like the HTTP helpers: requests are validated with
+// validate.StructCtx, so engines and skips on the context apply, and messages
+// come from the messages registered for the call's locale, the request
+// context (validate.WithMessageFunc), the global SetMessageFunc, or the
+// built-in English fallback, in that order.
+type Config struct {
+	// DefaultLocale used when none is derived from the request.
+	// Default: "en".
+	DefaultLocale string
+	// LocaleFromRequest returns the desired locale for a call.
+	// Default: primary language of the Accept-Language request header.
+	LocaleFromRequest func(req connect.AnyRequest) string
+	// MessageFuncFor mirrors validator.ValidatorI18nConfig.MessageFuncFor and
+	// returns a function that translates a FieldError for the given locale.
+	// Optional.
+	MessageFuncFor func(locale string) func(globalValidator.FieldError) string
+	// Validate validates a request message. ctx carries the call's locale
+	// (validate.WithLocale). Default: validate.StructCtx.
+	Validate func(ctx context.Context, msg any) error
+	// Message is the error message used for CodeInvalidArgument errors.
+	// Default: "validation failed".
+	Message string
+}
+
+// NewInterceptor returns a unary interceptor that validates struct (or pointer
+// to struct) request messages on the handler side before invoking the next
+// function. Client-side calls pass through untouched.
+//
+// Validation failures are returned as connect.CodeInvalidArgument errors with
+// an errdetails.BadRequest detail listing one FieldViolation per invalid field,
+// sorted by field name.
+func NewInterceptor(cfgs ...Config) connect.UnaryInterceptorFunc {
+	var cfg Config
+	if len(cfgs) > 0 {
+		cfg = cfgs[0]
+	}
+	if cfg.DefaultLocale == "" {
+		cfg.DefaultLocale = "en"
+	}
+	if cfg.LocaleFromRequest == nil {
+		cfg.LocaleFromRequest = localeFromHeader
+	}
+	if cfg.Validate == nil {
+		cfg.Validate = validate.StructCtx
+	}
+	if cfg.Message == "" {
+		cfg.Message = "validation failed"
+	}
+
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			msg := req.Any()
+			if !validate.IsStruct(msg) {
+				return next(ctx, req)
+			}
+			vctx := validate.WithLocaleMessageFunc(ctx, cfg.LocaleFromRequest(req), cfg.DefaultLocale, cfg.MessageFuncFor)
+			if err := cfg.Validate(vctx, msg); err != nil {
+				return nil, ToError(vctx, err, cfg.Message)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// ToError converts a validation error into a connect.CodeInvalidArgument error
+// carrying an errdetails.BadRequest detail. Messages are resolved with
+// validate.ToFieldErrorsWithContext, so request-scoped message functions apply.
+func ToError(ctx context.Context, err error, msg string) *connect.Error {
+	if msg == "" {
+		msg = "validation failed"
+	}
+	fields := validate.ToFieldErrorsWithContext(ctx, err)
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	br := &errdetails.BadRequest{}
+	for _, k := range keys {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       k,
+			Description: fields[k],
+		})
+	}
+	cErr := connect.NewError(connect.CodeInvalidArgument, errors.New(msg))
+	if detail, dErr := connect.NewErrorDetail(br); dErr == nil {
+		cErr.AddDetail(detail)
+	}
+	return cErr
+}
+
+// localeFromHeader reads the primary language from the Accept-Language header,
+// e.g. "es-ES,es;q=0.9" -> "es".
+func localeFromHeader(req connect.AnyRequest) string {
+	return validate.PrimaryLanguage(req.Header().Get("Accept-Language"))
+}
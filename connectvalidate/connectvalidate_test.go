@@ -0,0 +1,138 @@
+package connectvalidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	globalValidator "github.com/go-playground/validator/v10"
+	"github.com/goflash/validator/v2/validate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+type createUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func okNext(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+	return connect.NewResponse(&struct{}{}), nil
+}
+
+func badRequestOf(t *testing.T, err error) *errdetails.BadRequest {
+	t.Helper()
+	var cErr *connect.Error
+	if !errors.As(err, &cErr) {
+		t.Fatalf("expected connect error, got %v", err)
+	}
+	if cErr.Code() != connect.CodeInvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", cErr.Code())
+	}
+	for _, d := range cErr.Details() {
+		v, dErr := d.Value()
+		if dErr != nil {
+			t.Fatalf("detail decode: %v", dErr)
+		}
+		if br, ok := v.(*errdetails.BadRequest); ok {
+			return br
+		}
+	}
+	t.Fatalf("expected BadRequest detail")
+	return nil
+}
+
+func TestNewInterceptor_InvalidRequest(t *testing.T) {
+	next := NewInterceptor()(okNext)
+	_, err := next(context.Background(), connect.NewRequest(&createUserRequest{Email: "x"}))
+	br := badRequestOf(t, err)
+	if len(br.FieldViolations) != 2 {
+		t.Fatalf("expected 2 violations, got %v", br.FieldViolations)
+	}
+	if v := br.FieldViolations[0]; v.Field != "email" || v.Description != "must be a valid email" {
+		t.Fatalf("unexpected first violation: %v", v)
+	}
+	if v := br.FieldViolations[1]; v.Field != "name" || v.Description != "is required" {
+		t.Fatalf("unexpected second violation: %v", v)
+	}
+}
+
+func TestNewInterceptor_ValidRequestCallsNext(t *testing.T) {
+	next := NewInterceptor()(okNext)
+	if _, err := next(context.Background(), connect.NewRequest(&createUserRequest{Name: "a", Email: "a@b.co"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNewInterceptor_NonStructPassthrough(t *testing.T) {
+	next := NewInterceptor()(okNext)
+	s := "plain"
+	if _, err := next(context.Background(), connect.NewRequest(&s)); err != nil {
+		t.Fatalf("expected passthrough, got %v", err)
+	}
+}
+
+func TestNewInterceptor_LocaleFromHeader(t *testing.T) {
+	next := NewInterceptor(Config{
+		MessageFuncFor: func(locale string) func(globalValidator.FieldError) string {
+			if locale == "es" {
+				return func(globalValidator.FieldError) string { return "ES_MSG" }
+			}
+			return nil
+		},
+	})(okNext)
+	req := connect.NewRequest(&createUserRequest{Email: "a@b.co"})
+	req.Header().Set("Accept-Language", "es-ES,es;q=0.9")
+	_, err := next(context.Background(), req)
+	if d := badRequestOf(t, err).FieldViolations[0].Description; d != "ES_MSG" {
+		t.Fatalf("expected localized message, got %q", d)
+	}
+
+	req = connect.NewRequest(&createUserRequest{Email: "a@b.co"})
+	req.Header().Set("Accept-Language", "fr")
+	_, err = next(context.Background(), req)
+	if d := badRequestOf(t, err).FieldViolations[0].Description; d != "is required" {
+		t.Fatalf("expected default message, got %q", d)
+	}
+}
+
+func TestNewInterceptor_ContextEngineAndLocale(t *testing.T) {
+	e := validate.NewEngine()
+	e.RegisterMessages("required", map[string]string{"es": "es obligatorio"})
+	e.RegisterStructValidationMapRules(map[string]string{"Email": "required"}, createUserRequest{})
+	next := NewInterceptor()(okNext)
+
+	req := connect.NewRequest(&createUserRequest{Email: "x"})
+	req.Header().Set("Accept-Language", "es")
+	_, err := next(validate.WithEngine(context.Background(), e), req)
+	br := badRequestOf(t, err)
+	if len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != "name" || br.FieldViolations[0].Description != "es obligatorio" {
+		t.Fatalf("expected the context engine and the es message, got %v", br.FieldViolations)
+	}
+}
+
+func TestNewInterceptor_ContextMessageFuncWins(t *testing.T) {
+	next := NewInterceptor(Config{
+		MessageFuncFor: func(string) func(globalValidator.FieldError) string {
+			return func(globalValidator.FieldError) string { return "CFG" }
+		},
+	})(okNext)
+	ctx := validate.WithMessageFunc(context.Background(), func(globalValidator.FieldError) string { return "CTX" })
+	_, err := next(ctx, connect.NewRequest(&createUserRequest{Email: "a@b.co"}))
+	if d := badRequestOf(t, err).FieldViolations[0].Description; d != "CTX" {
+		t.Fatalf("expected request-scoped message, got %q", d)
+	}
+}
+
+func TestToError_CustomMessageAndFieldErrors(t *testing.T) {
+	err := ToError(context.Background(), validate.FieldErrors{"id": "is unknown"}, "bad request")
+	if err.Message() != "bad request" {
+		t.Fatalf("expected custom message, got %q", err.Message())
+	}
+	if v := badRequestOf(t, err).FieldViolations[0]; v.Field != "id" || v.Description != "is unknown" {
+		t.Fatalf("unexpected violation: %v", v)
+	}
+	if ToError(context.Background(), errors.New("boom"), "").Message() != "validation failed" {
+		t.Fatalf("expected default message")
+	}
+}
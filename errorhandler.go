@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"errors"
+	"net/http"
+
+	globalValidator "github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+// ErrorHandler returns a flash error handler that renders validation errors
+// returned by handlers, so a handler can simply return validate.Struct(in):
+//
+//	app.SetErrorHandler(validator.ErrorHandler(nil))
+//	app.POST("/users", func(c flash.Ctx) error {
+//		var in CreateUser
+//		if err := c.BindJSON(&in); err != nil {
+//			fields := validate.ToFieldErrorsWithContext(c.Context(), err)
+//			return validate.NewValidationError(validate.FieldErrors(fields), http.StatusBadRequest)
+//		}
+//		if err := validate.StructCtx(c.Context(), in); err != nil {
+//			return err
+//		}
+//		...
+//	})
+//
+// validator.ValidationErrors, validate.FieldErrors, *validate.BindingError and
+// *validate.ValidationError (also when wrapped with fmt.Errorf) get the Enforce response: {"message": "validation
+// failed", "fields": ..., "errors": ...} with status 422 or the wrapper's
+// status. Other errors go to next, or get a 500 when next is nil.
+func ErrorHandler(next flash.ErrorHandler) flash.ErrorHandler {
+	return func(c flash.Ctx, err error) {
+		verr, status, ok := validationError(err)
+		if !ok {
+			if next != nil {
+				next(c, err)
+			} else if !c.WroteHeader() {
+				_ = c.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			}
+			return
+		}
+		if c.WroteHeader() {
+			return
+		}
+		_ = c.Status(status).JSON(map[string]any{
+			"message": "validation failed",
+			"fields":  validate.ToFieldErrorsWithContext(c.Context(), verr),
+			"errors":  validate.ToFieldErrorDetails(c.Context(), verr),
+		})
+	}
+}
+
+// validationError finds a validation error in err's chain, returning the
+// error the converters understand and its status. Errors wrapped in
+// *validate.ValidationError always count as validation errors.
+func validationError(err error) (error, int, bool) {
+	var wrapped *validate.ValidationError
+	if errors.As(err, &wrapped) {
+		inner := wrapped.Err
+		var vErrs globalValidator.ValidationErrors
+		if errors.As(inner, &vErrs) {
+			inner = vErrs
+		}
+		return inner, wrapped.StatusCode(), true
+	}
+	var be *validate.BindingError
+	if errors.As(err, &be) {
+		return be, be.StatusCode(), true
+	}
+	var vErrs globalValidator.ValidationErrors
+	if errors.As(err, &vErrs) {
+		return vErrs, http.StatusUnprocessableEntity, true
+	}
+	var fields validate.FieldErrors
+	if errors.As(err, &fields) {
+		return fields, fields.StatusCode(), true
+	}
+	return nil, 0, false
+}
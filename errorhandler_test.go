@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+func TestErrorHandler(t *testing.T) {
+	var fallback error
+	app := flash.New()
+	app.SetErrorHandler(ErrorHandler(func(c flash.Ctx, err error) {
+		fallback = err
+		_ = c.String(http.StatusTeapot, "fallback")
+	}))
+	app.POST("/struct", func(c flash.Ctx) error {
+		return fmt.Errorf("create user: %w", validate.Struct(createUser{Name: "Ann"}))
+	})
+	app.POST("/fields", func(c flash.Ctx) error {
+		return validate.FieldErrors{"sku": "is unknown"}
+	})
+	app.POST("/status", func(c flash.Ctx) error {
+		return validate.NewValidationError(validate.FieldErrors{"body": "is malformed"}, http.StatusBadRequest)
+	})
+	app.POST("/other", func(c flash.Ctx) error { return errors.New("boom") })
+
+	for _, tc := range []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/struct", http.StatusUnprocessableEntity, `"fields":{"email":"is required"}`},
+		{"/fields", http.StatusUnprocessableEntity, `"fields":{"sku":"is unknown"}`},
+		{"/status", http.StatusBadRequest, `"fields":{"body":"is malformed"}`},
+		{"/other", http.StatusTeapot, "fallback"},
+	} {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tc.path, nil))
+		if rec.Code != tc.status || !strings.Contains(rec.Body.String(), tc.body) {
+			t.Fatalf("%s: expected %d with %s, got %d %s", tc.path, tc.status, tc.body, rec.Code, rec.Body.String())
+		}
+	}
+	if fallback == nil || fallback.Error() != "boom" {
+		t.Fatalf("expected other errors to reach the fallback, got %v", fallback)
+	}
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/struct", nil))
+	if !strings.Contains(rec.Body.String(), `"code":"REQUIRED"`) {
+		t.Fatalf("expected error details, got %s", rec.Body.String())
+	}
+}
+
+func TestErrorHandler_DefaultFallback(t *testing.T) {
+	app := flash.New()
+	app.SetErrorHandler(ErrorHandler(nil))
+	app.GET("/", func(c flash.Ctx) error { return errors.New("boom") })
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+}
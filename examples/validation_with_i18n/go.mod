@@ -5,15 +5,15 @@ go 1.23.10
 replace github.com/goflash/validator/v2 => ../..
 
 require (
-	github.com/go-playground/locales v0.14.1
-	github.com/go-playground/universal-translator v0.18.1
-	github.com/go-playground/validator/v10 v10.27.0
 	github.com/goflash/flash/v2 v2.0.0-beta.6
 	github.com/goflash/validator/v2 v2.0.0-00010101000000-000000000000
 )
 
 require (
 	github.com/gabriel-vasile/mimetype v1.4.8 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/go-playground/validator/v10 v10.27.0 // indirect
 	github.com/julienschmidt/httprouter v1.3.0 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
 	github.com/mitchellh/mapstructure v1.5.0 // indirect
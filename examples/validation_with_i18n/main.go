@@ -4,17 +4,9 @@ import (
 	"log"
 	"net/http"
 
-	v10 "github.com/go-playground/validator/v10"
 	"github.com/goflash/flash/v2"
 	mw "github.com/goflash/validator/v2"
 	"github.com/goflash/validator/v2/validate"
-
-	// App-level i18n wiring
-	"github.com/go-playground/locales/en"
-	"github.com/go-playground/locales/es"
-	ut "github.com/go-playground/universal-translator"
-	enTranslations "github.com/go-playground/validator/v10/translations/en"
-	esTranslations "github.com/go-playground/validator/v10/translations/es"
 )
 
 // User represents a user for the validation example.
@@ -24,37 +16,18 @@ type User struct {
 }
 
 func main() {
-	// Prepare translators
-	enLoc := en.New()
-	esLoc := es.New()
-	uni := ut.New(enLoc, enLoc, esLoc)
-
-	translators := map[string]ut.Translator{}
-	if t, ok := uni.GetTranslator("en"); ok {
-		_ = enTranslations.RegisterDefaultTranslations(validate.Validator, t)
-		translators["en"] = t
-	}
-	if t, ok := uni.GetTranslator("es"); ok {
-		_ = esTranslations.RegisterDefaultTranslations(validate.Validator, t)
-		translators["es"] = t
+	// Build translators for en and es and register the default validator
+	// translations; en is the default locale.
+	cfg, err := mw.NewI18n("en", "es")
+	if err != nil {
+		log.Fatal(err)
 	}
+	cfg.SetGlobal = true // optional: set the engine's fallback to DefaultLocale
 
 	app := flash.New()
 
-	// Install validator i18n middleware: derive locale from :lang and attach translator function.
-	app.Use(mw.ValidatorI18n(mw.ValidatorI18nConfig{
-		DefaultLocale: "en",
-		MessageFuncFor: func(locale string) func(v10.FieldError) string {
-			if trans, ok := translators[locale]; ok {
-				return func(fe v10.FieldError) string { return fe.Translate(trans) }
-			}
-			if trans, ok := translators["en"]; ok {
-				return func(fe v10.FieldError) string { return fe.Translate(trans) }
-			}
-			return nil
-		},
-		SetGlobal: true, // optional: set global fallback to DefaultLocale
-	}))
+	// Install validator i18n middleware: derive locale from :lang and attach the translator.
+	app.Use(mw.ValidatorI18n(cfg))
 
 	// POST /<lang>/users accepts a JSON user and validates fields using framework validation.
 	app.POST("/:lang/users", func(c flash.Ctx) error {
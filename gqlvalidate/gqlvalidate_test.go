@@ -0,0 +1,91 @@
+package gqlvalidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/goflash/validator/v2/validate"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type newUser struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func fieldsOf(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var gErr *gqlerror.Error
+	if !errors.As(err, &gErr) {
+		t.Fatalf("expected gqlerror, got %v", err)
+	}
+	if gErr.Extensions["code"] != ErrorCode {
+		t.Fatalf("expected code extension, got %v", gErr.Extensions)
+	}
+	fields, ok := gErr.Extensions["fields"].(map[string]string)
+	if !ok {
+		t.Fatalf("expected fields extension, got %v", gErr.Extensions)
+	}
+	return fields
+}
+
+func TestInput(t *testing.T) {
+	fields := fieldsOf(t, Input(context.Background(), newUser{Email: "x"}))
+	if fields["name"] != "is required" || fields["email"] != "must be a valid email" {
+		t.Fatalf("unexpected fields: %v", fields)
+	}
+	if err := Input(context.Background(), newUser{Name: "a", Email: "a@b.co"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInput_UsesContextEngine(t *testing.T) {
+	ctx := validate.WithEngine(context.Background(), validate.NewNoop())
+	if err := Input(ctx, newUser{}); err != nil {
+		t.Fatalf("expected the context engine to be used, got %v", err)
+	}
+	ctx = validate.SkipTagIf(context.Background(), "required", func() bool { return true })
+	if fields := fieldsOf(t, Input(ctx, newUser{Email: "x"})); len(fields) != 1 || fields["email"] == "" {
+		t.Fatalf("expected required to be skipped, got %v", fields)
+	}
+}
+
+func TestToGQLError_IncludesPath(t *testing.T) {
+	ctx := graphql.WithPathContext(context.Background(), graphql.NewPathWithField("input"))
+	e := ToGQLError(ctx, validate.FieldErrors{"id": "is unknown"})
+	if len(e.Path) != 1 || e.Path.String() != "input" {
+		t.Fatalf("unexpected path: %v", e.Path)
+	}
+}
+
+func TestDirective(t *testing.T) {
+	ctx := graphql.WithPathContext(context.Background(), graphql.NewPathWithField("email"))
+	next := func(context.Context) (any, error) { return "nope", nil }
+	_, err := Directive(ctx, nil, next, "required,email")
+	if fields := fieldsOf(t, err); fields["email"] != "must be a valid email" {
+		t.Fatalf("unexpected fields: %v", fields)
+	}
+
+	ok := func(context.Context) (any, error) { return "a@b.co", nil }
+	if v, err := Directive(ctx, nil, ok, "required,email"); err != nil || v != "a@b.co" {
+		t.Fatalf("expected passthrough, got %v, %v", v, err)
+	}
+}
+
+func TestDirective_PropagatesResolverError(t *testing.T) {
+	boom := errors.New("boom")
+	next := func(context.Context) (any, error) { return nil, boom }
+	if _, err := Directive(context.Background(), nil, next, "required"); err != boom {
+		t.Fatalf("expected resolver error, got %v", err)
+	}
+}
+
+func TestDirective_FallbackFieldName(t *testing.T) {
+	next := func(context.Context) (any, error) { return "", nil }
+	_, err := Directive(context.Background(), nil, next, "required")
+	if fields := fieldsOf(t, err); fields["value"] != "is required" {
+		t.Fatalf("unexpected fields: %v", fields)
+	}
+}
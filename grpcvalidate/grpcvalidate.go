@@ -0,0 +1,119 @@
+// Package grpcvalidate provides a gRPC unary server interceptor that validates
+// incoming request messages with the goflash validate helpers.
+//
+// It lives in its own module so the core validator module does not pull in
+// gRPC and protobuf dependencies.
+package grpcvalidate
+
+import (
+	"context"
+	"sort"
+
+	globalValidator "github.com/go-playground/validator/v10"
+	"github.com/goflash/validator/v2/validate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Config configures the validation interceptor. The zero value is usable:
+// requests are validated with validate.StructCtx, so engines and skips on the
+// call context apply, and messages come from the messages registered for the
+// call's locale, the request context (validate.WithMessageFunc), the global
+// SetMessageFunc, or the built-in English fallback, in that order.
+type Config struct {
+	// DefaultLocale used when none is derived from the request.
+	// Default: "en".
+	DefaultLocale string
+	// LocaleFromContext returns the desired locale for a call.
+	// Default: first value of the "accept-language" incoming metadata key.
+	LocaleFromContext func(ctx context.Context) string
+	// MessageFuncFor mirrors validator.ValidatorI18nConfig.MessageFuncFor and
+	// returns a function that translates a FieldError for the given locale.
+	// Optional.
+	MessageFuncFor func(locale string) func(globalValidator.FieldError) string
+	// Validate validates a request message. ctx carries the call's locale
+	// (validate.WithLocale). Default: validate.StructCtx.
+	Validate func(ctx context.Context, req any) error
+	// Message is the status message used for InvalidArgument errors.
+	// Default: "validation failed".
+	Message string
+}
+
+// UnaryServerInterceptor returns an interceptor that validates struct (or
+// pointer to struct) requests before invoking the handler. Validation failures
+// are returned as codes.InvalidArgument with an errdetails.BadRequest detail
+// listing one FieldViolation per invalid field, sorted by field name.
+func UnaryServerInterceptor(cfgs ...Config) grpc.UnaryServerInterceptor {
+	var cfg Config
+	if len(cfgs) > 0 {
+		cfg = cfgs[0]
+	}
+	if cfg.DefaultLocale == "" {
+		cfg.DefaultLocale = "en"
+	}
+	if cfg.LocaleFromContext == nil {
+		cfg.LocaleFromContext = localeFromMetadata
+	}
+	if cfg.Validate == nil {
+		cfg.Validate = validate.StructCtx
+	}
+	if cfg.Message == "" {
+		cfg.Message = "validation failed"
+	}
+
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !validate.IsStruct(req) {
+			return handler(ctx, req)
+		}
+		vctx := validate.WithLocaleMessageFunc(ctx, cfg.LocaleFromContext(ctx), cfg.DefaultLocale, cfg.MessageFuncFor)
+		if err := cfg.Validate(vctx, req); err != nil {
+			return nil, ToStatus(vctx, err, cfg.Message).Err()
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ToStatus converts a validation error into an InvalidArgument status carrying
+// BadRequest field violations. Messages are resolved with
+// validate.ToFieldErrorsWithContext, so request-scoped message functions apply.
+func ToStatus(ctx context.Context, err error, msg string) *status.Status {
+	if msg == "" {
+		msg = "validation failed"
+	}
+	fields := validate.ToFieldErrorsWithContext(ctx, err)
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	br := &errdetails.BadRequest{}
+	for _, k := range keys {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       k,
+			Description: fields[k],
+		})
+	}
+	st := status.New(codes.InvalidArgument, msg)
+	if withDetails, dErr := st.WithDetails(br); dErr == nil {
+		return withDetails
+	}
+	return st
+}
+
+// localeFromMetadata reads the primary language from "accept-language" metadata,
+// e.g. "es-ES,es;q=0.9" -> "es".
+func localeFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	vals := md.Get("accept-language")
+	if len(vals) == 0 {
+		return ""
+	}
+	return validate.PrimaryLanguage(vals[0])
+}
@@ -0,0 +1,150 @@
+package grpcvalidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	globalValidator "github.com/go-playground/validator/v10"
+	"github.com/goflash/validator/v2/validate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type createUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func okHandler(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+func badRequestOf(t *testing.T, err error) *errdetails.BadRequest {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("expected status error, got %v", err)
+	}
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", st.Code())
+	}
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			return br
+		}
+	}
+	t.Fatalf("expected BadRequest detail")
+	return nil
+}
+
+func TestUnaryServerInterceptor_InvalidRequest(t *testing.T) {
+	icpt := UnaryServerInterceptor()
+	_, err := icpt(context.Background(), &createUserRequest{Email: "x"}, &grpc.UnaryServerInfo{}, okHandler)
+	br := badRequestOf(t, err)
+	if len(br.FieldViolations) != 2 {
+		t.Fatalf("expected 2 violations, got %v", br.FieldViolations)
+	}
+	if br.FieldViolations[0].Field != "email" || br.FieldViolations[0].Description != "must be a valid email" {
+		t.Fatalf("unexpected first violation: %v", br.FieldViolations[0])
+	}
+	if br.FieldViolations[1].Field != "name" || br.FieldViolations[1].Description != "is required" {
+		t.Fatalf("unexpected second violation: %v", br.FieldViolations[1])
+	}
+}
+
+func TestUnaryServerInterceptor_ValidRequestCallsHandler(t *testing.T) {
+	icpt := UnaryServerInterceptor()
+	resp, err := icpt(context.Background(), &createUserRequest{Name: "a", Email: "a@b.co"}, &grpc.UnaryServerInfo{}, okHandler)
+	if err != nil || resp != "ok" {
+		t.Fatalf("expected handler result, got %v, %v", resp, err)
+	}
+}
+
+func TestUnaryServerInterceptor_NonStructPassthrough(t *testing.T) {
+	icpt := UnaryServerInterceptor()
+	var nilReq *createUserRequest
+	for _, req := range []any{"plain", nil, nilReq} {
+		if _, err := icpt(context.Background(), req, &grpc.UnaryServerInfo{}, okHandler); err != nil {
+			t.Fatalf("expected passthrough for %v, got %v", req, err)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor_LocaleFromMetadata(t *testing.T) {
+	icpt := UnaryServerInterceptor(Config{
+		MessageFuncFor: func(locale string) func(globalValidator.FieldError) string {
+			if locale == "es" {
+				return func(globalValidator.FieldError) string { return "ES_MSG" }
+			}
+			return nil
+		},
+	})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "es-ES,es;q=0.9"))
+	_, err := icpt(ctx, &createUserRequest{Email: "a@b.co"}, &grpc.UnaryServerInfo{}, okHandler)
+	br := badRequestOf(t, err)
+	if br.FieldViolations[0].Description != "ES_MSG" {
+		t.Fatalf("expected localized message, got %v", br.FieldViolations[0])
+	}
+
+	// Unknown locale without a default func falls back to built-in messages.
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "fr"))
+	_, err = icpt(ctx, &createUserRequest{Email: "a@b.co"}, &grpc.UnaryServerInfo{}, okHandler)
+	br = badRequestOf(t, err)
+	if br.FieldViolations[0].Description != "is required" {
+		t.Fatalf("expected default message, got %v", br.FieldViolations[0])
+	}
+}
+
+func TestUnaryServerInterceptor_ContextEngineAndLocale(t *testing.T) {
+	e := validate.NewEngine()
+	e.RegisterMessages("required", map[string]string{"es": "es obligatorio"})
+	e.RegisterStructValidationMapRules(map[string]string{"Email": "required"}, createUserRequest{})
+	ctx := metadata.NewIncomingContext(validate.WithEngine(context.Background(), e), metadata.Pairs("accept-language", "es"))
+
+	_, err := UnaryServerInterceptor()(ctx, &createUserRequest{Email: "x"}, &grpc.UnaryServerInfo{}, okHandler)
+	br := badRequestOf(t, err)
+	if len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != "name" || br.FieldViolations[0].Description != "es obligatorio" {
+		t.Fatalf("expected the context engine and the es message, got %v", br.FieldViolations)
+	}
+}
+
+func TestUnaryServerInterceptor_ContextMessageFuncWins(t *testing.T) {
+	icpt := UnaryServerInterceptor(Config{
+		MessageFuncFor: func(string) func(globalValidator.FieldError) string {
+			return func(globalValidator.FieldError) string { return "CFG" }
+		},
+	})
+	ctx := validate.WithMessageFunc(context.Background(), func(globalValidator.FieldError) string { return "CTX" })
+	_, err := icpt(ctx, &createUserRequest{Email: "a@b.co"}, &grpc.UnaryServerInfo{}, okHandler)
+	if d := badRequestOf(t, err).FieldViolations[0].Description; d != "CTX" {
+		t.Fatalf("expected request-scoped message, got %q", d)
+	}
+}
+
+func TestUnaryServerInterceptor_CustomValidateAndMessage(t *testing.T) {
+	icpt := UnaryServerInterceptor(Config{
+		Validate: func(context.Context, any) error { return validate.FieldErrors{"id": "is unknown"} },
+		Message:  "bad request",
+	})
+	_, err := icpt(context.Background(), &createUserRequest{}, &grpc.UnaryServerInfo{}, okHandler)
+	st, _ := status.FromError(err)
+	if st.Message() != "bad request" {
+		t.Fatalf("expected custom message, got %q", st.Message())
+	}
+	if v := badRequestOf(t, err).FieldViolations[0]; v.Field != "id" || v.Description != "is unknown" {
+		t.Fatalf("unexpected violation: %v", v)
+	}
+}
+
+func TestToStatus_NonValidationError(t *testing.T) {
+	st := ToStatus(context.Background(), errors.New("boom"), "")
+	if st.Message() != "validation failed" {
+		t.Fatalf("expected default message, got %q", st.Message())
+	}
+	br := badRequestOf(t, st.Err())
+	if br.FieldViolations[0].Field != "_error" || br.FieldViolations[0].Description != "boom" {
+		t.Fatalf("unexpected violation: %v", br.FieldViolations[0])
+	}
+}
@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/goflash/flash/v2"
+)
+
+// Router is where typed routes are registered: a flash.App or a *flash.Group.
+type Router interface {
+	POST(path string, h flash.Handler, mws ...flash.Middleware)
+	PUT(path string, h flash.Handler, mws ...flash.Middleware)
+	PATCH(path string, h flash.Handler, mws ...flash.Middleware)
+}
+
+// POST registers a POST route whose handler receives the bound and validated
+// request body. Invalid requests get the same 422 response as with Enforce
+// and never reach h; route middleware in mws runs after validation (see
+// Require):
+//
+//	validator.POST(app, "/users", func(c flash.Ctx, in CreateUser) error {
+//		return c.Status(http.StatusCreated).JSON(users.Create(in))
+//	})
+func POST[T any](r Router, path string, h func(flash.Ctx, T) error, mws ...flash.Middleware) {
+	POSTWith(r, path, EnforceConfig{}, h, mws...)
+}
+
+// PUT registers a PUT route like POST.
+func PUT[T any](r Router, path string, h func(flash.Ctx, T) error, mws ...flash.Middleware) {
+	PUTWith(r, path, EnforceConfig{}, h, mws...)
+}
+
+// PATCH registers a PATCH route like POST.
+func PATCH[T any](r Router, path string, h func(flash.Ctx, T) error, mws ...flash.Middleware) {
+	PATCHWith(r, path, EnforceConfig{}, h, mws...)
+}
+
+// POSTWith is POST with the binding and error options of cfg (see Require):
+//
+//	validator.POSTWith(app, "/users", validator.EnforceConfig{OnError: renderProblem}, createUser)
+func POSTWith[T any](r Router, path string, cfg EnforceConfig, h func(flash.Ctx, T) error, mws ...flash.Middleware) {
+	r.POST(path, typedHandler(h), typedMiddleware[T](cfg, mws)...)
+}
+
+// PUTWith is PUT with the options of cfg, like POSTWith.
+func PUTWith[T any](r Router, path string, cfg EnforceConfig, h func(flash.Ctx, T) error, mws ...flash.Middleware) {
+	r.PUT(path, typedHandler(h), typedMiddleware[T](cfg, mws)...)
+}
+
+// PATCHWith is PATCH with the options of cfg, like POSTWith.
+func PATCHWith[T any](r Router, path string, cfg EnforceConfig, h func(flash.Ctx, T) error, mws ...flash.Middleware) {
+	r.PATCH(path, typedHandler(h), typedMiddleware[T](cfg, mws)...)
+}
+
+// typedHandler calls h with the T bound by Require. Without one (a Router
+// that drops route middleware, or the handler mounted elsewhere) it returns
+// an error, which the app reports as a 500, instead of calling h.
+func typedHandler[T any](h func(flash.Ctx, T) error) flash.Handler {
+	return func(c flash.Ctx) error {
+		in := Get[T](c)
+		if in == nil {
+			return fmt.Errorf("validator: no %T bound for %s %s; typed routes need Require to run before the handler", in, c.Method(), c.Route())
+		}
+		return h(c, *in)
+	}
+}
+
+// typedMiddleware puts Require[T] in front of the route middleware.
+func typedMiddleware[T any](cfg EnforceConfig, mws []flash.Middleware) []flash.Middleware {
+	return append([]flash.Middleware{Require[T](cfg)}, mws...)
+}
@@ -0,0 +1,100 @@
+package validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+func TestTypedRoutes(t *testing.T) {
+	app := flash.New()
+	handler := func(c flash.Ctx, in createUser) error {
+		return c.String(http.StatusOK, c.Method()+" "+in.Name)
+	}
+	POST(app, "/users", handler)
+	api := app.Group("/api")
+	PUT(api, "/users/:id", handler)
+	order := ""
+	PATCH(api, "/users/:id", handler, func(next flash.Handler) flash.Handler {
+		return func(c flash.Ctx) error {
+			if Get[createUser](c) != nil {
+				order = "validated"
+			}
+			return next(c)
+		}
+	})
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodPost, "/users"},
+		{http.MethodPut, "/api/users/1"},
+		{http.MethodPatch, "/api/users/1"},
+	} {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, strings.NewReader(`{"name":"Ann"}`)))
+		if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), `"email":"is required"`) {
+			t.Fatalf("%s %s: expected 422, got %d %s", tc.method, tc.path, rec.Code, rec.Body.String())
+		}
+
+		rec = httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, strings.NewReader(`{"name":"Ann","email":"a@b.co"}`)))
+		if rec.Code != http.StatusOK || rec.Body.String() != tc.method+" Ann" {
+			t.Fatalf("%s %s: expected handler call, got %d %q", tc.method, tc.path, rec.Code, rec.Body.String())
+		}
+	}
+	if order != "validated" {
+		t.Fatalf("expected route middleware to run after validation")
+	}
+}
+
+func TestTypedRoutesWith(t *testing.T) {
+	app := flash.New()
+	cfg := EnforceConfig{OnError: func(c flash.Ctx, fields validate.FieldErrors) error {
+		return c.String(http.StatusBadRequest, "invalid "+fields["email"])
+	}}
+	handler := func(c flash.Ctx, in createUser) error { return c.String(http.StatusOK, c.Method()+" "+in.Name) }
+	POSTWith(app, "/users", cfg, handler)
+	PUTWith(app, "/users/:id", cfg, handler)
+	PATCHWith(app, "/users/:id", cfg, handler)
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodPost, "/users"},
+		{http.MethodPut, "/users/1"},
+		{http.MethodPatch, "/users/1"},
+	} {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, strings.NewReader(`{"name":"Ann"}`)))
+		if rec.Code != http.StatusBadRequest || rec.Body.String() != "invalid is required" {
+			t.Fatalf("%s %s: expected the configured error response, got %d %s", tc.method, tc.path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+// dropMiddleware is a Router that registers routes without their middleware.
+type dropMiddleware struct{ app flash.App }
+
+func (r dropMiddleware) POST(path string, h flash.Handler, _ ...flash.Middleware) {
+	r.app.POST(path, h)
+}
+
+func (r dropMiddleware) PUT(path string, h flash.Handler, _ ...flash.Middleware) {
+	r.app.PUT(path, h)
+}
+
+func (r dropMiddleware) PATCH(path string, h flash.Handler, _ ...flash.Middleware) {
+	r.app.PATCH(path, h)
+}
+
+func TestTypedRoutes_WithoutPayload(t *testing.T) {
+	app := flash.New()
+	called := false
+	POST(dropMiddleware{app}, "/users", func(flash.Ctx, createUser) error { called = true; return nil })
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{}`)))
+	if called || rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected a 500 without calling the handler, got %d (called=%v)", rec.Code, called)
+	}
+}
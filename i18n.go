@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"fmt"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/goflash/validator/v2/validate"
+)
+
+// NewI18n builds a universal-translator for the given locales, registers the
+// go-playground default translations of each on the default engine (see
+// LoadEngineTranslations, so engines cloned from it translate too) and
+// returns a ready ValidatorI18nConfig.
+// The first locale is the default:
+//
+//	cfg, err := validator.NewI18n("en", "es", "fr")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	app.Use(validator.ValidatorI18n(cfg))
+//
+// Locales without translations (see TranslationLocales) are rejected. The
+// returned config leaves Engine unset, so requests validate with the current
+// engine (validate.SetEngine, test doubles, per-tenant engines); it may be
+// adjusted (e.g. LocaleFromCtx, Engine) before installing the middleware.
+func NewI18n(localeNames ...string) (ValidatorI18nConfig, error) {
+	if len(localeNames) == 0 {
+		return ValidatorI18nConfig{}, fmt.Errorf("validator: NewI18n needs at least one locale")
+	}
+	translators, err := LoadEngineTranslations(validate.Default(), localeNames...)
+	if err != nil {
+		return ValidatorI18nConfig{}, err
+	}
+	names := make([]string, len(localeNames))
+	for i, l := range localeNames {
+		names[i] = normalizeLocale(l)
+	}
+	return ValidatorI18nConfig{
+		DefaultLocale:    names[0],
+		TranslatorFor:    func(locale string) ut.Translator { return translators[normalizeLocale(locale)] },
+		SupportedLocales: names,
+	}, nil
+}
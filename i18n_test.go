@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+func TestNewI18n(t *testing.T) {
+	defer validate.SetSupportedLocales()
+	cfg, err := NewI18n("en", "ES")
+	if err != nil {
+		t.Fatalf("NewI18n: %v", err)
+	}
+	if cfg.DefaultLocale != "en" || cfg.Engine != nil {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if cfg.TranslatorFor("es") == nil || cfg.TranslatorFor("de") != nil {
+		t.Fatalf("expected translators for en and es only")
+	}
+
+	app := flash.New()
+	app.Use(ValidatorI18n(cfg))
+	app.GET("/:lang/u", validateHandler)
+	for lang, want := range map[string]string{
+		"en": "name is a required field",
+		"es": "name es un campo requerido",
+		"de": "name is a required field",
+	} {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+lang+"/u", nil))
+		var body map[string]string
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: decode: %v", lang, err)
+		}
+		if body["name"] != want {
+			t.Fatalf("%s: expected %q, got %q", lang, want, body["name"])
+		}
+	}
+}
+
+func TestNewI18n_Errors(t *testing.T) {
+	if _, err := NewI18n(); err == nil {
+		t.Fatalf("expected error without locales")
+	}
+	if _, err := NewI18n("en", "xx"); err == nil {
+		t.Fatalf("expected error for unknown locale")
+	}
+}
+
+func TestNewI18n_KeepsCurrentEngine(t *testing.T) {
+	defer validate.SetSupportedLocales()
+	cfg, err := NewI18n("en", "es")
+	if err != nil {
+		t.Fatalf("NewI18n: %v", err)
+	}
+	validate.SetEngine(validate.NewNoop())
+	defer validate.SetEngine(nil)
+
+	app := flash.New()
+	app.Use(ValidatorI18n(cfg))
+	app.GET("/:lang/u", func(c flash.Ctx) error {
+		var u struct {
+			Name string `json:"name" validate:"required"`
+		}
+		if err := validate.StructCtx(c.Context(), u); err != nil {
+			return c.JSON(validate.ToFieldErrorsWithContext(c.Context(), err))
+		}
+		return c.String(http.StatusOK, "ok")
+	})
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/es/u", nil))
+	if rec.Body.String() != "ok" {
+		t.Fatalf("expected the noop engine set with SetEngine, got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,143 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	globalValidator "github.com/go-playground/validator/v10"
+	"github.com/goflash/validator/v2/validate"
+)
+
+var errNoMessageFunc = errors.New("validator: locale loader returned no message function")
+
+// LocaleLoader loads the message function of a locale, e.g. from a catalog
+// fetched from remote configuration.
+type LocaleLoader func(locale string) (func(globalValidator.FieldError) string, error)
+
+// LazyLocales loads the message functions of locales in the background on
+// first use, so startup is not blocked on loading dozens of catalogs. Use its
+// MessageFuncFor in ValidatorI18nConfig:
+//
+//	lazy := validator.NewLazyLocales([]string{"en", "de", "ja"}, loadCatalog)
+//	lazy.Preload("en")
+//	app.Use(validator.ValidatorI18n(validator.ValidatorI18nConfig{
+//		DefaultLocale:  "en",
+//		MessageFuncFor: lazy.MessageFuncFor,
+//	}))
+//
+// Until a locale is loaded, MessageFuncFor returns nil, so the middleware
+// falls back to the default locale (and then the built-in messages), and
+// counts validate.MetricLocaleNotReady. Failed loads are retried on the next
+// lookup. Locales not listed are never loaded.
+type LazyLocales struct {
+	// OnReady, if set, is called after each load of a locale with its
+	// result, e.g. to log failures or flip a readiness probe.
+	OnReady func(locale string, err error)
+
+	load    LocaleLoader
+	mu      sync.Mutex
+	locales map[string]*lazyLocale
+}
+
+type lazyLocale struct {
+	mf      func(globalValidator.FieldError) string
+	loading bool
+	ready   chan struct{} // closed once mf is set
+}
+
+// NewLazyLocales returns LazyLocales loading the given locales with load.
+func NewLazyLocales(locales []string, load LocaleLoader) *LazyLocales {
+	l := &LazyLocales{load: load, locales: make(map[string]*lazyLocale, len(locales))}
+	for _, locale := range locales {
+		l.locales[strings.ToLower(locale)] = &lazyLocale{ready: make(chan struct{})}
+	}
+	return l
+}
+
+// MessageFuncFor returns the message function of locale once it is loaded.
+// Before that it starts loading it and returns nil.
+func (l *LazyLocales) MessageFuncFor(locale string) func(globalValidator.FieldError) string {
+	locale = strings.ToLower(locale)
+	l.mu.Lock()
+	s, ok := l.locales[locale]
+	if !ok {
+		l.mu.Unlock()
+		return nil
+	}
+	if mf := s.mf; mf != nil {
+		l.mu.Unlock()
+		return mf
+	}
+	l.startLocked(locale, s)
+	l.mu.Unlock()
+	validate.ReportMetric(validate.MetricLocaleNotReady, map[string]string{"locale": locale})
+	return nil
+}
+
+// Preload starts loading the given locales without waiting for them.
+func (l *LazyLocales) Preload(locales ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, locale := range locales {
+		locale = strings.ToLower(locale)
+		if s, ok := l.locales[locale]; ok && s.mf == nil {
+			l.startLocked(locale, s)
+		}
+	}
+}
+
+// Ready reports whether locale is loaded.
+func (l *LazyLocales) Ready(locale string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s, ok := l.locales[strings.ToLower(locale)]
+	return ok && s.mf != nil
+}
+
+// Wait loads locale if needed and blocks until it is ready or ctx is done.
+// It returns ctx.Err() on timeout and nil for locales not listed.
+func (l *LazyLocales) Wait(ctx context.Context, locale string) error {
+	locale = strings.ToLower(locale)
+	l.mu.Lock()
+	s, ok := l.locales[locale]
+	if !ok {
+		l.mu.Unlock()
+		return nil
+	}
+	if s.mf == nil {
+		l.startLocked(locale, s)
+	}
+	l.mu.Unlock()
+	select {
+	case <-s.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// startLocked loads locale in the background unless a load is running.
+func (l *LazyLocales) startLocked(locale string, s *lazyLocale) {
+	if s.loading {
+		return
+	}
+	s.loading = true
+	go func() {
+		mf, err := l.load(locale)
+		if err == nil && mf == nil {
+			err = errNoMessageFunc
+		}
+		l.mu.Lock()
+		s.loading = false
+		if err == nil {
+			s.mf = mf
+			close(s.ready)
+		}
+		l.mu.Unlock()
+		if l.OnReady != nil {
+			l.OnReady(locale, err)
+		}
+	}()
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	globalValidator "github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+func TestLazyLocales_FallsBackUntilReady(t *testing.T) {
+	var mu sync.Mutex
+	notReady := map[string]int{}
+	validate.SetMetrics(func(metric string, labels map[string]string) {
+		if metric == validate.MetricLocaleNotReady {
+			mu.Lock()
+			notReady[labels["locale"]]++
+			mu.Unlock()
+		}
+	})
+	defer validate.SetMetrics(nil)
+
+	release := make(chan struct{})
+	lazy := NewLazyLocales([]string{"en", "de"}, func(locale string) (func(globalValidator.FieldError) string, error) {
+		if locale == "de" {
+			<-release
+		}
+		return func(globalValidator.FieldError) string { return "MSG_" + locale }, nil
+	})
+	ready := make(chan string, 2)
+	lazy.OnReady = func(locale string, err error) {
+		if err != nil {
+			t.Errorf("load %s: %v", locale, err)
+		}
+		ready <- locale
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := lazy.Wait(ctx, "en"); err != nil {
+		t.Fatalf("wait en: %v", err)
+	}
+	<-ready
+
+	app := flash.New()
+	app.Use(ValidatorI18n(ValidatorI18nConfig{DefaultLocale: "en", MessageFuncFor: lazy.MessageFuncFor}))
+	app.GET("/:lang/u", validateHandler)
+	get := func(lang string) string {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+lang+"/u", nil))
+		return rec.Body.String()
+	}
+
+	if body := get("de"); body != `{"name":"MSG_en"}` {
+		t.Fatalf("expected default locale while de loads, got %q", body)
+	}
+	if lazy.Ready("de") {
+		t.Fatalf("de should not be ready yet")
+	}
+	close(release)
+	if got := <-ready; got != "de" {
+		t.Fatalf("expected de to become ready, got %s", got)
+	}
+	if body := get("de"); body != `{"name":"MSG_de"}` {
+		t.Fatalf("expected de messages once loaded, got %q", body)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if notReady["de"] != 1 {
+		t.Fatalf("expected one not-ready metric for de, got %v", notReady)
+	}
+}
+
+func TestLazyLocales_RetriesFailedLoads(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	lazy := NewLazyLocales([]string{"ja"}, func(string) (func(globalValidator.FieldError) string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("catalog unavailable")
+		}
+		return func(globalValidator.FieldError) string { return "JA" }, nil
+	})
+	results := make(chan error, 2)
+	lazy.OnReady = func(_ string, err error) { results <- err }
+
+	if lazy.MessageFuncFor("ja") != nil {
+		t.Fatalf("expected nil before load")
+	}
+	if err := <-results; err == nil {
+		t.Fatalf("expected first load to fail")
+	}
+	if lazy.MessageFuncFor("ja") != nil {
+		t.Fatalf("expected nil after failed load")
+	}
+	if err := <-results; err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if mf := lazy.MessageFuncFor("JA"); mf == nil || mf(nil) != "JA" {
+		t.Fatalf("expected loaded message func")
+	}
+	if lazy.MessageFuncFor("xx") != nil || lazy.Wait(context.Background(), "xx") != nil {
+		t.Fatalf("expected unlisted locales to be ignored")
+	}
+}
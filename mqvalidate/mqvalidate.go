@@ -0,0 +1,146 @@
+// Package mqvalidate provides consumer-side helpers that decode and validate
+// message-queue event payloads (Kafka, NATS, SQS, ...) with the same struct
+// rules used by the HTTP API.
+//
+// The package is broker-agnostic: adapt your client's message type into a
+// Message and call Consumer.Handle from your subscription callback.
+package mqvalidate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+
+	"github.com/goflash/validator/v2/validate"
+)
+
+// Message is a broker-agnostic view of a consumed event.
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// DeadLetterFunc receives events that failed decoding or validation together
+// with their field -> message map (as produced by validate.ToFieldErrors) and
+// the original error. Returning an error makes Handle return it, so the
+// caller can decide not to ack the message.
+type DeadLetterFunc func(ctx context.Context, msg Message, fields map[string]string, err error) error
+
+// Config configures a Consumer. All fields are optional.
+type Config[T any] struct {
+	// Decode decodes the payload into dst. Default: strict JSON decoding that
+	// rejects unknown fields.
+	Decode func(payload []byte, dst *T) error
+	// Validate validates a decoded event. Default: validate.StructCtx.
+	Validate func(ctx context.Context, v T) error
+	// DeadLetter receives invalid events. When nil, invalid events are dropped
+	// (and counted).
+	DeadLetter DeadLetterFunc
+}
+
+// Stats is a snapshot of consumer counters.
+type Stats struct {
+	// Received counts every Handle call.
+	Received uint64
+	// DecodeFailed counts payloads that could not be decoded.
+	DecodeFailed uint64
+	// Invalid counts decoded events that failed validation.
+	Invalid uint64
+	// DeadLettered counts events delivered to the dead-letter callback without error.
+	DeadLettered uint64
+	// Handled counts events passed to the handler that returned nil.
+	Handled uint64
+	// HandlerFailed counts events for which the handler returned an error.
+	HandlerFailed uint64
+}
+
+// Consumer decodes, validates and dispatches events of type T.
+// It is safe for concurrent use.
+type Consumer[T any] struct {
+	cfg     Config[T]
+	handler func(ctx context.Context, v T, msg Message) error
+
+	received, decodeFailed, invalid, deadLettered, handled, handlerFailed atomic.Uint64
+}
+
+// NewConsumer returns a Consumer that invokes handler for every event that
+// decodes and validates successfully.
+func NewConsumer[T any](handler func(ctx context.Context, v T, msg Message) error, cfgs ...Config[T]) *Consumer[T] {
+	var cfg Config[T]
+	if len(cfgs) > 0 {
+		cfg = cfgs[0]
+	}
+	if cfg.Decode == nil {
+		cfg.Decode = decodeJSON[T]
+	}
+	if cfg.Validate == nil {
+		cfg.Validate = func(ctx context.Context, v T) error { return validate.StructCtx(ctx, v) }
+	}
+	return &Consumer[T]{cfg: cfg, handler: handler}
+}
+
+// Handle processes a single message. Invalid events are routed to the
+// dead-letter callback and Handle returns nil (or the callback's error), so the
+// message can be acknowledged. Handler errors are returned as-is.
+func (c *Consumer[T]) Handle(ctx context.Context, msg Message) error {
+	c.received.Add(1)
+
+	var v T
+	if err := c.cfg.Decode(msg.Value, &v); err != nil {
+		c.decodeFailed.Add(1)
+		return c.deadLetter(ctx, msg, decodeFieldErrors(err), err)
+	}
+	if err := c.cfg.Validate(ctx, v); err != nil {
+		c.invalid.Add(1)
+		return c.deadLetter(ctx, msg, validate.ToFieldErrorsWithContext(ctx, err), err)
+	}
+	if err := c.handler(ctx, v, msg); err != nil {
+		c.handlerFailed.Add(1)
+		return err
+	}
+	c.handled.Add(1)
+	return nil
+}
+
+// Stats returns a snapshot of the consumer counters.
+func (c *Consumer[T]) Stats() Stats {
+	return Stats{
+		Received:      c.received.Load(),
+		DecodeFailed:  c.decodeFailed.Load(),
+		Invalid:       c.invalid.Load(),
+		DeadLettered:  c.deadLettered.Load(),
+		Handled:       c.handled.Load(),
+		HandlerFailed: c.handlerFailed.Load(),
+	}
+}
+
+func (c *Consumer[T]) deadLetter(ctx context.Context, msg Message, fields map[string]string, err error) error {
+	if c.cfg.DeadLetter == nil {
+		return nil
+	}
+	if dErr := c.cfg.DeadLetter(ctx, msg, fields, err); dErr != nil {
+		return dErr
+	}
+	c.deadLettered.Add(1)
+	return nil
+}
+
+// decodeJSON strictly decodes payload into dst.
+func decodeJSON[T any](payload []byte, dst *T) error {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.DisallowUnknownFields()
+	return dec.Decode(dst)
+}
+
+// decodeFieldErrors maps JSON decoding errors to field errors where possible.
+func decodeFieldErrors(err error) map[string]string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return map[string]string{typeErr.Field: "expected " + typeErr.Type.String() + " but got " + typeErr.Value}
+	}
+	return validate.ToFieldErrors(err)
+}
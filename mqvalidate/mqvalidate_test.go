@@ -0,0 +1,124 @@
+package mqvalidate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type orderCreated struct {
+	ID     string `json:"id" validate:"required"`
+	Amount int    `json:"amount" validate:"gt=0"`
+}
+
+type deadLetterRecorder struct {
+	mu     sync.Mutex
+	fields []map[string]string
+}
+
+func (r *deadLetterRecorder) fn(_ context.Context, _ Message, fields map[string]string, _ error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fields = append(r.fields, fields)
+	return nil
+}
+
+func TestConsumer_ValidEventCallsHandler(t *testing.T) {
+	var got orderCreated
+	c := NewConsumer(func(_ context.Context, v orderCreated, _ Message) error { got = v; return nil })
+	if err := c.Handle(context.Background(), Message{Value: []byte(`{"id":"o1","amount":3}`)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ID != "o1" || got.Amount != 3 {
+		t.Fatalf("unexpected event: %+v", got)
+	}
+	if s := c.Stats(); s.Received != 1 || s.Handled != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestConsumer_InvalidEventRoutedToDeadLetter(t *testing.T) {
+	dl := &deadLetterRecorder{}
+	called := false
+	c := NewConsumer(func(context.Context, orderCreated, Message) error { called = true; return nil },
+		Config[orderCreated]{DeadLetter: dl.fn})
+	if err := c.Handle(context.Background(), Message{Value: []byte(`{"amount":0}`)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if called {
+		t.Fatalf("handler must not be called for invalid events")
+	}
+	if len(dl.fields) != 1 || dl.fields[0]["id"] != "is required" || dl.fields[0]["amount"] != "failed gt" {
+		t.Fatalf("unexpected dead-letter fields: %v", dl.fields)
+	}
+	if s := c.Stats(); s.Invalid != 1 || s.DeadLettered != 1 || s.Handled != 0 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestConsumer_DecodeErrors(t *testing.T) {
+	dl := &deadLetterRecorder{}
+	c := NewConsumer(func(context.Context, orderCreated, Message) error { return nil },
+		Config[orderCreated]{DeadLetter: dl.fn})
+	_ = c.Handle(context.Background(), Message{Value: []byte(`{"id":1}`)})
+	_ = c.Handle(context.Background(), Message{Value: []byte(`{"id":"x","extra":true}`)})
+	_ = c.Handle(context.Background(), Message{Value: []byte(`not json`)})
+	if len(dl.fields) != 3 {
+		t.Fatalf("expected 3 dead letters, got %v", dl.fields)
+	}
+	if dl.fields[0]["id"] != "expected string but got number" {
+		t.Fatalf("unexpected type error mapping: %v", dl.fields[0])
+	}
+	if dl.fields[1]["_error"] == "" || dl.fields[2]["_error"] == "" {
+		t.Fatalf("expected _error fallback, got %v", dl.fields[1:])
+	}
+	if s := c.Stats(); s.DecodeFailed != 3 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestConsumer_NoDeadLetterDropsEvent(t *testing.T) {
+	c := NewConsumer(func(context.Context, orderCreated, Message) error { return nil })
+	if err := c.Handle(context.Background(), Message{Value: []byte(`{}`)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := c.Stats(); s.Invalid != 1 || s.DeadLettered != 0 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestConsumer_ErrorsPropagate(t *testing.T) {
+	boom := errors.New("boom")
+	c := NewConsumer(func(context.Context, orderCreated, Message) error { return boom })
+	if err := c.Handle(context.Background(), Message{Value: []byte(`{"id":"o","amount":1}`)}); err != boom {
+		t.Fatalf("expected handler error, got %v", err)
+	}
+	if s := c.Stats(); s.HandlerFailed != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+
+	dlErr := errors.New("dlq down")
+	c = NewConsumer(func(context.Context, orderCreated, Message) error { return nil }, Config[orderCreated]{
+		DeadLetter: func(context.Context, Message, map[string]string, error) error { return dlErr },
+	})
+	if err := c.Handle(context.Background(), Message{Value: []byte(`{}`)}); err != dlErr {
+		t.Fatalf("expected dead-letter error, got %v", err)
+	}
+	if s := c.Stats(); s.DeadLettered != 0 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestConsumer_CustomDecodeAndValidate(t *testing.T) {
+	c := NewConsumer(func(context.Context, orderCreated, Message) error { return nil }, Config[orderCreated]{
+		Decode:   func(p []byte, dst *orderCreated) error { dst.ID = string(p); return nil },
+		Validate: func(_ context.Context, v orderCreated) error { return nil },
+	})
+	if err := c.Handle(context.Background(), Message{Value: []byte("raw")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := c.Stats(); s.Handled != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
@@ -0,0 +1,181 @@
+// Package pbvalidate validates protobuf messages through protoreflect using
+// go-playground validator tags, so dynamic messages (dynamicpb) and generated
+// messages share one rule syntax with the rest of the goflash validate helpers.
+//
+// Rules are looked up per field, first from an optional annotation reader
+// (for applications that declare a custom field option carrying a tag string)
+// and then from a rule map registered by message full name.
+//
+// It lives in its own module so the core validator module does not pull in
+// protobuf dependencies.
+package pbvalidate
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/goflash/validator/v2/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// AnnotationFunc returns the validator tag declared on a field (typically read
+// from a custom field option via proto.GetExtension), or "" if none.
+type AnnotationFunc func(fd protoreflect.FieldDescriptor) string
+
+// Validator validates protobuf messages against registered rules.
+// It is safe for concurrent use.
+type Validator struct {
+	mu          sync.RWMutex
+	rules       map[protoreflect.FullName]map[protoreflect.Name]string
+	annotations AnnotationFunc
+}
+
+// New returns an empty Validator.
+func New() *Validator {
+	return &Validator{rules: map[protoreflect.FullName]map[protoreflect.Name]string{}}
+}
+
+// Default is the package-level Validator used by Register and Validate.
+var Default = New()
+
+// Register sets rules for a message, keyed by proto field name (not JSON name),
+// e.g. Register("acme.v1.CreateUserRequest", map[string]string{"email": "required,email"}).
+// Calling Register again for the same message replaces its rules.
+func Register(message protoreflect.FullName, rules map[string]string) {
+	Default.Register(message, rules)
+}
+
+// SetAnnotationFunc installs the annotation reader on the Default validator.
+func SetAnnotationFunc(fn AnnotationFunc) { Default.SetAnnotationFunc(fn) }
+
+// Validate validates msg with the Default validator.
+func Validate(msg proto.Message) error { return Default.Validate(msg) }
+
+// ValidateCtx validates msg with the Default validator, resolving messages
+// with the request-scoped message function from ctx (see validate.WithMessageFunc).
+func ValidateCtx(ctx context.Context, msg proto.Message) error {
+	return Default.ValidateCtx(ctx, msg)
+}
+
+// Register sets rules for a message, keyed by proto field name.
+func (v *Validator) Register(message protoreflect.FullName, rules map[string]string) {
+	m := make(map[protoreflect.Name]string, len(rules))
+	for k, tag := range rules {
+		m[protoreflect.Name(k)] = tag
+	}
+	v.mu.Lock()
+	v.rules[message] = m
+	v.mu.Unlock()
+}
+
+// SetAnnotationFunc installs a reader for tags declared as field options.
+// Annotations take precedence over registered rules.
+func (v *Validator) SetAnnotationFunc(fn AnnotationFunc) {
+	v.mu.Lock()
+	v.annotations = fn
+	v.mu.Unlock()
+}
+
+// Validate validates msg and returns validate.FieldErrors keyed by proto JSON
+// names (nested fields joined with ".", list elements by index), or nil.
+func (v *Validator) Validate(msg proto.Message) error {
+	return v.ValidateCtx(context.Background(), msg)
+}
+
+// ValidateCtx is like Validate but resolves messages using ctx.
+func (v *Validator) ValidateCtx(ctx context.Context, msg proto.Message) error {
+	if msg == nil {
+		return nil
+	}
+	res := validate.FieldErrors{}
+	v.validateMessage(ctx, msg.ProtoReflect(), "", res)
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+func (v *Validator) validateMessage(ctx context.Context, m protoreflect.Message, prefix string, res validate.FieldErrors) {
+	desc := m.Descriptor()
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		key := prefix + fd.JSONName()
+
+		if tag := v.ruleFor(desc.FullName(), fd); tag != "" {
+			if err := validate.VarCtx(ctx, key, goValue(m, fd), tag); err != nil {
+				for k, msg := range validate.ToFieldErrorsWithContext(ctx, err) {
+					res[k] = msg
+				}
+			}
+		}
+
+		// Recurse into set message fields and lists of messages.
+		if fd.Kind() != protoreflect.MessageKind && fd.Kind() != protoreflect.GroupKind || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		if fd.IsList() {
+			list := m.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				v.validateMessage(ctx, list.Get(j).Message(), key+"."+strconv.Itoa(j)+".", res)
+			}
+			continue
+		}
+		v.validateMessage(ctx, m.Get(fd).Message(), key+".", res)
+	}
+}
+
+func (v *Validator) ruleFor(message protoreflect.FullName, fd protoreflect.FieldDescriptor) string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.annotations != nil {
+		if tag := v.annotations(fd); tag != "" {
+			return tag
+		}
+	}
+	return v.rules[message][fd.Name()]
+}
+
+// goValue converts a field value into a Go value the validator understands:
+// scalars map to their Go types, enums to their number, lists to []any, maps
+// to map[any]any and singular messages to a nil/non-nil pointer so that
+// `required` reports presence.
+func goValue(m protoreflect.Message, fd protoreflect.FieldDescriptor) any {
+	switch {
+	case fd.IsList():
+		list := m.Get(fd).List()
+		out := make([]any, list.Len())
+		for i := range out {
+			out[i] = scalar(fd, list.Get(i))
+		}
+		return out
+	case fd.IsMap():
+		mp := m.Get(fd).Map()
+		out := make(map[any]any, mp.Len())
+		mp.Range(func(k protoreflect.MapKey, val protoreflect.Value) bool {
+			out[k.Interface()] = scalar(fd.MapValue(), val)
+			return true
+		})
+		return out
+	case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+		if !m.Has(fd) {
+			return (*struct{})(nil)
+		}
+		return &struct{}{}
+	default:
+		return scalar(fd, m.Get(fd))
+	}
+}
+
+func scalar(fd protoreflect.FieldDescriptor, val protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		return int32(val.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return &struct{}{}
+	default:
+		return val.Interface()
+	}
+}
@@ -0,0 +1,161 @@
+package pbvalidate
+
+import (
+	"context"
+	"testing"
+
+	globalValidator "github.com/go-playground/validator/v10"
+	"github.com/goflash/validator/v2/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// testDescriptors builds:
+//
+//	message Address { string postal_code = 1; }
+//	message User {
+//	  string email = 1; int32 age = 2; repeated string tags = 3;
+//	  Address home_address = 4; repeated Address addresses = 5;
+//	}
+func testDescriptors(t *testing.T) (user, address protoreflect.MessageDescriptor) {
+	t.Helper()
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	i32 := descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum()
+	msg := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	opt := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	rep := descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("acme.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Address"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("postal_code"), Number: proto.Int32(1), Type: str, Label: opt, JsonName: proto.String("postalCode")},
+				},
+			},
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("email"), Number: proto.Int32(1), Type: str, Label: opt, JsonName: proto.String("email")},
+					{Name: proto.String("age"), Number: proto.Int32(2), Type: i32, Label: opt, JsonName: proto.String("age")},
+					{Name: proto.String("tags"), Number: proto.Int32(3), Type: str, Label: rep, JsonName: proto.String("tags")},
+					{Name: proto.String("home_address"), Number: proto.Int32(4), Type: msg, Label: opt, TypeName: proto.String(".acme.v1.Address"), JsonName: proto.String("homeAddress")},
+					{Name: proto.String("addresses"), Number: proto.Int32(5), Type: msg, Label: rep, TypeName: proto.String(".acme.v1.Address"), JsonName: proto.String("addresses")},
+				},
+			},
+		},
+	}
+	fd, err := protodesc.NewFile(fdp, nil)
+	if err != nil {
+		t.Fatalf("build descriptor: %v", err)
+	}
+	return fd.Messages().ByName("User"), fd.Messages().ByName("Address")
+}
+
+func newValidator() *Validator {
+	v := New()
+	v.Register("acme.v1.User", map[string]string{
+		"email":        "required,email",
+		"age":          "gte=18",
+		"tags":         "min=1",
+		"home_address": "required",
+	})
+	v.Register("acme.v1.Address", map[string]string{"postal_code": "required,len=5"})
+	return v
+}
+
+func TestValidate_ReportsJSONNames(t *testing.T) {
+	userDesc, addrDesc := testDescriptors(t)
+	m := dynamicpb.NewMessage(userDesc)
+	m.Set(userDesc.Fields().ByName("email"), protoreflect.ValueOfString("nope"))
+	m.Set(userDesc.Fields().ByName("age"), protoreflect.ValueOfInt32(10))
+
+	list := m.Mutable(userDesc.Fields().ByName("addresses")).List()
+	bad := dynamicpb.NewMessage(addrDesc)
+	bad.Set(addrDesc.Fields().ByName("postal_code"), protoreflect.ValueOfString("123"))
+	list.Append(protoreflect.ValueOfMessage(bad))
+
+	err := newValidator().Validate(m)
+	fe, ok := err.(validate.FieldErrors)
+	if !ok {
+		t.Fatalf("expected FieldErrors, got %T %v", err, err)
+	}
+	want := map[string]string{
+		"email":                  "must be a valid email",
+		"age":                    "must be greater than or equal to 18",
+		"tags":                   "must be at least 1",
+		"homeAddress":            "is required",
+		"addresses.0.postalCode": "must be length 5",
+	}
+	for k, msg := range want {
+		if fe[k] != msg {
+			t.Fatalf("field %q: expected %q, got %q (all: %v)", k, msg, fe[k], fe)
+		}
+	}
+	if len(fe) != len(want) {
+		t.Fatalf("unexpected extra errors: %v", fe)
+	}
+}
+
+func TestValidate_NestedMessageAndValid(t *testing.T) {
+	userDesc, addrDesc := testDescriptors(t)
+	m := dynamicpb.NewMessage(userDesc)
+	m.Set(userDesc.Fields().ByName("email"), protoreflect.ValueOfString("a@b.co"))
+	m.Set(userDesc.Fields().ByName("age"), protoreflect.ValueOfInt32(30))
+	m.Mutable(userDesc.Fields().ByName("tags")).List().Append(protoreflect.ValueOfString("x"))
+	home := dynamicpb.NewMessage(addrDesc)
+	m.Set(userDesc.Fields().ByName("home_address"), protoreflect.ValueOfMessage(home))
+
+	v := newValidator()
+	fe, _ := v.Validate(m).(validate.FieldErrors)
+	if len(fe) != 1 || fe["homeAddress.postalCode"] != "is required" {
+		t.Fatalf("expected nested error only, got %v", fe)
+	}
+
+	home.Set(addrDesc.Fields().ByName("postal_code"), protoreflect.ValueOfString("12345"))
+	if err := v.Validate(m); err != nil {
+		t.Fatalf("expected valid message, got %v", err)
+	}
+	if err := v.Validate(nil); err != nil {
+		t.Fatalf("expected nil for nil message, got %v", err)
+	}
+}
+
+func TestValidate_AnnotationsTakePrecedence(t *testing.T) {
+	userDesc, _ := testDescriptors(t)
+	v := New()
+	v.Register("acme.v1.User", map[string]string{"email": "required"})
+	v.SetAnnotationFunc(func(fd protoreflect.FieldDescriptor) string {
+		if fd.Name() == "email" {
+			return "email"
+		}
+		return ""
+	})
+	m := dynamicpb.NewMessage(userDesc)
+	m.Set(userDesc.Fields().ByName("email"), protoreflect.ValueOfString("nope"))
+	fe, _ := v.Validate(m).(validate.FieldErrors)
+	if fe["email"] != "must be a valid email" {
+		t.Fatalf("expected annotation rule to apply, got %v", fe)
+	}
+}
+
+func TestDefaultAndContextMessages(t *testing.T) {
+	userDesc, _ := testDescriptors(t)
+	Register("acme.v1.User", map[string]string{"email": "required"})
+	defer Register("acme.v1.User", nil)
+	defer SetAnnotationFunc(nil)
+
+	m := dynamicpb.NewMessage(userDesc)
+	if fe, _ := Validate(m).(validate.FieldErrors); fe["email"] != "is required" {
+		t.Fatalf("expected default validator rule, got %v", fe)
+	}
+	ctx := validate.WithMessageFunc(context.Background(), func(globalValidator.FieldError) string { return "CTX" })
+	if fe, _ := ValidateCtx(ctx, m).(validate.FieldErrors); fe["email"] != "CTX" {
+		t.Fatalf("expected request-scoped message, got %v", fe)
+	}
+}
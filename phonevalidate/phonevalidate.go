@@ -0,0 +1,124 @@
+// Package phonevalidate adds region-aware phone number tags to the goflash
+// validate helpers, backed by github.com/nyaruka/phonenumbers (a Go port of
+// libphonenumber):
+//
+//   - phone: a valid number in international format ("+44 20 7946 0958").
+//   - phone_region=GB: a valid number of that region, in national or
+//     international format.
+//   - phone_region_field=Country: like phone_region, with the ISO 3166-1
+//     alpha-2 region read from a sibling field. An empty region requires a
+//     valid international number.
+//
+// It lives in its own module so the core validator module does not pull in
+// the phone number metadata.
+package phonevalidate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/goflash/validator/v2/validate"
+	"github.com/nyaruka/phonenumbers"
+)
+
+// ErrInvalidNumber is returned by Normalize for numbers that are not valid.
+var ErrInvalidNumber = errors.New("phonevalidate: invalid phone number")
+
+// Register registers the phone tags and their messages on the global
+// validator. Call it once at startup.
+func Register() error { return RegisterOn(validate.Global()) }
+
+// RegisterOn registers the phone tags and their messages on e.
+func RegisterOn(e *validate.DefaultEngine) error {
+	tags := []struct {
+		tag string
+		fn  validator.Func
+		msg string
+	}{
+		{"phone", isPhone, "must be a valid phone number"},
+		{"phone_region", isPhoneForRegion, "must be a valid {param} phone number"},
+		{"phone_region_field", isPhoneForRegionField, "must be a valid phone number for the selected country"},
+	}
+	for _, t := range tags {
+		if err := e.RegisterValidationWithMessage(t.tag, t.fn, map[string]string{validate.DefaultMessageLocale: t.msg}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Normalize returns number in E.164 format ("+442079460958"). region is the
+// ISO 3166-1 alpha-2 region used for numbers in national format; with "" the
+// number must be in international format.
+func Normalize(number, region string) (string, error) {
+	num, ok := parse(number, region)
+	if !ok {
+		return "", ErrInvalidNumber
+	}
+	return phonenumbers.Format(num, phonenumbers.E164), nil
+}
+
+// Region returns the ISO 3166-1 alpha-2 region of a valid international
+// number, or "".
+func Region(number string) string {
+	num, ok := parse(number, "")
+	if !ok {
+		return ""
+	}
+	return phonenumbers.GetRegionCodeForNumber(num)
+}
+
+func isPhone(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	_, ok := parse(fl.Field().String(), "")
+	return ok
+}
+
+func isPhoneForRegion(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	return validForRegion(fl.Field().String(), fl.Param())
+}
+
+func isPhoneForRegionField(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	region, kind, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+	if !found || kind != reflect.String {
+		return false
+	}
+	if strings.TrimSpace(region.String()) == "" {
+		_, ok := parse(fl.Field().String(), "")
+		return ok
+	}
+	return validForRegion(fl.Field().String(), region.String())
+}
+
+// validForRegion reports whether number is a valid number of region.
+func validForRegion(number, region string) bool {
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if len(region) != 2 {
+		return false
+	}
+	num, ok := parse(number, region)
+	return ok && phonenumbers.IsValidNumberForRegion(num, region)
+}
+
+// parse parses and validates number, using region for national formats.
+func parse(number, region string) (*phonenumbers.PhoneNumber, bool) {
+	number = strings.TrimSpace(number)
+	if number == "" || region == "" && !strings.HasPrefix(number, "+") {
+		return nil, false
+	}
+	num, err := phonenumbers.Parse(number, strings.ToUpper(region))
+	if err != nil || !phonenumbers.IsValidNumber(num) {
+		return nil, false
+	}
+	return num, true
+}
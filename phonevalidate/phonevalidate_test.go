@@ -0,0 +1,75 @@
+package phonevalidate
+
+import (
+	"testing"
+
+	"github.com/goflash/validator/v2/validate"
+)
+
+func init() {
+	if err := Register(); err != nil {
+		panic(err)
+	}
+}
+
+func TestPhoneTags(t *testing.T) {
+	cases := []struct {
+		value, tag string
+		ok         bool
+	}{
+		{"+44 20 7946 0958", "phone", true},
+		{"020 7946 0958", "phone", false},
+		{"+1 555", "phone", false},
+		{"020 7946 0958", "phone_region=GB", true},
+		{"+44 20 7946 0958", "phone_region=gb", true},
+		{"+33 1 42 68 53 00", "phone_region=GB", false},
+		{"020 7946 0958", "phone_region=GBR", false},
+		{"not a phone", "phone_region=GB", false},
+	}
+	for _, c := range cases {
+		if err := validate.Var("phone", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%q %s: expected ok=%v, got %v", c.value, c.tag, c.ok, err)
+		}
+	}
+	if got := validate.ToFieldErrors(validate.Var("phone", "1", "phone_region=GB"))["phone"]; got != "must be a valid GB phone number" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestPhoneRegionField(t *testing.T) {
+	type contact struct {
+		Country string `json:"country"`
+		Phone   string `json:"phone" validate:"phone_region_field=Country"`
+	}
+	valid := []contact{
+		{Country: "FR", Phone: "01 42 68 53 00"},
+		{Country: "fr", Phone: "+33 1 42 68 53 00"},
+		{Country: "", Phone: "+44 20 7946 0958"},
+	}
+	for _, c := range valid {
+		if err := validate.Struct(c); err != nil {
+			t.Fatalf("%+v: expected valid, got %v", c, err)
+		}
+	}
+	for _, c := range []contact{{Country: "GB", Phone: "01 42 68 53 00"}, {Country: "", Phone: "020 7946 0958"}} {
+		m := validate.ToFieldErrors(validate.Struct(c))
+		if m["phone"] != "must be a valid phone number for the selected country" {
+			t.Fatalf("%+v: unexpected errors %v", c, m)
+		}
+	}
+}
+
+func TestNormalizeAndRegion(t *testing.T) {
+	if got, err := Normalize("020 7946 0958", "GB"); err != nil || got != "+442079460958" {
+		t.Fatalf("expected E.164, got %q, %v", got, err)
+	}
+	if got, err := Normalize("+1 (650) 253-0000", ""); err != nil || got != "+16502530000" {
+		t.Fatalf("expected E.164, got %q, %v", got, err)
+	}
+	if _, err := Normalize("020 7946 0958", ""); err != ErrInvalidNumber {
+		t.Fatalf("expected ErrInvalidNumber, got %v", err)
+	}
+	if Region("+33 1 42 68 53 00") != "FR" || Region("nope") != "" {
+		t.Fatalf("unexpected regions")
+	}
+}
@@ -0,0 +1,278 @@
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+// RouteSchema describes the request type registered for a route.
+type RouteSchema struct {
+	Method string
+	Path   string
+	// Type is the struct type requests are bound into.
+	Type reflect.Type
+}
+
+// RouteRegistry maps routes (method + path pattern) to request types.
+// It is safe for concurrent use.
+type RouteRegistry struct {
+	mu     sync.RWMutex
+	routes map[string]RouteSchema
+}
+
+// NewRouteRegistry returns an empty RouteRegistry.
+func NewRouteRegistry() *RouteRegistry {
+	return &RouteRegistry{routes: map[string]RouteSchema{}}
+}
+
+// DefaultRoutes is the registry used by ForRoute, Routes and Enforce when no
+// registry is configured.
+var DefaultRoutes = NewRouteRegistry()
+
+// ForRoute registers the request type for a route on DefaultRoutes, e.g.
+// ForRoute("/users", http.MethodPost, User{}). path is the route pattern as
+// registered with flash ("/users/:id"). v may be a struct or a pointer to one.
+func ForRoute(path, method string, v any) { DefaultRoutes.ForRoute(path, method, v) }
+
+// Routes returns the schemas registered on DefaultRoutes.
+func Routes() []RouteSchema { return DefaultRoutes.Routes() }
+
+// ForRoute registers the request type for a route, replacing any previous one.
+// It panics if v is not a struct or pointer to struct.
+func (r *RouteRegistry) ForRoute(path, method string, v any) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validator: ForRoute requires a struct type, got %T", v))
+	}
+	method = strings.ToUpper(method)
+	r.mu.Lock()
+	r.routes[routeKey(method, path)] = RouteSchema{Method: method, Path: path, Type: t}
+	r.mu.Unlock()
+}
+
+// Lookup returns the schema registered for method and path pattern.
+func (r *RouteRegistry) Lookup(method, path string) (RouteSchema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.routes[routeKey(strings.ToUpper(method), path)]
+	return s, ok
+}
+
+// Routes returns all registered schemas sorted by path, then method.
+func (r *RouteRegistry) Routes() []RouteSchema {
+	r.mu.RLock()
+	out := make([]RouteSchema, 0, len(r.routes))
+	for _, s := range r.routes {
+		out = append(out, s)
+	}
+	r.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Path != out[j].Path {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Method < out[j].Method
+	})
+	return out
+}
+
+func routeKey(method, path string) string { return method + " " + path }
+
+// EnforceConfig configures the Enforce middleware.
+type EnforceConfig struct {
+	// Registry to look routes up in. Default: DefaultRoutes.
+	Registry *RouteRegistry
+	// Bind options forwarded to validate.BindAndValidate.
+	Bind validate.BindOptions
+	// Stats records rejected requests. Default: the aggregator installed with
+	// EnableStats, if any.
+	Stats *StatsAggregator
+	// CodePrefix namespaces the error codes of rejected requests, e.g.
+	// "USR_" (see validate.WithErrorCodePrefix). Default: the prefix set by
+	// ErrorCodes, if any.
+	CodePrefix string
+	// GroupErrors adds the messages partitioned by origin to the default
+	// response, as "binding_errors" (malformed payload) or
+	// "validation_errors" (failed rules); see validate.ToErrorGroups.
+	GroupErrors bool
+	// OnError renders a rejected request. fields holds the per-field messages;
+	// ErrorDetails(c) adds codes. Default: 422 with {"message": "validation
+	// failed", "fields": fields, "errors": details}, plus "deprecation" when
+	// the request uses a deprecated API version and "warnings" when it sets
+	// deprecated fields.
+	OnError func(c flash.Ctx, fields validate.FieldErrors) error
+}
+
+// payloadKey, warningsKey, detailsKey and groupsKey store the bound request
+// value, its warnings, its error details and grouped errors on the flash
+// context.
+type (
+	payloadKey  struct{}
+	warningsKey struct{}
+	detailsKey  struct{}
+	groupsKey   struct{}
+)
+
+// Enforce returns middleware that binds and validates the request type
+// registered for the matched route before the handler runs. Invalid requests
+// are rejected without calling the handler; valid ones are available to the
+// handler via Payload, and fields tagged `deprecated` or soft failures via
+// Warnings. Routes without a registration pass through.
+//
+// Install it after ValidatorI18n so messages are localized.
+func Enforce(cfgs ...EnforceConfig) flash.Middleware {
+	cfg := enforceConfig(cfgs)
+	return func(next flash.Handler) flash.Handler {
+		return func(c flash.Ctx) error {
+			schema, ok := cfg.Registry.Lookup(c.Method(), c.Route())
+			if !ok {
+				return next(c)
+			}
+			return cfg.enforce(c, reflect.New(schema.Type).Interface(), next)
+		}
+	}
+}
+
+// Require returns route middleware that binds and validates a T before the
+// rest of the chain runs, so authorization and business middleware after it
+// can rely on a well-formed payload. Invalid requests are rejected like with
+// Enforce; valid ones are available via Get (and Payload):
+//
+//	app.POST("/orders", createOrder, validator.Require[CreateOrder](), authorizeOrder)
+//
+//	func authorizeOrder(next flash.Handler) flash.Handler {
+//		return func(c flash.Ctx) error {
+//			in := validator.Get[CreateOrder](c)
+//			...
+//		}
+//	}
+//
+// The registry in cfgs is not used.
+func Require[T any](cfgs ...EnforceConfig) flash.Middleware {
+	cfg := enforceConfig(cfgs)
+	return func(next flash.Handler) flash.Handler {
+		return func(c flash.Ctx) error {
+			return cfg.enforce(c, new(T), next)
+		}
+	}
+}
+
+// Get returns the T bound by Require (or by Enforce for a route registered
+// with a T), or nil.
+func Get[T any](c flash.Ctx) *T {
+	v, _ := c.Get(payloadKey{}).(*T)
+	return v
+}
+
+// enforceConfig returns the first of cfgs with defaults applied.
+func enforceConfig(cfgs []EnforceConfig) EnforceConfig {
+	var cfg EnforceConfig
+	if len(cfgs) > 0 {
+		cfg = cfgs[0]
+	}
+	if cfg.Registry == nil {
+		cfg.Registry = DefaultRoutes
+	}
+	if cfg.OnError == nil {
+		cfg.OnError = func(c flash.Ctx, fields validate.FieldErrors) error {
+			body := map[string]any{
+				"message": "validation failed",
+				"fields":  fields,
+				"errors":  ErrorDetails(c),
+			}
+			if note := DeprecationNote(c); note != "" {
+				body["deprecation"] = note
+			}
+			if w := Warnings(c); len(w) > 0 {
+				body["warnings"] = w
+			}
+			if cfg.GroupErrors {
+				g := ErrorGroups(c)
+				if len(g.Binding) > 0 {
+					body["binding_errors"] = g.Binding
+				}
+				if len(g.Validation) > 0 {
+					body["validation_errors"] = g.Validation
+				}
+			}
+			return c.Status(http.StatusUnprocessableEntity).JSON(body)
+		}
+	}
+	return cfg
+}
+
+// enforce binds and validates the request into v, a pointer to a struct, and
+// calls next with v stored as the payload, or rejects the request.
+func (cfg EnforceConfig) enforce(c flash.Ctx, v any, next flash.Handler) error {
+	stats := cfg.Stats
+	if stats == nil {
+		stats = defaultStats.Load()
+	}
+	route := c.Method() + " " + c.Route()
+	ctx := validate.WithRoute(validate.CollectWarnings(c.Context()), route)
+	if cfg.CodePrefix != "" {
+		ctx = validate.WithErrorCodePrefix(ctx, cfg.CodePrefix)
+	}
+	c.SetRequest(c.Request().WithContext(ctx))
+	err := validate.BindAndValidate(c, v, cfg.Bind)
+	w := validate.DeprecationWarnings(v)
+	for field, msg := range validate.ContextWarnings(ctx) {
+		if _, ok := w[field]; !ok {
+			w[field] = msg
+		}
+	}
+	if len(w) > 0 {
+		c.Set(warningsKey{}, w)
+	}
+	if err != nil {
+		fields := validate.FieldErrors(validate.ToFieldErrorsWithContext(ctx, err))
+		c.Set(groupsKey{}, validate.ToErrorGroups(ctx, err))
+		c.Set(detailsKey{}, validate.ToFieldErrorDetails(ctx, err))
+		if stats != nil {
+			stats.Record(route, err)
+		}
+		return cfg.OnError(c, fields)
+	}
+	c.Set(payloadKey{}, v)
+	return next(c)
+}
+
+// Payload returns the request value bound by Enforce: a pointer to the
+// registered struct type, or nil if the route has no registration.
+func Payload(c flash.Ctx) any {
+	return c.Get(payloadKey{})
+}
+
+// Warnings returns the deprecation warnings (see validate.DeprecationWarnings)
+// of the request value bound by Enforce, merged with warnings reported during
+// validation (see validate.CollectWarnings), or nil. Include them in responses to
+// help migrate clients:
+//
+//	return c.JSON(map[string]any{"data": out, "warnings": validator.Warnings(c)})
+func Warnings(c flash.Ctx) map[string]string {
+	w, _ := c.Get(warningsKey{}).(map[string]string)
+	return w
+}
+
+// ErrorGroups returns the messages of the request rejected by Enforce,
+// partitioned into binding and validation errors.
+func ErrorGroups(c flash.Ctx) validate.ErrorGroups {
+	g, _ := c.Get(groupsKey{}).(validate.ErrorGroups)
+	return g
+}
+
+// ErrorDetails returns the details (field, code, tag and message) of the
+// request rejected by Enforce, for custom OnError renderers, or nil.
+func ErrorDetails(c flash.Ctx) []validate.FieldErrorDetail {
+	d, _ := c.Get(detailsKey{}).([]validate.FieldErrorDetail)
+	return d
+}
@@ -0,0 +1,254 @@
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+type createUser struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func TestEnforce_RejectsAndBinds(t *testing.T) {
+	reg := NewRouteRegistry()
+	reg.ForRoute("/users/:org", http.MethodPost, &createUser{})
+
+	called := 0
+	app := flash.New()
+	app.Use(Enforce(EnforceConfig{Registry: reg}))
+	app.POST("/users/:org", func(c flash.Ctx) error {
+		called++
+		in := Payload(c).(*createUser)
+		return c.String(http.StatusCreated, in.Name)
+	})
+	app.GET("/users/:org", func(c flash.Ctx) error {
+		if Payload(c) != nil {
+			t.Fatalf("expected no payload for unregistered route")
+		}
+		return c.String(http.StatusOK, "list")
+	})
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/acme", strings.NewReader(`{"email":"x"}`)))
+	if rec.Code != http.StatusUnprocessableEntity || called != 0 {
+		t.Fatalf("expected 422 without handler call, got %d (called %d)", rec.Code, called)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `"name":"is required"`) || !strings.Contains(body, `"email":"must be a valid email"`) {
+		t.Fatalf("unexpected body: %s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/acme", strings.NewReader(`{"name":"Ann","email":"a@b.co"}`)))
+	if rec.Code != http.StatusCreated || rec.Body.String() != "Ann" {
+		t.Fatalf("expected handler to receive payload, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/acme", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected passthrough, got %d", rec.Code)
+	}
+}
+
+func TestEnforce_CustomOnError(t *testing.T) {
+	reg := NewRouteRegistry()
+	reg.ForRoute("/x", "post", createUser{})
+	app := flash.New()
+	app.Use(Enforce(EnforceConfig{Registry: reg, OnError: func(c flash.Ctx, fields validate.FieldErrors) error {
+		return c.String(http.StatusBadRequest, fields["name"])
+	}}))
+	app.POST("/x", func(c flash.Ctx) error { return nil })
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/x", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusBadRequest || rec.Body.String() != "is required" {
+		t.Fatalf("unexpected response: %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRouteRegistry_RoutesAndDefault(t *testing.T) {
+	reg := NewRouteRegistry()
+	reg.ForRoute("/b", http.MethodPost, createUser{})
+	reg.ForRoute("/a", http.MethodPut, createUser{})
+	reg.ForRoute("/a", http.MethodPatch, createUser{})
+	got := reg.Routes()
+	if len(got) != 3 || got[0].Method != "PATCH" || got[1].Method != "PUT" || got[2].Path != "/b" {
+		t.Fatalf("unexpected order: %+v", got)
+	}
+	if got[0].Type.Name() != "createUser" {
+		t.Fatalf("unexpected type: %v", got[0].Type)
+	}
+
+	ForRoute("/default", http.MethodPost, createUser{})
+	if _, ok := DefaultRoutes.Lookup("post", "/default"); !ok || len(Routes()) == 0 {
+		t.Fatalf("expected default registry entry")
+	}
+
+	for _, v := range []any{1, nil, (*int)(nil)} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || !strings.HasPrefix(fmt.Sprint(r), "validator: ForRoute requires a struct type") {
+					t.Fatalf("expected ForRoute panic for %T, got %v", v, r)
+				}
+			}()
+			reg.ForRoute("/bad", http.MethodPost, v)
+		}()
+	}
+}
+
+type renameReq struct {
+	Name     string `json:"name" validate:"required"`
+	Username string `json:"username" deprecated:"use name"`
+}
+
+func TestEnforce_DeprecationWarnings(t *testing.T) {
+	reg := NewRouteRegistry()
+	reg.ForRoute("/rename", http.MethodPost, renameReq{})
+	app := flash.New()
+	app.Use(Enforce(EnforceConfig{Registry: reg}))
+	app.POST("/rename", func(c flash.Ctx) error {
+		return c.JSON(map[string]any{"warnings": Warnings(c)})
+	})
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/rename", strings.NewReader(`{"name":"a","username":"b"}`)))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"warnings":{"username":"use name"}`) {
+		t.Fatalf("expected warnings on success, got %d %s", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/rename", strings.NewReader(`{"username":"b"}`)))
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), `"warnings":{"username":"use name"}`) {
+		t.Fatalf("expected warnings in error payload, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+type nicknameReq struct {
+	Nickname string `json:"nickname" validate:"required"`
+}
+
+func init() {
+	validate.RegisterStructRule(func(r nicknameReq, rep *validate.StructReporter) {
+		if len(r.Nickname) > 5 {
+			rep.AddWarning("nickname", "will be truncated")
+		}
+	})
+}
+
+func TestEnforce_ValidationWarnings(t *testing.T) {
+	reg := NewRouteRegistry()
+	reg.ForRoute("/nick", http.MethodPost, nicknameReq{})
+	app := flash.New()
+	app.Use(Enforce(EnforceConfig{Registry: reg}))
+	app.POST("/nick", func(c flash.Ctx) error {
+		return c.JSON(map[string]any{"warnings": Warnings(c)})
+	})
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/nick", strings.NewReader(`{"nickname":"abcdefgh"}`)))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"warnings":{"nickname":"will be truncated"}`) {
+		t.Fatalf("expected validation warnings, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestRequire_ValidatesBeforeMiddleware(t *testing.T) {
+	authorized := 0
+	authorize := func(next flash.Handler) flash.Handler {
+		return func(c flash.Ctx) error {
+			in := Get[createUser](c)
+			if in == nil {
+				t.Fatalf("expected bound payload in downstream middleware")
+			}
+			authorized++
+			if in.Name == "mallory" {
+				return c.String(http.StatusForbidden, "forbidden")
+			}
+			return next(c)
+		}
+	}
+	app := flash.New()
+	app.POST("/users", func(c flash.Ctx) error {
+		return c.String(http.StatusCreated, Get[createUser](c).Email)
+	}, Require[createUser](), authorize)
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Ann"}`)))
+	if rec.Code != http.StatusUnprocessableEntity || authorized != 0 {
+		t.Fatalf("expected 422 before authorization, got %d (authorized %d)", rec.Code, authorized)
+	}
+	if !strings.Contains(rec.Body.String(), `"email":"is required"`) {
+		t.Fatalf("unexpected body: %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"mallory","email":"m@x.io"}`)))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected authorization to see the payload, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Ann","email":"a@b.co"}`)))
+	if rec.Code != http.StatusCreated || rec.Body.String() != "a@b.co" {
+		t.Fatalf("expected handler to get payload, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGet_WrongType(t *testing.T) {
+	app := flash.New()
+	app.POST("/users", func(c flash.Ctx) error {
+		if Get[createUser](c) == nil || Get[struct{ X int }](c) != nil {
+			t.Fatalf("expected Get to match the bound type only")
+		}
+		return nil
+	}, Require[createUser]())
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Ann","email":"a@b.co"}`)))
+}
+
+func TestEnforce_GroupErrors(t *testing.T) {
+	app := flash.New()
+	app.POST("/users", func(c flash.Ctx) error { return nil }, Require[createUser](EnforceConfig{GroupErrors: true}))
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":1}`)))
+	if body := rec.Body.String(); !strings.Contains(body, `"binding_errors":{"name":`) || strings.Contains(body, "validation_errors") {
+		t.Fatalf("expected binding errors only, got %s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Ann"}`)))
+	if body := rec.Body.String(); !strings.Contains(body, `"validation_errors":{"email":"is required"}`) || strings.Contains(body, "binding_errors") {
+		t.Fatalf("expected validation errors only, got %s", body)
+	}
+
+	// Coercion failures are binding errors; rules on the other fields still
+	// count as validation errors.
+	type searchQuery struct {
+		Page int    `json:"page"`
+		Q    string `json:"q" validate:"required"`
+	}
+	app.GET("/search", func(c flash.Ctx) error { return nil }, Require[searchQuery](EnforceConfig{GroupErrors: true}))
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search?page=x", nil))
+	if body := rec.Body.String(); !strings.Contains(body, `"binding_errors":{"page":"must be an integer"}`) || !strings.Contains(body, `"validation_errors":{"q":"is required"}`) {
+		t.Fatalf("expected coercion and validation errors apart, got %s", body)
+	}
+
+	// Without GroupErrors the body is unchanged, but ErrorGroups is set.
+	var groups validate.ErrorGroups
+	app.POST("/plain", func(c flash.Ctx) error { return nil }, Require[createUser](EnforceConfig{
+		OnError: func(c flash.Ctx, fields validate.FieldErrors) error {
+			groups = ErrorGroups(c)
+			return c.String(http.StatusUnprocessableEntity, "invalid")
+		},
+	}))
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/plain", strings.NewReader(`{"name":"Ann"}`)))
+	if groups.Validation["email"] != "is required" {
+		t.Fatalf("expected groups for custom renderers, got %+v", groups)
+	}
+}
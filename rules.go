@@ -0,0 +1,178 @@
+package validator
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+// RouteRules is the JSON description of a route served by RulesHandler.
+type RouteRules struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Type   string      `json:"type"`
+	Fields []FieldRule `json:"fields"`
+}
+
+// FieldRule describes one request field and its constraints.
+type FieldRule struct {
+	// Name is the JSON name of the field.
+	Name string `json:"name"`
+	// Type is a JSON-schema-like type: string, integer, number, boolean,
+	// array, object or the Go type name for anything else.
+	Type string `json:"type"`
+	// Rules is the raw validate tag.
+	Rules string `json:"rules,omitempty"`
+	// Constraints is Rules split into individual tags.
+	Constraints []Constraint `json:"constraints,omitempty"`
+	// Fields describes nested structs (or slice elements that are structs).
+	Fields []FieldRule `json:"fields,omitempty"`
+}
+
+// Constraint is a single validation tag and its parameter.
+type Constraint struct {
+	Tag   string `json:"tag"`
+	Param string `json:"param,omitempty"`
+}
+
+// RulesHandler returns a handler that serves the request schemas of every
+// route in reg (DefaultRoutes when nil) as JSON, for internal tooling and
+// client generation. Mount it at e.g. "/_validation/rules".
+func RulesHandler(reg *RouteRegistry) flash.Handler {
+	if reg == nil {
+		reg = DefaultRoutes
+	}
+	return func(c flash.Ctx) error {
+		return c.Status(http.StatusOK).JSON(reg.Describe())
+	}
+}
+
+// CatalogHandler returns a handler that serves the catalog of possible
+// validation errors (see validate.Catalog) as JSON, with each tag's code,
+// message template and translations. Mount it next to RulesHandler, e.g. at
+// "/_validation/errors".
+func CatalogHandler() flash.Handler {
+	return func(c flash.Ctx) error {
+		return c.Status(http.StatusOK).JSON(validate.Catalog())
+	}
+}
+
+// Describe returns the rules of every registered route, sorted like Routes.
+func (r *RouteRegistry) Describe() []RouteRules {
+	routes := r.Routes()
+	out := make([]RouteRules, 0, len(routes))
+	for _, s := range routes {
+		out = append(out, RouteRules{
+			Method: s.Method,
+			Path:   s.Path,
+			Type:   s.Type.String(),
+			Fields: describeStruct(s.Type, map[reflect.Type]bool{}),
+		})
+	}
+	return out
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// describeStruct lists the fields of t. seen guards against recursive types.
+func describeStruct(t reflect.Type, seen map[reflect.Type]bool) []FieldRule {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	fields := []FieldRule{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		ft := indirect(sf.Type)
+		if sf.Anonymous && ft.Kind() == reflect.Struct {
+			fields = append(fields, describeStruct(ft, seen)...)
+			continue
+		}
+		name := jsonFieldName(sf)
+		if name == "" {
+			continue
+		}
+		fr := FieldRule{Name: name, Type: schemaType(ft), Rules: sf.Tag.Get("validate")}
+		fr.Constraints = parseConstraints(fr.Rules)
+		if elem := indirect(elemType(ft)); elem.Kind() == reflect.Struct && elem != timeType {
+			fr.Fields = describeStruct(elem, seen)
+		}
+		fields = append(fields, fr)
+	}
+	return fields
+}
+
+func parseConstraints(tag string) []Constraint {
+	if tag == "" || tag == "-" {
+		return nil
+	}
+	var out []Constraint
+	for _, part := range strings.Split(tag, ",") {
+		if part == "" {
+			continue
+		}
+		c := Constraint{Tag: part}
+		if idx := strings.Index(part, "="); idx >= 0 {
+			c.Tag, c.Param = part[:idx], part[idx+1:]
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func schemaType(t reflect.Type) string {
+	if t == timeType {
+		return "string"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	}
+	return t.String()
+}
+
+func elemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		return t.Elem()
+	}
+	return t
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// jsonFieldName returns the JSON name of a field, or "" for json:"-".
+func jsonFieldName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return sf.Name
+	}
+	return name
+}
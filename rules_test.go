@@ -0,0 +1,94 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+type address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type node struct {
+	Children []*node `json:"children"`
+}
+
+type orderReq struct {
+	ID        int       `json:"id" validate:"gte=1"`
+	Price     float64   `json:"price"`
+	Paid      bool      `json:"paid"`
+	Tags      []string  `json:"tags,omitempty" validate:"max=3,dive,required"`
+	Ship      *address  `json:"ship" validate:"required"`
+	CreatedAt time.Time `json:"created_at"`
+	Tree      node      `json:"tree"`
+	Internal  string    `json:"-"`
+}
+
+func TestRulesHandler(t *testing.T) {
+	reg := NewRouteRegistry()
+	reg.ForRoute("/orders", http.MethodPost, orderReq{})
+
+	app := flash.New()
+	app.GET("/_validation/rules", RulesHandler(reg))
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/_validation/rules", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+
+	var got []RouteRules
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 1 || got[0].Method != "POST" || got[0].Path != "/orders" || got[0].Type != "validator.orderReq" {
+		t.Fatalf("unexpected routes: %+v", got)
+	}
+	byName := map[string]FieldRule{}
+	for _, f := range got[0].Fields {
+		byName[f.Name] = f
+	}
+	if len(byName) != 7 {
+		t.Fatalf("expected 7 fields, got %+v", got[0].Fields)
+	}
+	wantTypes := map[string]string{"id": "integer", "price": "number", "paid": "boolean", "tags": "array", "ship": "object", "created_at": "string"}
+	for name, typ := range wantTypes {
+		if byName[name].Type != typ {
+			t.Fatalf("field %s: expected type %s, got %s", name, typ, byName[name].Type)
+		}
+	}
+	if c := byName["id"].Constraints; len(c) != 1 || c[0].Tag != "gte" || c[0].Param != "1" {
+		t.Fatalf("unexpected constraints: %+v", c)
+	}
+	if c := byName["tags"].Constraints; len(c) != 3 || c[1].Tag != "dive" {
+		t.Fatalf("unexpected constraints: %+v", c)
+	}
+	if f := byName["ship"].Fields; len(f) != 1 || f[0].Name != "city" || f[0].Rules != "required" {
+		t.Fatalf("unexpected nested fields: %+v", f)
+	}
+	if f := byName["tree"].Fields; len(f) != 1 || f[0].Fields != nil {
+		t.Fatalf("expected recursion to stop, got %+v", f)
+	}
+}
+
+func TestCatalogHandler(t *testing.T) {
+	app := flash.New()
+	app.GET("/_validation/errors", CatalogHandler())
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/_validation/errors", nil))
+	var got []validate.CatalogEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+	for _, e := range got {
+		if e.Tag == "required" && e.Code == "REQUIRED" && e.Template == "is required" {
+			return
+		}
+	}
+	t.Fatalf("expected required in catalog, got %+v", got)
+}
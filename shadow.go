@@ -0,0 +1,71 @@
+package validator
+
+import (
+	"errors"
+	"log/slog"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+// ShadowConfig configures the Shadow middleware.
+type ShadowConfig struct {
+	// Engine holds the new, stricter rules, typically a clone of the global
+	// engine with extra registrations. Required.
+	Engine validate.Engine
+	// Routes limits shadow validation to these route patterns ("/users/:id").
+	// Empty means every route with an Enforce payload.
+	Routes []string
+	// Logger receives one warning per failing request. Default: slog.Default().
+	Logger *slog.Logger
+}
+
+// Shadow returns middleware that evaluates the request value bound by Enforce
+// against cfg.Engine without affecting the response: failures are logged and
+// counted (validate.MetricShadowFailure) but never returned to clients. Use it
+// to measure breakage before enforcing a rule change. Install it after Enforce.
+func Shadow(cfg ShadowConfig) flash.Middleware {
+	if cfg.Engine == nil {
+		// No-op middleware if misconfigured
+		return func(next flash.Handler) flash.Handler { return next }
+	}
+	var only map[string]bool
+	if len(cfg.Routes) > 0 {
+		only = make(map[string]bool, len(cfg.Routes))
+		for _, r := range cfg.Routes {
+			only[r] = true
+		}
+	}
+
+	return func(next flash.Handler) flash.Handler {
+		return func(c flash.Ctx) error {
+			v := Payload(c)
+			if v == nil || only != nil && !only[c.Route()] {
+				return next(c)
+			}
+			if err := cfg.Engine.StructCtx(c.Context(), v); err != nil {
+				reportShadow(c, cfg.Logger, err)
+			}
+			return next(c)
+		}
+	}
+}
+
+// reportShadow logs and counts a shadow failure.
+func reportShadow(c flash.Ctx, logger *slog.Logger, err error) {
+	route := c.Method() + " " + c.Route()
+	var vErrs validator.ValidationErrors
+	if errors.As(err, &vErrs) {
+		for _, fe := range vErrs {
+			validate.ReportMetric(validate.MetricShadowFailure, map[string]string{
+				"route": route, "field": fe.Field(), "tag": fe.Tag(),
+			})
+		}
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.WarnContext(c.Context(), "shadow validation failed",
+		"route", route, "fields", validate.ToFieldErrorsWithContext(c.Context(), err))
+}
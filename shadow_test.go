@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+type commentReq struct {
+	Body string `json:"body" validate:"required"`
+}
+
+func TestShadow_LogsWithoutRejecting(t *testing.T) {
+	strict := validate.Global().Clone()
+	strict.RegisterStructValidationMapRules(map[string]string{"Body": "required,min=10"}, commentReq{})
+
+	var logs bytes.Buffer
+	var metrics []map[string]string
+	validate.SetMetrics(func(metric string, labels map[string]string) {
+		if metric == validate.MetricShadowFailure {
+			metrics = append(metrics, labels)
+		}
+	})
+	defer validate.SetMetrics(nil)
+
+	reg := NewRouteRegistry()
+	reg.ForRoute("/comments", http.MethodPost, commentReq{})
+	reg.ForRoute("/drafts", http.MethodPost, commentReq{})
+	app := flash.New()
+	app.Use(Enforce(EnforceConfig{Registry: reg}), Shadow(ShadowConfig{
+		Engine: strict,
+		Routes: []string{"/comments"},
+		Logger: slog.New(slog.NewTextHandler(&logs, nil)),
+	}))
+	ok := func(c flash.Ctx) error { return c.String(http.StatusCreated, "ok") }
+	app.POST("/comments", ok)
+	app.POST("/drafts", ok)
+
+	serve := func(path, body string) int {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		return rec.Code
+	}
+	if code := serve("/comments", `{"body":"short"}`); code != http.StatusCreated {
+		t.Fatalf("expected shadow failure not to reject, got %d", code)
+	}
+	if !strings.Contains(logs.String(), "shadow validation failed") || !strings.Contains(logs.String(), "body:must be at least 10") {
+		t.Fatalf("unexpected logs: %q", logs.String())
+	}
+	if len(metrics) != 1 || metrics[0]["route"] != "POST /comments" || metrics[0]["field"] != "body" || metrics[0]["tag"] != "min" {
+		t.Fatalf("unexpected metrics: %v", metrics)
+	}
+
+	logs.Reset()
+	if code := serve("/drafts", `{"body":"short"}`); code != http.StatusCreated || logs.Len() != 0 {
+		t.Fatalf("expected route filter to skip shadow validation, got %d %q", code, logs.String())
+	}
+	if code := serve("/comments", `{}`); code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected enforced rules to still reject, got %d", code)
+	}
+}
@@ -0,0 +1,186 @@
+package validator
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+// statsBuckets is the number of buckets a stats window is split into.
+const statsBuckets = 60
+
+// StatCount is a name and how often it failed.
+type StatCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// StatsSnapshot summarizes validation failures over the aggregator window.
+type StatsSnapshot struct {
+	Window   string      `json:"window"`
+	Failures int         `json:"failures"`
+	Fields   []StatCount `json:"fields"`
+	Tags     []StatCount `json:"tags"`
+	Routes   []StatCount `json:"routes"`
+}
+
+type statsBucket struct {
+	start    time.Time
+	failures int
+	fields   map[string]int
+	tags     map[string]int
+	routes   map[string]int
+}
+
+// StatsAggregator counts validation failures per field, tag and route over a
+// sliding window, in memory. It is safe for concurrent use.
+type StatsAggregator struct {
+	window time.Duration
+	span   time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buckets [statsBuckets]statsBucket
+}
+
+// NewStatsAggregator returns an aggregator over window (default 5 minutes).
+func NewStatsAggregator(window time.Duration) *StatsAggregator {
+	if window <= 0 {
+		window = 5 * time.Minute
+	}
+	span := window / statsBuckets
+	if span <= 0 {
+		span = 1
+	}
+	return &StatsAggregator{window: window, span: span, now: time.Now}
+}
+
+// Record counts one failing request on route (e.g. "POST /users"). Tags are
+// taken from validator.ValidationErrors; other errors count fields only
+// (validate.FieldErrors, *validate.BindingError) or the request alone.
+func (a *StatsAggregator) Record(route string, err error) {
+	if err == nil {
+		return
+	}
+	now := a.now()
+	start := now.Truncate(a.span)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	b := &a.buckets[int(start.UnixNano()/int64(a.span))%statsBuckets]
+	if !b.start.Equal(start) {
+		*b = statsBucket{start: start, fields: map[string]int{}, tags: map[string]int{}, routes: map[string]int{}}
+	}
+	b.failures++
+	b.routes[route]++
+
+	var be *validate.BindingError
+	if errors.As(err, &be) && be.Validation != nil {
+		b.count(be.Err)
+		b.count(be.Validation)
+		return
+	}
+	b.count(err)
+}
+
+// count adds the fields and tags of err to b.
+func (b *statsBucket) count(err error) {
+	var vErrs validator.ValidationErrors
+	var fe validate.FieldErrors
+	switch {
+	case errors.As(err, &vErrs):
+		for _, e := range vErrs {
+			b.fields[e.Field()]++
+			b.tags[e.Tag()]++
+		}
+	case errors.As(err, &fe):
+		for k := range fe {
+			b.fields[k]++
+		}
+	}
+}
+
+// Snapshot returns the top entries (default 10) of the current window.
+func (a *StatsAggregator) Snapshot(top int) StatsSnapshot {
+	if top <= 0 {
+		top = 10
+	}
+	cutoff := a.now().Add(-a.window)
+	fields, tags, routes := map[string]int{}, map[string]int{}, map[string]int{}
+	snap := StatsSnapshot{Window: a.window.String()}
+
+	a.mu.Lock()
+	for i := range a.buckets {
+		b := &a.buckets[i]
+		if b.start.IsZero() || !b.start.After(cutoff) {
+			continue
+		}
+		snap.Failures += b.failures
+		merge(fields, b.fields)
+		merge(tags, b.tags)
+		merge(routes, b.routes)
+	}
+	a.mu.Unlock()
+
+	snap.Fields = topCounts(fields, top)
+	snap.Tags = topCounts(tags, top)
+	snap.Routes = topCounts(routes, top)
+	return snap
+}
+
+func merge(dst, src map[string]int) {
+	for k, v := range src {
+		dst[k] += v
+	}
+}
+
+// topCounts returns the n largest counts, ties broken by name.
+func topCounts(m map[string]int, n int) []StatCount {
+	out := make([]StatCount, 0, len(m))
+	for k, v := range m {
+		out = append(out, StatCount{Name: k, Count: v})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+var defaultStats atomic.Pointer[StatsAggregator]
+
+// EnableStats installs a default aggregator over window, fed by Enforce.
+// Passing 0 uses 5 minutes.
+func EnableStats(window time.Duration) { defaultStats.Store(NewStatsAggregator(window)) }
+
+// DisableStats removes the default aggregator.
+func DisableStats() { defaultStats.Store(nil) }
+
+// Stats returns the top failing fields, tags and routes of the default
+// aggregator, or an empty snapshot when stats are not enabled.
+func Stats() StatsSnapshot {
+	if a := defaultStats.Load(); a != nil {
+		return a.Snapshot(0)
+	}
+	return StatsSnapshot{Fields: []StatCount{}, Tags: []StatCount{}, Routes: []StatCount{}}
+}
+
+// StatsHandler returns a handler serving Stats as JSON. Mount it on an
+// internal route, e.g. "/_validation/stats".
+func StatsHandler() flash.Handler {
+	return func(c flash.Ctx) error {
+		return c.Status(http.StatusOK).JSON(Stats())
+	}
+}
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+func TestStatsAggregator_WindowAndTop(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	a := NewStatsAggregator(time.Minute)
+	a.now = func() time.Time { return now }
+
+	err := validate.Struct(&createUser{Email: "x"})
+	a.Record("POST /users", err)
+	a.Record("POST /users", err)
+	a.Record("PUT /users", validate.FieldErrors{"email": "is taken"})
+
+	snap := a.Snapshot(1)
+	if snap.Failures != 3 || snap.Window != "1m0s" {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+	if len(snap.Fields) != 1 || snap.Fields[0] != (StatCount{Name: "email", Count: 3}) {
+		t.Fatalf("unexpected top field: %+v", snap.Fields)
+	}
+	if snap.Routes[0] != (StatCount{Name: "POST /users", Count: 2}) {
+		t.Fatalf("unexpected top route: %+v", snap.Routes)
+	}
+	if all := a.Snapshot(0); len(all.Tags) != 2 || all.Tags[0] != (StatCount{Name: "email", Count: 2}) || all.Tags[1].Name != "required" {
+		t.Fatalf("unexpected tags: %+v", all.Tags)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if snap := a.Snapshot(0); snap.Failures != 0 || len(snap.Fields) != 0 {
+		t.Fatalf("expected window to expire, got %+v", snap)
+	}
+	a.Record("POST /users", nil)
+	if a.Snapshot(0).Failures != 0 {
+		t.Fatalf("expected nil error to be ignored")
+	}
+}
+
+func TestStats_EnforceAndHandler(t *testing.T) {
+	if snap := Stats(); snap.Failures != 0 || snap.Fields == nil {
+		t.Fatalf("expected empty snapshot when disabled, got %+v", snap)
+	}
+	EnableStats(time.Minute)
+	defer DisableStats()
+
+	reg := NewRouteRegistry()
+	reg.ForRoute("/users", http.MethodPost, createUser{})
+	app := flash.New()
+	app.Use(Enforce(EnforceConfig{Registry: reg}))
+	app.POST("/users", func(c flash.Ctx) error { return nil })
+	app.GET("/_validation/stats", StatsHandler())
+
+	for _, body := range []string{`{"email":"x"}`, `{"name":"a","email":"x"}`, `{bad`} {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body)))
+	}
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/_validation/stats", nil))
+	var snap StatsSnapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &snap); err != nil {
+		t.Fatalf("decode: %v (%s)", err, rec.Body.String())
+	}
+	if snap.Failures != 3 || snap.Routes[0] != (StatCount{Name: "POST /users", Count: 3}) {
+		t.Fatalf("unexpected stats: %+v", snap)
+	}
+	if snap.Fields[0] != (StatCount{Name: "email", Count: 2}) || snap.Tags[0] != (StatCount{Name: "email", Count: 2}) {
+		t.Fatalf("unexpected field/tag stats: %+v", snap)
+	}
+}
@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+// TenantOptions is the validation setup of one tenant.
+type TenantOptions struct {
+	// Engine validates the tenant's requests, typically a clone of the global
+	// engine with tenant-specific rules and messages:
+	//
+	//	e := validate.Global().Clone()
+	//	e.RegisterMessages("required", map[string]string{"en": "can't be blank"})
+	Engine validate.Engine
+	// DefaultLocale selects registered messages for requests of the tenant
+	// that carry no locale yet (see validate.WithLocale). Optional.
+	DefaultLocale string
+}
+
+// TenantRegistry maps tenant IDs to their validation setup.
+// It is safe for concurrent use.
+type TenantRegistry struct {
+	mu      sync.RWMutex
+	tenants map[string]TenantOptions
+}
+
+// NewTenantRegistry returns an empty TenantRegistry.
+func NewTenantRegistry() *TenantRegistry {
+	return &TenantRegistry{tenants: map[string]TenantOptions{}}
+}
+
+// Register sets (or replaces) the setup of tenant.
+func (r *TenantRegistry) Register(tenant string, opts TenantOptions) {
+	r.mu.Lock()
+	r.tenants[tenant] = opts
+	r.mu.Unlock()
+}
+
+// Remove deletes the setup of tenant.
+func (r *TenantRegistry) Remove(tenant string) {
+	r.mu.Lock()
+	delete(r.tenants, tenant)
+	r.mu.Unlock()
+}
+
+// Lookup returns the setup registered for tenant.
+func (r *TenantRegistry) Lookup(tenant string) (TenantOptions, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	opts, ok := r.tenants[tenant]
+	return opts, ok
+}
+
+// Tenants returns the registered tenant IDs, sorted.
+func (r *TenantRegistry) Tenants() []string {
+	r.mu.RLock()
+	out := make([]string, 0, len(r.tenants))
+	for t := range r.tenants {
+		out = append(out, t)
+	}
+	r.mu.RUnlock()
+	sort.Strings(out)
+	return out
+}
+
+// TenantConfig configures the Tenant middleware.
+type TenantConfig struct {
+	// Registry holds the tenants. Required.
+	Registry *TenantRegistry
+	// Resolver returns the tenant of a request.
+	// Default: the "X-Tenant-ID" request header.
+	Resolver func(c flash.Ctx) string
+}
+
+// Tenant returns middleware that selects the validation engine of the
+// request's tenant and attaches it to the request context (validate.WithEngine),
+// so the context-aware helpers and Enforce use it. Handlers can also retrieve
+// it with TenantEngine. Requests of unknown tenants keep the current engine.
+func Tenant(cfg TenantConfig) flash.Middleware {
+	if cfg.Registry == nil {
+		// No-op middleware if misconfigured
+		return func(next flash.Handler) flash.Handler { return next }
+	}
+	if cfg.Resolver == nil {
+		cfg.Resolver = func(c flash.Ctx) string { return c.Request().Header.Get("X-Tenant-ID") }
+	}
+
+	return func(next flash.Handler) flash.Handler {
+		return func(c flash.Ctx) error {
+			opts, ok := cfg.Registry.Lookup(cfg.Resolver(c))
+			if !ok {
+				return next(c)
+			}
+			ctx := c.Context()
+			if opts.DefaultLocale != "" && validate.LocaleFromContext(ctx) == "" {
+				ctx = validate.WithLocale(ctx, opts.DefaultLocale)
+			}
+			if opts.Engine != nil {
+				ctx = validate.WithEngine(ctx, opts.Engine)
+			}
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// TenantEngine returns the engine selected for the request, or the current
+// engine (validate.CurrentEngine) when none was selected.
+func TenantEngine(c flash.Ctx) validate.Engine {
+	if e := validate.EngineFromContext(c.Context()); e != nil {
+		return e
+	}
+	return validate.CurrentEngine()
+}
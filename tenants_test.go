@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+type tenantReq struct {
+	Code string `json:"code" validate:"required,max=3"`
+}
+
+func TestTenant_SelectsEngine(t *testing.T) {
+	strict := validate.NewEngine()
+	if err := strict.RegisterValidation("max", func(validator.FieldLevel) bool { return false }); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	reg := NewTenantRegistry()
+	reg.Register("acme", TenantOptions{Engine: validate.NewNoop(), DefaultLocale: "de"})
+	reg.Register("globex", TenantOptions{Engine: strict})
+
+	app := flash.New()
+	app.Use(Tenant(TenantConfig{Registry: reg}))
+	app.GET("/", func(c flash.Ctx) error {
+		err := TenantEngine(c).StructCtx(c.Context(), tenantReq{Code: "ab"})
+		if (err == nil) != (validate.StructCtx(c.Context(), tenantReq{Code: "ab"}) == nil) {
+			t.Fatalf("expected package helpers to use the tenant engine")
+		}
+		status := http.StatusOK
+		if err != nil {
+			status = http.StatusUnprocessableEntity
+		}
+		return c.String(status, validate.LocaleFromContext(c.Context()))
+	})
+
+	for tenant, want := range map[string]struct {
+		code   int
+		locale string
+	}{
+		"acme":    {http.StatusOK, "de"},
+		"globex":  {http.StatusUnprocessableEntity, ""},
+		"unknown": {http.StatusOK, ""},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Tenant-ID", tenant)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		if rec.Code != want.code || rec.Body.String() != want.locale {
+			t.Fatalf("tenant %s: expected %d %q, got %d %q", tenant, want.code, want.locale, rec.Code, rec.Body.String())
+		}
+	}
+
+	if got := reg.Tenants(); len(got) != 2 || got[0] != "acme" {
+		t.Fatalf("unexpected tenants: %v", got)
+	}
+	reg.Remove("acme")
+	if _, ok := reg.Lookup("acme"); ok {
+		t.Fatalf("expected tenant to be removed")
+	}
+}
@@ -0,0 +1,180 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/locales"
+	arLocale "github.com/go-playground/locales/ar"
+	deLocale "github.com/go-playground/locales/de"
+	enLocale "github.com/go-playground/locales/en"
+	esLocale "github.com/go-playground/locales/es"
+	faLocale "github.com/go-playground/locales/fa"
+	frLocale "github.com/go-playground/locales/fr"
+	idLocale "github.com/go-playground/locales/id"
+	itLocale "github.com/go-playground/locales/it"
+	jaLocale "github.com/go-playground/locales/ja"
+	koLocale "github.com/go-playground/locales/ko"
+	lvLocale "github.com/go-playground/locales/lv"
+	nlLocale "github.com/go-playground/locales/nl"
+	plLocale "github.com/go-playground/locales/pl"
+	ptLocale "github.com/go-playground/locales/pt"
+	ptBRLocale "github.com/go-playground/locales/pt_BR"
+	ruLocale "github.com/go-playground/locales/ru"
+	thLocale "github.com/go-playground/locales/th"
+	trLocale "github.com/go-playground/locales/tr"
+	ukLocale "github.com/go-playground/locales/uk"
+	viLocale "github.com/go-playground/locales/vi"
+	zhLocale "github.com/go-playground/locales/zh"
+	zhTWLocale "github.com/go-playground/locales/zh_Hant_TW"
+	ut "github.com/go-playground/universal-translator"
+	globalValidator "github.com/go-playground/validator/v10"
+	arTranslations "github.com/go-playground/validator/v10/translations/ar"
+	deTranslations "github.com/go-playground/validator/v10/translations/de"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	esTranslations "github.com/go-playground/validator/v10/translations/es"
+	faTranslations "github.com/go-playground/validator/v10/translations/fa"
+	frTranslations "github.com/go-playground/validator/v10/translations/fr"
+	idTranslations "github.com/go-playground/validator/v10/translations/id"
+	itTranslations "github.com/go-playground/validator/v10/translations/it"
+	jaTranslations "github.com/go-playground/validator/v10/translations/ja"
+	koTranslations "github.com/go-playground/validator/v10/translations/ko"
+	lvTranslations "github.com/go-playground/validator/v10/translations/lv"
+	nlTranslations "github.com/go-playground/validator/v10/translations/nl"
+	plTranslations "github.com/go-playground/validator/v10/translations/pl"
+	ptTranslations "github.com/go-playground/validator/v10/translations/pt"
+	ptBRTranslations "github.com/go-playground/validator/v10/translations/pt_BR"
+	ruTranslations "github.com/go-playground/validator/v10/translations/ru"
+	thTranslations "github.com/go-playground/validator/v10/translations/th"
+	trTranslations "github.com/go-playground/validator/v10/translations/tr"
+	ukTranslations "github.com/go-playground/validator/v10/translations/uk"
+	viTranslations "github.com/go-playground/validator/v10/translations/vi"
+	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+	zhTWTranslations "github.com/go-playground/validator/v10/translations/zh_tw"
+	"github.com/goflash/validator/v2/validate"
+)
+
+// Translations pairs a locale with the registration of its validation
+// messages, usually a go-playground/validator translations package.
+type Translations struct {
+	// Locale returns the locale's plural and formatting rules.
+	Locale func() locales.Translator
+	// Register adds the locale's messages to v, e.g.
+	// en_translations.RegisterDefaultTranslations.
+	Register func(v *globalValidator.Validate, trans ut.Translator) error
+}
+
+var (
+	translationsMu sync.RWMutex
+	translations   = map[string]Translations{
+		"ar":    {arLocale.New, arTranslations.RegisterDefaultTranslations},
+		"de":    {deLocale.New, deTranslations.RegisterDefaultTranslations},
+		"en":    {enLocale.New, enTranslations.RegisterDefaultTranslations},
+		"es":    {esLocale.New, esTranslations.RegisterDefaultTranslations},
+		"fa":    {faLocale.New, faTranslations.RegisterDefaultTranslations},
+		"fr":    {frLocale.New, frTranslations.RegisterDefaultTranslations},
+		"id":    {idLocale.New, idTranslations.RegisterDefaultTranslations},
+		"it":    {itLocale.New, itTranslations.RegisterDefaultTranslations},
+		"ja":    {jaLocale.New, jaTranslations.RegisterDefaultTranslations},
+		"ko":    {koLocale.New, koTranslations.RegisterDefaultTranslations},
+		"lv":    {lvLocale.New, lvTranslations.RegisterDefaultTranslations},
+		"nl":    {nlLocale.New, nlTranslations.RegisterDefaultTranslations},
+		"pl":    {plLocale.New, plTranslations.RegisterDefaultTranslations},
+		"pt":    {ptLocale.New, ptTranslations.RegisterDefaultTranslations},
+		"pt-br": {ptBRLocale.New, ptBRTranslations.RegisterDefaultTranslations},
+		"ru":    {ruLocale.New, ruTranslations.RegisterDefaultTranslations},
+		"th":    {thLocale.New, thTranslations.RegisterDefaultTranslations},
+		"tr":    {trLocale.New, trTranslations.RegisterDefaultTranslations},
+		"uk":    {ukLocale.New, ukTranslations.RegisterDefaultTranslations},
+		"vi":    {viLocale.New, viTranslations.RegisterDefaultTranslations},
+		"zh":    {zhLocale.New, zhTranslations.RegisterDefaultTranslations},
+		"zh-tw": {zhTWLocale.New, zhTWTranslations.RegisterDefaultTranslations},
+	}
+)
+
+// RegisterTranslations adds or replaces the translations of a locale, e.g.
+// for a locale go-playground/validator has no package for. Locales are
+// matched case-insensitively, with "_" and "-" equivalent ("pt_BR", "pt-br").
+func RegisterTranslations(locale string, t Translations) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	translations[normalizeLocale(locale)] = t
+}
+
+// TranslationLocales returns the locales with registered translations,
+// sorted, in the normalized form used by the middleware ("pt-br").
+func TranslationLocales() []string {
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+	out := make([]string, 0, len(translations))
+	for l := range translations {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// LoadTranslations builds translators for the given locales and registers
+// their translations on v; only the requested locales are loaded. The first
+// locale is the universal-translator fallback. The result is keyed by the
+// normalized locale:
+//
+//	translators, err := validator.LoadTranslations(validate.Validator, "en", "de", "pt-BR")
+//	trans := translators["pt-br"]
+//
+// Use LoadEngineTranslations for engines, so their clones translate too.
+func LoadTranslations(v *globalValidator.Validate, localeNames ...string) (map[string]ut.Translator, error) {
+	return loadTranslations(func(t Translations, trans ut.Translator) (ut.Translator, error) {
+		return trans, t.Register(v, trans)
+	}, localeNames)
+}
+
+// LoadEngineTranslations is LoadTranslations for an engine. The translations
+// are registered with DefaultEngine.RegisterTranslations, so engines cloned
+// from e (API versions, tenants) translate as well.
+func LoadEngineTranslations(e *validate.DefaultEngine, localeNames ...string) (map[string]ut.Translator, error) {
+	return loadTranslations(func(t Translations, trans ut.Translator) (ut.Translator, error) {
+		return e.RegisterTranslations(trans, t.Register)
+	}, localeNames)
+}
+
+// loadTranslations builds translators for localeNames and registers the
+// translations of each with register, which returns the translator to use.
+func loadTranslations(register func(Translations, ut.Translator) (ut.Translator, error), localeNames []string) (map[string]ut.Translator, error) {
+	if len(localeNames) == 0 {
+		return nil, fmt.Errorf("validator: no locales to load")
+	}
+	names := make([]string, len(localeNames))
+	entries := make([]Translations, len(localeNames))
+	all := make([]locales.Translator, len(localeNames))
+	translationsMu.RLock()
+	for i, l := range localeNames {
+		names[i] = normalizeLocale(l)
+		entry, ok := translations[names[i]]
+		if !ok {
+			translationsMu.RUnlock()
+			return nil, fmt.Errorf("validator: no translations for locale %q", l)
+		}
+		entries[i] = entry
+		all[i] = entry.Locale()
+	}
+	translationsMu.RUnlock()
+
+	uni := ut.New(all[0], all...)
+	out := make(map[string]ut.Translator, len(names))
+	for i, l := range names {
+		trans, _ := uni.GetTranslator(all[i].Locale())
+		trans, err := register(entries[i], trans)
+		if err != nil {
+			return nil, fmt.Errorf("validator: register %s translations: %w", l, err)
+		}
+		out[l] = trans
+	}
+	return out, nil
+}
+
+func normalizeLocale(l string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(l), "_", "-"))
+}
@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	globalValidator "github.com/go-playground/validator/v10"
+)
+
+func TestTranslationLocales(t *testing.T) {
+	got := TranslationLocales()
+	for _, l := range []string{"de", "en", "es", "fr", "ja", "pt", "pt-br", "zh", "zh-tw"} {
+		if !slices.Contains(got, l) {
+			t.Fatalf("expected %s in %v", l, got)
+		}
+	}
+	if !slices.IsSorted(got) {
+		t.Fatalf("expected sorted locales, got %v", got)
+	}
+}
+
+func TestLoadTranslations(t *testing.T) {
+	v := globalValidator.New()
+	translators, err := LoadTranslations(v, "de", "pt_BR")
+	if err != nil {
+		t.Fatalf("LoadTranslations: %v", err)
+	}
+	if len(translators) != 2 || translators["de"] == nil || translators["pt-br"] == nil {
+		t.Fatalf("unexpected translators: %v", translators)
+	}
+	var ve globalValidator.ValidationErrors
+	if !errors.As(v.Var("", "required"), &ve) {
+		t.Fatalf("expected validation errors")
+	}
+	if got := ve[0].Translate(translators["de"]); got != " ist ein Pflichtfeld" {
+		t.Fatalf("unexpected de translation: %q", got)
+	}
+	if _, err := LoadTranslations(v, "xx"); err == nil {
+		t.Fatalf("expected error for unknown locale")
+	}
+}
+
+func TestRegisterTranslations(t *testing.T) {
+	RegisterTranslations("en_GB", Translations{
+		Locale: en.New,
+		Register: func(v *globalValidator.Validate, trans ut.Translator) error {
+			return v.RegisterTranslation("required", trans,
+				func(ut ut.Translator) error { return ut.Add("required", "{0} is compulsory", false) },
+				func(ut ut.Translator, fe globalValidator.FieldError) string {
+					msg, _ := ut.T("required", fe.Field())
+					return msg
+				})
+		},
+	})
+	defer func() {
+		translationsMu.Lock()
+		delete(translations, "en-gb")
+		translationsMu.Unlock()
+	}()
+
+	v := globalValidator.New()
+	translators, err := LoadTranslations(v, "en-GB")
+	if err != nil {
+		t.Fatalf("LoadTranslations: %v", err)
+	}
+	type form struct {
+		Name string `validate:"required"`
+	}
+	var ve globalValidator.ValidationErrors
+	if !errors.As(v.Struct(form{}), &ve) {
+		t.Fatalf("expected validation errors")
+	}
+	if got := ve[0].Translate(translators["en-gb"]); got != "Name is compulsory" {
+		t.Fatalf("unexpected translation: %q", got)
+	}
+}
@@ -0,0 +1,121 @@
+// Package twirpvalidate translates goflash validation errors returned by Twirp
+// handlers into twirp.InvalidArgument errors with field metadata.
+//
+// Twirp's ServerHooks observe errors but cannot replace them, so translation is
+// done by a server interceptor; use WithServerValidation to install it.
+//
+// It lives in its own module so the core validator module does not pull in
+// Twirp dependencies.
+package twirpvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	globalValidator "github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2/ctx"
+	"github.com/goflash/validator/v2/validate"
+	"github.com/twitchtv/twirp"
+)
+
+// MetaFields is the twirp.Error meta key holding a JSON object of
+// field -> message, matching the "fields" object of REST responses.
+const MetaFields = "fields"
+
+// MetaArgument is the standard Twirp meta key naming the invalid argument.
+// When several fields are invalid it holds the first one in sorted order.
+const MetaArgument = "argument"
+
+// NewInterceptor returns a server interceptor that converts validation errors
+// returned by handlers (validate.FieldErrors, validate.BindingError,
+// validator.ValidationErrors and flash ctx.FieldErrors, also when wrapped, e.g.
+// by fmt.Errorf with %w or validate.ValidationError) into twirp.InvalidArgument
+// errors. Other errors are returned unchanged.
+func NewInterceptor() twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(c context.Context, req any) (any, error) {
+			resp, err := next(c, req)
+			if vErr, ok := validationError(err); ok {
+				return resp, ToTwirpError(c, vErr)
+			}
+			return resp, err
+		}
+	}
+}
+
+// WithServerValidation is a convenience ServerOption installing NewInterceptor.
+func WithServerValidation() twirp.ServerOption {
+	return twirp.WithServerInterceptors(NewInterceptor())
+}
+
+// ToTwirpError converts err into a twirp.InvalidArgument error. Messages are
+// resolved with validate.ToFieldErrorsWithContext, so request-scoped message
+// functions apply. Wrapped validation errors are unwrapped first. A single invalid field yields the same shape as
+// twirp.InvalidArgumentError ("<field> <message>").
+func ToTwirpError(c context.Context, err error) twirp.Error {
+	if vErr, ok := validationError(err); ok {
+		err = vErr
+	}
+	fields := validate.ToFieldErrorsWithContext(c, err)
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var twerr twirp.Error
+	switch len(keys) {
+	case 0:
+		return twirp.NewError(twirp.InvalidArgument, "validation failed")
+	case 1:
+		twerr = twirp.InvalidArgumentError(keys[0], fields[keys[0]])
+	default:
+		twerr = twirp.NewError(twirp.InvalidArgument, "validation failed").WithMeta(MetaArgument, keys[0])
+	}
+	if b, mErr := json.Marshal(fields); mErr == nil {
+		twerr = twerr.WithMeta(MetaFields, string(b))
+	}
+	return twerr
+}
+
+// FieldsFromError decodes the MetaFields entry of a twirp.Error back into a
+// field -> message map. It returns nil if err carries no field metadata.
+func FieldsFromError(err error) map[string]string {
+	var twerr twirp.Error
+	if !errors.As(err, &twerr) {
+		return nil
+	}
+	raw := twerr.Meta(MetaFields)
+	if raw == "" {
+		return nil
+	}
+	var fields map[string]string
+	if json.Unmarshal([]byte(raw), &fields) != nil {
+		return nil
+	}
+	return fields
+}
+
+// validationError returns the field error understood by
+// validate.ToFieldErrors that err is or wraps, and whether there is one.
+func validationError(err error) (error, bool) {
+	var be *validate.BindingError
+	if errors.As(err, &be) {
+		return be, true
+	}
+	var fe validate.FieldErrors
+	if errors.As(err, &fe) {
+		return fe, true
+	}
+	var ve globalValidator.ValidationErrors
+	if errors.As(err, &ve) {
+		return ve, true
+	}
+	var ce ctx.FieldErrors
+	if errors.As(err, &ce) {
+		return ce, true
+	}
+	return nil, false
+}
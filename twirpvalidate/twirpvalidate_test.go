@@ -0,0 +1,113 @@
+package twirpvalidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	globalValidator "github.com/go-playground/validator/v10"
+	"github.com/goflash/validator/v2/validate"
+	"github.com/twitchtv/twirp"
+)
+
+type createUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func handlerReturning(err error) twirp.Method {
+	return func(context.Context, any) (any, error) { return nil, err }
+}
+
+func TestNewInterceptor_TranslatesValidationErrors(t *testing.T) {
+	err := validate.Struct(createUserRequest{Email: "x"})
+	_, got := NewInterceptor()(handlerReturning(err))(context.Background(), nil)
+
+	var twerr twirp.Error
+	if !errors.As(got, &twerr) || twerr.Code() != twirp.InvalidArgument {
+		t.Fatalf("expected invalid_argument twirp error, got %v", got)
+	}
+	if twerr.Meta(MetaArgument) != "email" {
+		t.Fatalf("expected first sorted argument, got %q", twerr.Meta(MetaArgument))
+	}
+	fields := FieldsFromError(got)
+	if fields["name"] != "is required" || fields["email"] != "must be a valid email" {
+		t.Fatalf("unexpected fields meta: %v", fields)
+	}
+}
+
+func TestNewInterceptor_SingleFieldUsesInvalidArgumentShape(t *testing.T) {
+	_, got := NewInterceptor()(handlerReturning(validate.FieldErrors{"id": "is unknown"}))(context.Background(), nil)
+	var twerr twirp.Error
+	if !errors.As(got, &twerr) {
+		t.Fatalf("expected twirp error, got %v", got)
+	}
+	if twerr.Msg() != "id is unknown" || twerr.Meta(MetaArgument) != "id" {
+		t.Fatalf("unexpected error: msg=%q meta=%v", twerr.Msg(), twerr.MetaMap())
+	}
+}
+
+func TestNewInterceptor_UnwrapsValidationErrors(t *testing.T) {
+	verr := validate.Struct(createUserRequest{Name: "n", Email: "x"})
+	for _, err := range []error{
+		fmt.Errorf("create user: %w", verr),
+		validate.NewValidationError(verr, 0),
+		fmt.Errorf("create user: %w", &validate.BindingError{Err: validate.FieldErrors{"email": "must be a valid email"}}),
+	} {
+		_, got := NewInterceptor()(handlerReturning(err))(context.Background(), nil)
+		var twerr twirp.Error
+		if !errors.As(got, &twerr) || twerr.Code() != twirp.InvalidArgument {
+			t.Fatalf("expected invalid_argument twirp error for %v, got %v", err, got)
+		}
+		if twerr.Meta(MetaArgument) != "email" {
+			t.Fatalf("expected email argument, got %q", twerr.Meta(MetaArgument))
+		}
+		if fields := FieldsFromError(got); len(fields) != 1 || fields["email"] != "must be a valid email" {
+			t.Fatalf("unexpected fields meta: %v", fields)
+		}
+	}
+}
+
+func TestNewInterceptor_PassesThroughOtherErrors(t *testing.T) {
+	boom := errors.New("boom")
+	_, got := NewInterceptor()(handlerReturning(boom))(context.Background(), nil)
+	if got != boom {
+		t.Fatalf("expected original error, got %v", got)
+	}
+	if _, got = NewInterceptor()(handlerReturning(nil))(context.Background(), nil); got != nil {
+		t.Fatalf("expected nil error, got %v", got)
+	}
+}
+
+func TestToTwirpError_UsesContextMessageFunc(t *testing.T) {
+	c := validate.WithMessageFunc(context.Background(), func(globalValidator.FieldError) string { return "CTX" })
+	twerr := ToTwirpError(c, validate.Struct(createUserRequest{Email: "a@b.co"}))
+	if FieldsFromError(twerr)["name"] != "CTX" {
+		t.Fatalf("expected request-scoped message, got %v", twerr.MetaMap())
+	}
+}
+
+func TestToTwirpError_EmptyFieldErrors(t *testing.T) {
+	twerr := ToTwirpError(context.Background(), validate.FieldErrors{})
+	if twerr.Code() != twirp.InvalidArgument || FieldsFromError(twerr) != nil {
+		t.Fatalf("unexpected error: %v", twerr)
+	}
+}
+
+func TestFieldsFromError_NoMeta(t *testing.T) {
+	if FieldsFromError(errors.New("plain")) != nil {
+		t.Fatalf("expected nil for non-twirp error")
+	}
+	if FieldsFromError(twirp.NewError(twirp.InvalidArgument, "x").WithMeta(MetaFields, "{")) != nil {
+		t.Fatalf("expected nil for malformed meta")
+	}
+}
+
+func TestWithServerValidation(t *testing.T) {
+	var opts twirp.ServerOptions
+	WithServerValidation()(&opts)
+	if len(opts.Interceptors) != 1 {
+		t.Fatalf("expected interceptor installed, got %d", len(opts.Interceptors))
+	}
+}
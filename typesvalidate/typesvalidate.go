@@ -0,0 +1,68 @@
+// Package typesvalidate makes the goflash validate helpers understand the
+// decimal and UUID types of github.com/shopspring/decimal and
+// github.com/google/uuid:
+//
+//   - decimal.Decimal validates as a number, so the built-in comparison tags
+//     (`gt=0,lte=100`) compare its value; they see it as a float64, so use
+//     the decimal_* and money tags (`decimal_places=2,decimal_gt=0`) for
+//     exact comparisons, which read struct fields with every digit.
+//     decimal.NullDecimal validates like the sql.Null* types (NULL is nil).
+//   - uuid.UUID validates as its string form, with uuid.Nil as "" (so
+//     `required` rejects the nil UUID); uuid.NullUUID like the sql.Null*
+//     types.
+//   - uuid.UUID fields in JSON bodies, query strings and forms report
+//     "must be a valid UUID" when they fail to parse.
+//
+// It lives in its own module so the core validator module does not pull in
+// the decimal and UUID libraries.
+package typesvalidate
+
+import (
+	"reflect"
+
+	"github.com/goflash/validator/v2/validate"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Register registers the decimal and UUID types on the global validator.
+// Call it once at startup, before validating.
+func Register() { RegisterOn(validate.Global()) }
+
+// RegisterOn registers the decimal and UUID types on e. The UUID parser is
+// process-wide, like every validate.RegisterParser registration.
+func RegisterOn(e *validate.DefaultEngine) {
+	e.RegisterCustomTypeFunc(decimalValue, decimal.Decimal{}, decimal.NullDecimal{})
+	e.RegisterCustomTypeFunc(uuidValue, uuid.UUID{}, uuid.NullUUID{})
+	validate.RegisterParser("must be a valid UUID", uuid.Parse)
+}
+
+// decimalValue converts decimal types into float64 for the built-in
+// comparison tags; the decimal_* tags read the exact value themselves.
+func decimalValue(field reflect.Value) any {
+	switch d := field.Interface().(type) {
+	case decimal.Decimal:
+		return d.InexactFloat64()
+	case decimal.NullDecimal:
+		if d.Valid {
+			return d.Decimal.InexactFloat64()
+		}
+	}
+	return nil
+}
+
+// uuidValue converts uuid types into their string form ("" for uuid.Nil).
+func uuidValue(field reflect.Value) any {
+	switch u := field.Interface().(type) {
+	case uuid.UUID:
+		if u == uuid.Nil {
+			return ""
+		}
+		return u.String()
+	case uuid.NullUUID:
+		if u.Valid && u.UUID != uuid.Nil {
+			return u.UUID.String()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package typesvalidate
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/goflash/validator/v2/validate"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func init() { Register() }
+
+type account struct {
+	Balance  decimal.Decimal     `json:"balance" validate:"decimal_gt=0"`
+	Price    decimal.Decimal     `json:"price" validate:"decimal_places=2,decimal_lte=100000000000000000000.01"`
+	Limit    decimal.NullDecimal `json:"limit" validate:"omitempty,decimal_lte=1000"`
+	ID       uuid.UUID           `json:"id" validate:"required"`
+	ParentID uuid.NullUUID       `json:"parent_id" validate:"omitempty,uuid"`
+}
+
+func TestRegister_Invalid(t *testing.T) {
+	a := account{
+		Balance: decimal.RequireFromString("-0.01"),
+		Price:   decimal.RequireFromString("100000000000000000000.02"),
+		Limit:   decimal.NullDecimal{Decimal: decimal.NewFromInt(5000), Valid: true},
+	}
+	m := validate.ToFieldErrors(validate.Struct(a))
+	want := map[string]string{
+		"balance": "must be greater than 0",
+		"price":   "must be less than or equal to 100000000000000000000.01",
+		"limit":   "must be less than or equal to 1000",
+		"id":      "is required",
+	}
+	for k, msg := range want {
+		if m[k] != msg {
+			t.Fatalf("field %q: expected %q, got %q (all: %v)", k, msg, m[k], m)
+		}
+	}
+	if len(m) != len(want) {
+		t.Fatalf("unexpected extra errors: %v", m)
+	}
+}
+
+func TestRegister_Valid(t *testing.T) {
+	a := account{
+		Balance:  decimal.RequireFromString("0.01"),
+		Price:    decimal.RequireFromString("100000000000000000000.01"),
+		ID:       uuid.New(),
+		ParentID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+	}
+	if err := validate.Struct(a); err != nil {
+		t.Fatalf("unexpected error: %v", validate.ToFieldErrors(err))
+	}
+}
+
+func TestDecimalPlaces(t *testing.T) {
+	type s struct {
+		Price decimal.Decimal `validate:"decimal_places=2,decimal_gt=0"`
+	}
+	if err := validate.Struct(s{Price: decimal.RequireFromString("19.99")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := validate.Struct(s{Price: decimal.RequireFromString("19.999")}); err == nil {
+		t.Fatalf("expected decimal_places failure")
+	}
+}
+
+func TestUUIDParser(t *testing.T) {
+	var dst struct {
+		ID uuid.UUID `json:"id"`
+	}
+	err := validate.Coerce(url.Values{"id": {"nope"}}, &dst)
+	if fe, _ := err.(validate.FieldErrors); fe["id"] != "must be a valid UUID" || len(fe) != 1 {
+		t.Fatalf("unexpected result: %v", err)
+	}
+	id := uuid.New()
+	if err := validate.Coerce(url.Values{"id": {id.String()}}, &dst); err != nil || dst.ID != id {
+		t.Fatalf("expected UUID to parse, got %v %v", err, dst.ID)
+	}
+}
+
+func TestRegister_BuiltinComparisons(t *testing.T) {
+	type order struct {
+		Quantity decimal.Decimal     `validate:"gt=0"`
+		Discount decimal.Decimal     `validate:"lte=100"`
+		Tip      decimal.NullDecimal `validate:"omitempty,gte=0"`
+	}
+	if err := validate.Struct(order{Quantity: decimal.NewFromInt(5), Discount: decimal.RequireFromString("99.5")}); err != nil {
+		t.Fatalf("unexpected error: %v", validate.ToFieldErrors(err))
+	}
+	m := validate.ToFieldErrors(validate.Struct(order{
+		Quantity: decimal.NewFromInt(-5),
+		Discount: decimal.NewFromInt(1000),
+		Tip:      decimal.NullDecimal{Decimal: decimal.RequireFromString("-0.5"), Valid: true},
+	}))
+	if m["Quantity"] == "" || m["Discount"] != "must be less than or equal to 100" || m["Tip"] != "must be greater than or equal to 0" {
+		t.Fatalf("expected numeric comparisons, got %v", m)
+	}
+}
@@ -0,0 +1,178 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+func init() {
+	registerBuiltin(func(e *DefaultEngine) {
+		e.RegisterMessages("address", map[string]string{DefaultMessageLocale: "is not a deliverable address"})
+	})
+}
+
+// Address is the postal address passed to an AddressVerifier.
+type Address struct {
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string
+}
+
+// AddressVerifier checks an address against an external provider (a postal
+// service, a geocoder, ...). It reports whether the address is deliverable; a
+// non-nil error means the provider could not answer.
+type AddressVerifier interface {
+	Verify(ctx context.Context, a Address) (bool, error)
+}
+
+// AddressVerifierFunc adapts a function to AddressVerifier.
+type AddressVerifierFunc func(ctx context.Context, a Address) (bool, error)
+
+// Verify calls f.
+func (f AddressVerifierFunc) Verify(ctx context.Context, a Address) (bool, error) { return f(ctx, a) }
+
+// AddressFields names the Go fields of a struct that hold each address
+// component. Line1 is required; other empty names are left blank in the
+// Address passed to the verifier.
+type AddressFields struct {
+	Line1, Line2, City, Region, PostalCode, Country string
+}
+
+// AddressOptions configures RegisterAddressVerifier.
+type AddressOptions struct {
+	// Timeout bounds each verification, in addition to any deadline of the
+	// validation context. Default: 2s.
+	Timeout time.Duration
+	// CacheTTL keeps answers per address for this long. 0 disables caching.
+	CacheTTL time.Duration
+	// CacheSize bounds the number of cached addresses. Default: 10000.
+	CacheSize int
+	// FailClosed treats provider errors and timeouts like undeliverable
+	// addresses. By default such addresses pass, so an outage does not block
+	// users.
+	FailClosed bool
+	// SoftFail reports undeliverable addresses as warnings (see
+	// CollectWarnings) instead of validation errors.
+	SoftFail bool
+	// Messages are the "address" messages by locale, as in
+	// RegisterValidationWithMessage. Default: "is not a deliverable address".
+	Messages map[string]string
+}
+
+// RegisterAddressVerifier registers a struct-level rule that verifies T's
+// address with av. Fields are named by Go field names; an undeliverable
+// address is reported as an "address" failure on the Line1 field's json name,
+// or as a warning there in SoftFail mode:
+//
+//	type Shipping struct {
+//		Street string `json:"street" validate:"required"`
+//		City   string `json:"city" validate:"required"`
+//		Zip    string `json:"zip" validate:"required"`
+//	}
+//
+//	validate.RegisterAddressVerifier[Shipping](validate.AddressFields{
+//		Line1: "Street", City: "City", PostalCode: "Zip",
+//	}, verifier, validate.AddressOptions{CacheTTL: time.Hour, SoftFail: true})
+//
+// Verification runs with the validation context (see StructCtx) and is
+// skipped when every address field is empty. Provider errors are counted as
+// MetricRemoteError with tag "address". It panics if T lacks a named string
+// field.
+func RegisterAddressVerifier[T any](fields AddressFields, av AddressVerifier, opts AddressOptions) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if fields.Line1 == "" {
+		panic("validate: RegisterAddressVerifier: AddressFields.Line1 is required")
+	}
+	names := []string{fields.Line1, fields.Line2, fields.City, fields.Region, fields.PostalCode, fields.Country}
+	index := make([][]int, len(names))
+	for i, name := range names {
+		if name != "" {
+			index[i] = addressField(t, name).Index
+		}
+	}
+	report := jsonName(addressField(t, fields.Line1))
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Second
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = 10000
+	}
+	var cache *ttlCache
+	if opts.CacheTTL > 0 {
+		cache = newTTLCache(opts.CacheTTL, opts.CacheSize)
+	}
+	if len(opts.Messages) > 0 {
+		Default().RegisterMessages("address", opts.Messages)
+	}
+
+	RegisterStructRule(func(v T, rep *StructReporter) {
+		rv := reflect.ValueOf(v)
+		parts := make([]string, len(index))
+		empty := true
+		for i, idx := range index {
+			if idx == nil {
+				continue
+			}
+			if fv, err := rv.FieldByIndexErr(idx); err == nil {
+				if fv = indirectValue(fv); fv.IsValid() {
+					parts[i] = fv.String()
+				}
+			}
+			empty = empty && parts[i] == ""
+		}
+		if empty {
+			return
+		}
+		addr := Address{parts[0], parts[1], parts[2], parts[3], parts[4], parts[5]}
+		if verifyAddress(rep.Context(), av, addr, cache, opts) {
+			return
+		}
+		if opts.SoftFail {
+			rep.AddWarning(report, warningMessage(rep.Context(), "address"))
+			return
+		}
+		rep.AddFieldTag(report, "address", "")
+	})
+}
+
+// verifyAddress answers from the cache or asks the verifier.
+func verifyAddress(ctx context.Context, av AddressVerifier, a Address, cache *ttlCache, opts AddressOptions) bool {
+	key := strings.Join([]string{a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country}, "\x00")
+	if cache != nil {
+		if ok, hit := cache.get(key); hit {
+			return ok
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	ok, err := av.Verify(ctx, a)
+	if err != nil {
+		incMetric(MetricRemoteError, map[string]string{"tag": "address"})
+		return !opts.FailClosed
+	}
+	if cache != nil {
+		cache.set(key, ok)
+	}
+	return ok
+}
+
+// addressField looks up a string struct field of t by Go name.
+func addressField(t reflect.Type, name string) reflect.StructField {
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: RegisterAddressVerifier: %v is not a struct", t))
+	}
+	sf, ok := t.FieldByName(name)
+	if !ok {
+		panic(fmt.Sprintf("validate: RegisterAddressVerifier: %v has no field %s", t, name))
+	}
+	if derefType(sf.Type).Kind() != reflect.String {
+		panic(fmt.Sprintf("validate: RegisterAddressVerifier: %v.%s is not a string", t, name))
+	}
+	return sf
+}
@@ -0,0 +1,99 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type shippingAddress struct {
+	Street string  `json:"street"`
+	Unit   *string `json:"unit"`
+	City   string  `json:"city"`
+	Zip    string  `json:"zip"`
+}
+
+type billingAddress struct {
+	Street string `json:"street"`
+	Zip    string `json:"zip"`
+}
+
+type outageAddress struct {
+	Street string `json:"street"`
+}
+
+func TestRegisterAddressVerifier(t *testing.T) {
+	var calls atomic.Int32
+	RegisterAddressVerifier[shippingAddress](AddressFields{Line1: "Street", Line2: "Unit", City: "City", PostalCode: "Zip"},
+		AddressVerifierFunc(func(ctx context.Context, a Address) (bool, error) {
+			calls.Add(1)
+			if _, ok := ctx.Deadline(); !ok {
+				t.Fatalf("expected a deadline on verification")
+			}
+			return a.Line1 == "1 Main St" && a.City == "Springfield" && a.Line2 == "", nil
+		}), AddressOptions{CacheTTL: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		if err := Struct(shippingAddress{Street: "1 Main St", City: "Springfield", Zip: "12345"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	unit := "4B"
+	if m := ToFieldErrors(Struct(shippingAddress{Street: "1 Main St", Unit: &unit, City: "Springfield"})); m["street"] != "is not a deliverable address" {
+		t.Fatalf("expected address failure on street, got %v", m)
+	}
+	if err := Struct(shippingAddress{}); err != nil {
+		t.Fatalf("expected empty address to be skipped, got %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected cached answers, got %d calls", n)
+	}
+}
+
+func TestRegisterAddressVerifier_SoftFail(t *testing.T) {
+	RegisterAddressVerifier[billingAddress](AddressFields{Line1: "Street", PostalCode: "Zip"},
+		AddressVerifierFunc(func(context.Context, Address) (bool, error) { return false, nil }),
+		AddressOptions{SoftFail: true, Messages: map[string]string{"en": "could not be verified", "es": "no se pudo verificar"}})
+
+	ctx := CollectWarnings(WithLocale(context.Background(), "es"))
+	if err := StructCtx(ctx, billingAddress{Street: "nowhere"}); err != nil {
+		t.Fatalf("expected soft failure to pass, got %v", err)
+	}
+	if w := ContextWarnings(ctx); len(w) != 1 || w["street"] != "no se pudo verificar" {
+		t.Fatalf("expected localized warning, got %v", w)
+	}
+	if err := Struct(billingAddress{Street: "nowhere"}); err != nil {
+		t.Fatalf("expected soft failure to pass without a collector, got %v", err)
+	}
+}
+
+func TestRegisterAddressVerifier_ProviderErrors(t *testing.T) {
+	var metrics atomic.Int32
+	SetMetrics(func(metric string, labels map[string]string) {
+		if metric == MetricRemoteError && labels["tag"] == "address" {
+			metrics.Add(1)
+		}
+	})
+	defer SetMetrics(nil)
+
+	RegisterAddressVerifier[outageAddress](AddressFields{Line1: "Street"},
+		AddressVerifierFunc(func(context.Context, Address) (bool, error) { return false, errors.New("unavailable") }),
+		AddressOptions{})
+	if err := Struct(outageAddress{Street: "1 Main St"}); err != nil {
+		t.Fatalf("expected provider errors to fail open, got %v", err)
+	}
+	if metrics.Load() != 1 {
+		t.Fatalf("expected one remote error metric, got %d", metrics.Load())
+	}
+}
+
+func TestRegisterAddressVerifier_PanicsOnBadField(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	RegisterAddressVerifier[shippingAddress](AddressFields{Line1: "Street", City: "Town"}, AddressVerifierFunc(nil), AddressOptions{})
+}
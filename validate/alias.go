@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterAlias registers alias for tags on the global Validator, e.g.
+// RegisterAlias("us_zip", "numeric,len=5"), and a default English message for
+// it. Without an explicit message one is composed from the underlying tags
+// ("must contain only numbers and must be length 5"); pass message to override
+// it ("must be a 5-digit US ZIP code"). Failures are reported under the alias
+// tag, so translations can also be registered for it with RegisterMessages.
+func RegisterAlias(alias, tags string, message ...string) {
+	Default().RegisterAlias(alias, tags, message...)
+}
+
+// RegisterAlias registers alias for tags on e with a default English message.
+// See the package-level RegisterAlias.
+func (e *DefaultEngine) RegisterAlias(alias, tags string, message ...string) {
+	_ = e.register(func(v *validator.Validate) error {
+		v.RegisterAlias(alias, tags)
+		return nil
+	})
+	e.recordTag(alias, tags)
+	msg := ""
+	if len(message) > 0 {
+		msg = message[0]
+	}
+	if msg == "" {
+		msg = e.composeMessage(tags)
+	}
+	e.RegisterMessages(alias, map[string]string{DefaultMessageLocale: msg})
+}
+
+// composeMessage builds a combined message for a tag expression: "," joins
+// with "and", "|" joins with "or".
+func (e *DefaultEngine) composeMessage(tags string) string {
+	var all []string
+	for _, and := range strings.Split(tags, ",") {
+		var alts []string
+		for _, or := range strings.Split(and, "|") {
+			tag, param, _ := strings.Cut(strings.TrimSpace(or), "=")
+			if tag == "" || tag == "omitempty" {
+				continue
+			}
+			alts = append(alts, e.tagMessage(tag, param))
+		}
+		if len(alts) > 0 {
+			all = append(all, strings.Join(alts, " or "))
+		}
+	}
+	return strings.Join(all, " and ")
+}
+
+// tagMessage returns the default English message for tag: a registered
+// message if any, otherwise the built-in fallback.
+func (e *DefaultEngine) tagMessage(tag, param string) string {
+	e.mu.RLock()
+	msg := e.messages[tag][DefaultMessageLocale]
+	e.mu.RUnlock()
+	if msg != "" {
+		return strings.NewReplacer("{param}", param).Replace(msg)
+	}
+	return defaultMessageFor(tag, param)
+}
@@ -0,0 +1,53 @@
+package validate
+
+import (
+	"context"
+	"testing"
+)
+
+type address struct {
+	Zip     string `json:"zip" validate:"us_zip"`
+	Country string `json:"country" validate:"country_code"`
+	Color   string `json:"color" validate:"omitempty,hexish"`
+}
+
+func init() {
+	RegisterAlias("us_zip", "numeric,len=5", "must be a 5-digit US ZIP code")
+	RegisterAlias("country_code", "required,alpha,len=2")
+	RegisterAlias("hexish", "hexcolor|rgb")
+}
+
+func TestRegisterAlias_OverrideMessage(t *testing.T) {
+	m := ToFieldErrors(Struct(address{Zip: "12a", Country: "US"}))
+	if m["zip"] != "must be a 5-digit US ZIP code" {
+		t.Fatalf("expected alias message, got %v", m)
+	}
+}
+
+func TestRegisterAlias_ComposedMessage(t *testing.T) {
+	m := ToFieldErrors(Struct(address{Zip: "12345", Country: "USA", Color: "nope"}))
+	if m["country"] != "is required and must contain only letters and must be length 2" {
+		t.Fatalf("unexpected composed message: %v", m)
+	}
+	if m["color"] != "failed hexcolor or failed rgb" {
+		t.Fatalf("unexpected or-composed message: %v", m)
+	}
+}
+
+func TestRegisterAlias_TranslatableUnderAlias(t *testing.T) {
+	RegisterMessages("us_zip", map[string]string{"en": "bad zip", "es": "código postal inválido"})
+	defer RegisterAlias("us_zip", "numeric,len=5", "must be a 5-digit US ZIP code")
+	m := ToFieldErrorsWithContext(WithLocale(context.Background(), "es"), Struct(address{Zip: "1", Country: "US"}))
+	if m["zip"] != "código postal inválido" {
+		t.Fatalf("expected translated alias message, got %v", m)
+	}
+}
+
+func TestComposeMessage_UsesRegisteredMessages(t *testing.T) {
+	if got := Default().composeMessage("sku,prefixed=AB"); got != "must be a valid SKU and {field} must start with AB" {
+		t.Fatalf("unexpected message: %q", got)
+	}
+	if got := Default().composeMessage("omitempty,max=3"); got != "must be at most 3" {
+		t.Fatalf("unexpected message: %q", got)
+	}
+}
@@ -0,0 +1,111 @@
+package validate
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Limits for inline-encoded files. Both decode the value as a stream, so
+	// oversized payloads are never held in memory decoded.
+	mustRegister("base64_maxbytes", base64MaxBytes, map[string]string{DefaultMessageLocale: "must be base64 data of at most {param}"})
+	mustRegister("base64_mime", base64MIME, map[string]string{DefaultMessageLocale: "must be a base64-encoded file of type {param}"})
+}
+
+// base64MaxBytes accepts base64 strings whose decoded size is within the
+// parameter, a byte count with an optional unit: `base64_maxbytes=1MB`.
+func base64MaxBytes(fl validator.FieldLevel) bool {
+	limit, ok := parseByteSize(fl.Param())
+	if !ok || fl.Field().Kind() != reflect.String {
+		return false
+	}
+	_, data, ok := splitDataURL(fl.Field().String())
+	if !ok {
+		return false
+	}
+	n, err := io.Copy(io.Discard, io.LimitReader(base64Reader(data), limit+1))
+	return err == nil && n <= limit
+}
+
+// base64MIME accepts base64 strings whose decoded content is sniffed as one of
+// the space-separated media types in the parameter, e.g.
+// `base64_mime=image/png image/jpeg`. A data URL prefix
+// ("data:image/png;base64,") must declare an allowed type as well.
+func base64MIME(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	declared, data, ok := splitDataURL(fl.Field().String())
+	if !ok {
+		return false
+	}
+	allowed := strings.Fields(fl.Param())
+	if declared != "" && !containsString(allowed, declared) {
+		return false
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(base64Reader(data), head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF || n == 0 {
+		return false
+	}
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	return containsString(allowed, sniffed)
+}
+
+// splitDataURL strips an optional "data:<type>;base64," prefix and returns
+// the declared media type. ok is false for data URLs that are not base64.
+func splitDataURL(s string) (mediaType, data string, ok bool) {
+	if !strings.HasPrefix(s, "data:") {
+		return "", s, true
+	}
+	header, data, found := strings.Cut(s[len("data:"):], ",")
+	if !found || !strings.HasSuffix(header, ";base64") {
+		return "", "", false
+	}
+	mediaType, _, _ = strings.Cut(strings.TrimSuffix(header, ";base64"), ";")
+	return strings.ToLower(mediaType), data, true
+}
+
+// base64Reader decodes standard or URL-safe base64, padded or not. Line
+// breaks, as in MIME-encoded content, are ignored.
+func base64Reader(s string) io.Reader {
+	enc := base64.RawStdEncoding
+	if strings.ContainsAny(s, "-_") {
+		enc = base64.RawURLEncoding
+	}
+	return base64.NewDecoder(enc, strings.NewReader(strings.TrimRight(s, "=\r\n")))
+}
+
+// byteUnits maps size suffixes to multipliers: decimal KB, MB and GB, and
+// binary KiB, MiB and GiB.
+var byteUnits = []struct {
+	suffix string
+	n      int64
+}{
+	{"KIB", 1 << 10}, {"MIB", 1 << 20}, {"GIB", 1 << 30},
+	{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"B", 1},
+}
+
+// parseByteSize parses sizes such as "512", "64KB" or "1MiB".
+func parseByteSize(s string) (int64, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	mult := int64(1)
+	for _, u := range byteUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			s, mult = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.n
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n * mult, true
+}
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// pngHeader is the PNG signature followed by the start of an IHDR chunk.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestBase64MaxBytes(t *testing.T) {
+	small := base64.StdEncoding.EncodeToString(make([]byte, 1000))
+	cases := []struct {
+		value, tag string
+		ok         bool
+	}{
+		{small, "base64_maxbytes=1KB", true},
+		{small, "base64_maxbytes=999", false},
+		{base64.StdEncoding.EncodeToString(make([]byte, 1025)), "base64_maxbytes=1KiB", false},
+		{base64.RawURLEncoding.EncodeToString([]byte{0xfb, 0xff}), "base64_maxbytes=2B", true},
+		{"data:text/plain;base64," + base64.StdEncoding.EncodeToString([]byte("hi")), "base64_maxbytes=2", true},
+		{"data:text/plain,hi", "base64_maxbytes=2", false},
+		{"not base64!", "base64_maxbytes=1MB", false},
+		{small, "base64_maxbytes=lots", false},
+	}
+	for _, c := range cases {
+		if err := Var("file", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%s on %.30q: expected ok=%v, got %v", c.tag, c.value, c.ok, err)
+		}
+	}
+	if got := ToFieldErrors(Var("file", small, "base64_maxbytes=1B"))["file"]; got != "must be base64 data of at most 1B" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestBase64MIME(t *testing.T) {
+	png := base64.StdEncoding.EncodeToString(pngHeader)
+	wrapped := strings.Join([]string{png[:8], png[8:]}, "\r\n")
+	cases := []struct {
+		value, tag string
+		ok         bool
+	}{
+		{png, "base64_mime=image/png", true},
+		{wrapped, "base64_mime=image/jpeg image/png", true},
+		{"data:image/png;base64," + png, "base64_mime=image/png", true},
+		{"data:image/gif;base64," + png, "base64_mime=image/png", false},
+		{base64.StdEncoding.EncodeToString([]byte("<html></html>")), "base64_mime=image/png", false},
+		{base64.StdEncoding.EncodeToString([]byte("hello")), "base64_mime=text/plain", true},
+		{"", "base64_mime=image/png", false},
+	}
+	for _, c := range cases {
+		if err := Var("file", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%s on %.30q: expected ok=%v, got %v", c.tag, c.value, c.ok, err)
+		}
+	}
+	if got := ToFieldErrors(Var("file", "aGVsbG8=", "base64_mime=image/png"))["file"]; got != "must be a base64-encoded file of type image/png" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
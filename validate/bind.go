@@ -0,0 +1,122 @@
+package validate
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/goflash/flash/v2/ctx"
+)
+
+// BindOptions customizes BindAndValidate.
+type BindOptions struct {
+	// JSON is forwarded to c.BindJSON. Nil keeps flash defaults
+	// (unknown fields rejected, no type coercion).
+	JSON *ctx.BindJSONOptions
+	// Coerce is forwarded to Coerce for query and form input. Its TimeLayout
+	// also applies to time.Time fields of JSON bodies.
+	Coerce CoerceOptions
+	// OnValidationError, if set, observes the validation error (usually
+	// validator.ValidationErrors) before it is returned, e.g. to record
+	// failing tags.
+	OnValidationError func(err error)
+	// MaxBodyBytes rejects request bodies larger than this many bytes before
+	// decoding. 0 means no limit.
+	MaxBodyBytes int64
+	// MaxFields rejects payloads with more fields: object keys anywhere in a
+	// JSON body, or distinct keys in query and form input. 0 means no limit.
+	MaxFields int
+	// MaxArrayLength rejects JSON arrays, or repeated query and form values,
+	// with more items, reported under the offending field. 0 means no limit.
+	MaxArrayLength int
+	// UseNumber keeps JSON numbers bound to untyped targets (any,
+	// map[string]any) as json.Number instead of float64, so large integers
+	// survive for validation with tags such as intstring and max_digits.
+	// Fields declared as json.Number always receive the number verbatim.
+	UseNumber bool
+	// RetainBody keeps a copy of up to this many bytes of a JSON or form
+	// body in the request context, readable with RawBody, for error
+	// enrichment and auditing. Longer bodies are cut at the cap. 0 retains
+	// nothing.
+	RetainBody int64
+}
+
+// BindAndValidate binds the request into v and validates it. GET, HEAD and
+// DELETE requests bind the query string; form content types bind the form
+// body; everything else binds a JSON body. Query and form values go through
+// Coerce, so conversion failures are reported per field.
+//
+// Binding failures are returned as a *BindingError holding FieldErrors with
+// messages resolved from the request context; validation failures as the
+// validation error itself (usually validator.ValidationErrors), so callers
+// and ToErrorGroups can tell them apart. ToFieldErrorsWithContext converts
+// either. Payloads exceeding MaxBodyBytes, MaxFields or MaxArrayLength are rejected
+// before any decoding into v. JSON numbers are checked against the field
+// types: fractions for integer fields and values that overflow the field are
+// reported per field instead of being truncated, and so are strings that do
+// not parse as a time.Time field's layout. Unknown JSON fields close to a field of v get a
+// hint, e.g. "unexpected field; did you mean 'email'?". With RetainBody, the
+// body read is kept for RawBody before decoding.
+func BindAndValidate(c ctx.Ctx, v any, opts ...BindOptions) error {
+	var o BindOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	switch c.Method() {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		values := c.Request().URL.Query()
+		if fe := checkValueLimits(values, o.MaxFields, o.MaxArrayLength); fe != nil {
+			return &BindingError{Err: fe}
+		}
+		return bindValues(c, values, v, o.Coerce, o.OnValidationError)
+	}
+	mt, _, _ := mime.ParseMediaType(c.Request().Header.Get("Content-Type"))
+	if mt == "application/x-www-form-urlencoded" || mt == "multipart/form-data" {
+		if o.MaxBodyBytes > 0 || o.RetainBody > 0 {
+			body, fe := readLimitedBody(c, o.MaxBodyBytes)
+			if fe != nil {
+				return &BindingError{Err: fe}
+			}
+			retainBody(c, body, o.RetainBody)
+		}
+		values, err := formValues(c)
+		if err != nil {
+			return &BindingError{Err: FieldErrors(ToFieldErrorsWithContext(c.Context(), err))}
+		}
+		if fe := checkValueLimits(values, o.MaxFields, o.MaxArrayLength); fe != nil {
+			return &BindingError{Err: fe}
+		}
+		return bindValues(c, values, v, o.Coerce, o.OnValidationError)
+	}
+
+	body, fe := readLimitedBody(c, o.MaxBodyBytes)
+	if fe == nil {
+		retainBody(c, body, o.RetainBody)
+	}
+	if fe == nil && (o.MaxFields > 0 || o.MaxArrayLength > 0) {
+		fe = checkJSONLimits(body, o.MaxFields, o.MaxArrayLength)
+	}
+	if fe != nil {
+		return &BindingError{Err: fe}
+	}
+	if err := bindJSON(c, body, v, o); err != nil {
+		res := ToFieldErrorsWithContext(c.Context(), err)
+		suggestUnexpected(res, v)
+		return &BindingError{Err: FieldErrors(res)}
+	}
+	if err := StructCtx(c.Context(), v); err != nil {
+		if o.OnValidationError != nil {
+			o.OnValidationError(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// Validated binds and validates the request into a new T, as BindAndValidate.
+//
+//	in, err := validate.Validated[CreateUser](c, validate.BindOptions{MaxBodyBytes: 1 << 20})
+func Validated[T any](c ctx.Ctx, opts ...BindOptions) (T, error) {
+	var v T
+	err := BindAndValidate(c, &v, opts...)
+	return v, err
+}
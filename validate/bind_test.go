@@ -0,0 +1,128 @@
+package validate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/flash/v2/ctx"
+)
+
+type bindUser struct {
+	Name string `json:"name" validate:"required"`
+	Age  int    `json:"age" validate:"gte=18"`
+}
+
+func serveBind(t *testing.T, method, body, contentType string, opts ...BindOptions) (bindUser, FieldErrors) {
+	t.Helper()
+	var (
+		in  bindUser
+		res FieldErrors
+	)
+	app := flash.New()
+	app.Handle(method, "/users", func(c flash.Ctx) error {
+		if err := BindAndValidate(c, &in, opts...); err != nil {
+			res = FieldErrors(ToFieldErrorsWithContext(c.Context(), err))
+		}
+		return c.String(http.StatusOK, "ok")
+	})
+	target := "/users"
+	var req *http.Request
+	if method == http.MethodGet {
+		req = httptest.NewRequest(method, target+"?"+body, nil)
+	} else {
+		req = httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+	}
+	app.ServeHTTP(httptest.NewRecorder(), req)
+	return in, res
+}
+
+func TestBindAndValidate_JSON(t *testing.T) {
+	in, fe := serveBind(t, http.MethodPost, `{"name":"Ann","age":30}`, "application/json")
+	if fe != nil || in.Name != "Ann" || in.Age != 30 {
+		t.Fatalf("expected valid bind, got %+v %v", in, fe)
+	}
+	_, fe = serveBind(t, http.MethodPost, `{"age":10}`, "application/json")
+	if fe["name"] != "is required" || fe["age"] != "must be greater than or equal to 18" {
+		t.Fatalf("unexpected errors: %v", fe)
+	}
+	_, fe = serveBind(t, http.MethodPost, `{"name":"Ann","age":30,"extra":1}`, "application/json")
+	if _, ok := fe["extra"]; !ok {
+		t.Fatalf("expected unknown field error, got %v", fe)
+	}
+	_, fe = serveBind(t, http.MethodPost, `{"name":"Ann","age":30,"extra":1}`, "application/json",
+		BindOptions{JSON: &ctx.BindJSONOptions{}})
+	if fe != nil {
+		t.Fatalf("expected unknown fields to be ignored, got %v", fe)
+	}
+}
+
+func TestBindAndValidate_QueryAndForm(t *testing.T) {
+	in, fe := serveBind(t, http.MethodGet, "name=Ann&age=20", "")
+	if fe != nil || in.Age != 20 {
+		t.Fatalf("expected valid query bind, got %+v %v", in, fe)
+	}
+	_, fe = serveBind(t, http.MethodPost, "name=Ann&age=old", "application/x-www-form-urlencoded")
+	if fe["age"] != "must be an integer" {
+		t.Fatalf("expected coercion error, got %v", fe)
+	}
+}
+
+func TestBindAndValidate_OnValidationError(t *testing.T) {
+	var seen []error
+	hook := BindOptions{OnValidationError: func(err error) { seen = append(seen, err) }}
+	serveBind(t, http.MethodPost, `{"age":10}`, "application/json", hook)
+	serveBind(t, http.MethodGet, "age=10", "", hook)
+	serveBind(t, http.MethodPost, `{bad`, "application/json", hook)
+	if len(seen) != 2 {
+		t.Fatalf("expected hook for validation failures only, got %v", seen)
+	}
+	if _, ok := seen[0].(validator.ValidationErrors); !ok {
+		t.Fatalf("expected raw ValidationErrors, got %T", seen[0])
+	}
+}
+
+func TestBindAndValidate_ErrorTypes(t *testing.T) {
+	serve := func(method, body, contentType string) (err error, groups ErrorGroups) {
+		t.Helper()
+		app := flash.New()
+		app.Handle(method, "/users", func(c flash.Ctx) error {
+			var in bindUser
+			err = BindAndValidate(c, &in)
+			groups = ToErrorGroups(c.Context(), err)
+			return nil
+		})
+		req := httptest.NewRequest(method, "/users", strings.NewReader(body))
+		if method == http.MethodGet {
+			req = httptest.NewRequest(method, "/users?"+body, nil)
+		}
+		req.Header.Set("Content-Type", contentType)
+		app.ServeHTTP(httptest.NewRecorder(), req)
+		return err, groups
+	}
+
+	err, g := serve(http.MethodPost, `{"name":1}`, "application/json")
+	var be *BindingError
+	if !errors.As(err, &be) || g.Binding["name"] == "" || g.Validation != nil || g.Business != nil {
+		t.Fatalf("expected a binding error, got %T %+v", err, g)
+	}
+
+	err, g = serve(http.MethodPost, `{"age":30}`, "application/json")
+	if _, ok := err.(validator.ValidationErrors); !ok || g.Validation["name"] != "is required" || g.Binding != nil {
+		t.Fatalf("expected validation errors, got %T %+v", err, g)
+	}
+
+	err, g = serve(http.MethodGet, "age=old", "")
+	if !errors.As(err, &be) || g.Binding["age"] != "must be an integer" || len(g.Binding) != 1 ||
+		g.Validation["name"] != "is required" || len(g.Validation) != 1 {
+		t.Fatalf("expected coercion under binding and rules under validation, got %+v", g)
+	}
+	if fe := ToFieldErrors(err); fe["age"] != "must be an integer" || fe["name"] != "is required" || len(fe) != 2 {
+		t.Fatalf("expected merged messages, got %v", fe)
+	}
+}
@@ -0,0 +1,46 @@
+package validate
+
+import "sync"
+
+// BindingErrorParser maps a decoding error to messages by field. ok is false
+// for errors it does not understand.
+type BindingErrorParser func(err error) (fields map[string]string, ok bool)
+
+var (
+	bindingParsersMu sync.RWMutex
+	bindingParsers   []BindingErrorParser
+)
+
+// RegisterBindingErrorParser adds a parser for decoding errors the built-in
+// mapping does not understand (it knows flash's binding errors, validator
+// errors and mapstructure's messages), e.g. for encoding/json strict mode,
+// jsoniter or custom decoders:
+//
+//	validate.RegisterBindingErrorParser(func(err error) (map[string]string, bool) {
+//		var e *jsoniter.DecodeError
+//		if !errors.As(err, &e) {
+//			return nil, false
+//		}
+//		return map[string]string{e.Field: "has an invalid value"}, true
+//	})
+//
+// Parsers run in registration order before the mapstructure parsing; the
+// first one returning ok wins. Register them at startup.
+func RegisterBindingErrorParser(p BindingErrorParser) {
+	bindingParsersMu.Lock()
+	bindingParsers = append(bindingParsers, p)
+	bindingParsersMu.Unlock()
+}
+
+// parseBindingError runs the registered parsers on err.
+func parseBindingError(err error) (map[string]string, bool) {
+	bindingParsersMu.RLock()
+	ps := bindingParsers
+	bindingParsersMu.RUnlock()
+	for _, p := range ps {
+		if fields, ok := p(err); ok && len(fields) > 0 {
+			return fields, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegisterBindingErrorParser(t *testing.T) {
+	defer func() {
+		bindingParsersMu.Lock()
+		bindingParsers = nil
+		bindingParsersMu.Unlock()
+	}()
+	// encoding/json strict mode: json: unknown field "nick"
+	RegisterBindingErrorParser(func(err error) (map[string]string, bool) {
+		field, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+		if !ok {
+			return nil, false
+		}
+		return map[string]string{strings.Trim(field, `"`): "unexpected"}, true
+	})
+	RegisterBindingErrorParser(func(error) (map[string]string, bool) {
+		t.Fatalf("later parsers should not run once one matched")
+		return nil, false
+	})
+
+	var v struct {
+		Name string `json:"name"`
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(`{"nick":"x"}`)))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&v)
+	if got := ToFieldErrors(err); got["nick"] != "unexpected" || len(got) != 1 {
+		t.Fatalf("expected parsed field, got %v", got)
+	}
+	if g := ToErrorGroups(context.Background(), err); g.Binding["nick"] != "unexpected" {
+		t.Fatalf("expected parsed error in binding group, got %+v", g)
+	}
+}
+
+func TestParseBindingError_NoParsers(t *testing.T) {
+	if _, ok := parseBindingError(errors.New("x")); ok {
+		t.Fatalf("expected no match without parsers")
+	}
+	if got := ToFieldErrors(errors.New("boom")); got["_error"] != "boom" {
+		t.Fatalf("expected fallback, got %v", got)
+	}
+}
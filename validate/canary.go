@@ -0,0 +1,197 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Divergence kinds reported by Canary.
+const (
+	// DivergenceNewlyFailing: the current rules accept the value, the
+	// candidate rejects it.
+	DivergenceNewlyFailing = "newly_failing"
+	// DivergenceNewlyPassing: the current rules reject the value, the
+	// candidate accepts it.
+	DivergenceNewlyPassing = "newly_passing"
+	// DivergenceChanged: both reject the value, on different fields or tags.
+	DivergenceChanged = "changed"
+)
+
+// Divergence records one validation where the candidate disagreed.
+type Divergence struct {
+	Kind string
+	// Type is the validated type ("string" etc. for Var).
+	Type string
+	// Current and Candidate map failing fields to their tags.
+	Current   map[string]string
+	Candidate map[string]string
+	Time      time.Time
+}
+
+// CanaryStats are the counters of a Canary.
+type CanaryStats struct {
+	Compared      uint64
+	NewlyFailing  uint64
+	NewlyPassing  uint64
+	Changed       uint64
+	LastDivergent time.Time
+}
+
+// CanaryOptions customizes NewCanary.
+type CanaryOptions struct {
+	// Keep is the number of recent divergences retained. Default: 100.
+	Keep int
+	// Logger receives one warning per divergence. Default: slog.Default().
+	Logger *slog.Logger
+}
+
+// Canary is an Engine that validates with the current rules and also runs a
+// candidate rule set on the same value, recording divergences (logged,
+// counted in MetricCanaryDivergence and kept in memory). Results always come
+// from the current engine, so clients are unaffected. Install it with
+// SetEngine or WithEngine to make rule changes data-driven:
+//
+//	candidate := validate.Global().Clone()
+//	candidate.RegisterStructValidationMapRules(map[string]string{"Name": "required,min=3"}, User{})
+//	canary := validate.NewCanary(validate.Global(), candidate)
+//	validate.SetEngine(canary)
+type Canary struct {
+	current, candidate Engine
+	logger             *slog.Logger
+	keep               int
+
+	compared, newlyFailing, newlyPassing, changed atomic.Uint64
+
+	mu     sync.Mutex
+	recent []Divergence
+	last   time.Time
+}
+
+var _ Engine = (*Canary)(nil)
+
+// NewCanary returns a Canary comparing candidate against current.
+func NewCanary(current, candidate Engine, opts ...CanaryOptions) *Canary {
+	var o CanaryOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.Keep <= 0 {
+		o.Keep = 100
+	}
+	return &Canary{current: current, candidate: candidate, logger: o.Logger, keep: o.Keep}
+}
+
+// Struct validates s with both engines and returns the current result.
+func (c *Canary) Struct(s any) error { return c.StructCtx(context.Background(), s) }
+
+// StructCtx validates s with both engines and returns the current result.
+func (c *Canary) StructCtx(ctx context.Context, s any) error {
+	err := c.current.StructCtx(ctx, s)
+	c.compare(ctx, s, err, c.candidate.StructCtx(ctx, s))
+	return err
+}
+
+// Var validates field with both engines and returns the current result.
+func (c *Canary) Var(field any, tag string) error { return c.VarCtx(context.Background(), field, tag) }
+
+// VarCtx validates field with both engines and returns the current result.
+func (c *Canary) VarCtx(ctx context.Context, field any, tag string) error {
+	err := c.current.VarCtx(ctx, field, tag)
+	c.compare(ctx, field, err, c.candidate.VarCtx(ctx, field, tag))
+	return err
+}
+
+// Stats returns the counters.
+func (c *Canary) Stats() CanaryStats {
+	c.mu.Lock()
+	last := c.last
+	c.mu.Unlock()
+	return CanaryStats{
+		Compared:      c.compared.Load(),
+		NewlyFailing:  c.newlyFailing.Load(),
+		NewlyPassing:  c.newlyPassing.Load(),
+		Changed:       c.changed.Load(),
+		LastDivergent: last,
+	}
+}
+
+// Divergences returns the most recent divergences, oldest first.
+func (c *Canary) Divergences() []Divergence {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Divergence(nil), c.recent...)
+}
+
+func (c *Canary) compare(ctx context.Context, v any, curErr, candErr error) {
+	c.compared.Add(1)
+	cur, cand := failedTags(curErr), failedTags(candErr)
+
+	var kind string
+	switch {
+	case cur == nil && cand == nil:
+		return
+	case cur == nil:
+		kind = DivergenceNewlyFailing
+		c.newlyFailing.Add(1)
+	case cand == nil:
+		kind = DivergenceNewlyPassing
+		c.newlyPassing.Add(1)
+	case reflect.DeepEqual(cur, cand):
+		return
+	default:
+		kind = DivergenceChanged
+		c.changed.Add(1)
+	}
+
+	d := Divergence{Kind: kind, Type: typeName(v), Current: cur, Candidate: cand, Time: time.Now()}
+	c.mu.Lock()
+	c.recent = append(c.recent, d)
+	if len(c.recent) > c.keep {
+		c.recent = c.recent[len(c.recent)-c.keep:]
+	}
+	c.last = d.Time
+	c.mu.Unlock()
+
+	incMetric(MetricCanaryDivergence, map[string]string{"kind": kind, "type": d.Type})
+	logger := c.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.WarnContext(ctx, "canary validation diverged",
+		"kind", kind, "type", d.Type, "current", cur, "candidate", cand)
+}
+
+// failedTags maps failing fields to tags, nil for no error. Non-validation
+// errors are recorded under "_error".
+func failedTags(err error) map[string]string {
+	if err == nil {
+		return nil
+	}
+	var vErrs validator.ValidationErrors
+	if !errors.As(err, &vErrs) {
+		return map[string]string{"_error": err.Error()}
+	}
+	out := make(map[string]string, len(vErrs))
+	for _, fe := range vErrs {
+		out[fe.Namespace()] = fe.Tag()
+	}
+	return out
+}
+
+func typeName(v any) string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "nil"
+	}
+	return t.String()
+}
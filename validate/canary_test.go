@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type canaryReq struct {
+	Name string `json:"name" validate:"required"`
+	Nick string `json:"nick" validate:"omitempty,max=5"`
+}
+
+func TestCanary_RecordsDivergences(t *testing.T) {
+	candidate := Global().Clone()
+	candidate.RegisterStructValidationMapRules(map[string]string{"Name": "required,min=3", "Nick": "omitempty"}, canaryReq{})
+
+	var logs bytes.Buffer
+	var kinds []string
+	SetMetrics(func(metric string, labels map[string]string) {
+		if metric == MetricCanaryDivergence {
+			kinds = append(kinds, labels["kind"]+":"+labels["type"])
+		}
+	})
+	defer SetMetrics(nil)
+
+	c := NewCanary(Global(), candidate, CanaryOptions{Keep: 2, Logger: slog.New(slog.NewTextHandler(&logs, nil))})
+	ctx := context.Background()
+
+	if err := c.StructCtx(ctx, canaryReq{Name: "Ann"}); err != nil {
+		t.Fatalf("expected agreement, got %v", err)
+	}
+	if err := c.Struct(&canaryReq{Name: "Al"}); err != nil {
+		t.Fatalf("expected current result (pass), got %v", err)
+	}
+	if err := c.StructCtx(ctx, canaryReq{Name: "Ann", Nick: "toolong"}); err == nil {
+		t.Fatalf("expected current result (fail)")
+	}
+	if err := c.Struct(canaryReq{Nick: "toolong"}); err == nil {
+		t.Fatalf("expected current result (fail)")
+	}
+	if err := c.Var("", "required"); err == nil {
+		t.Fatalf("expected Var to be validated")
+	}
+
+	st := c.Stats()
+	if st.Compared != 5 || st.NewlyFailing != 1 || st.NewlyPassing != 1 || st.Changed != 1 || st.LastDivergent.IsZero() {
+		t.Fatalf("unexpected stats: %+v", st)
+	}
+	d := c.Divergences()
+	if len(d) != 2 || d[0].Kind != DivergenceNewlyPassing || d[1].Kind != DivergenceChanged {
+		t.Fatalf("unexpected divergences: %+v", d)
+	}
+	if d[1].Current["canaryReq.nick"] != "max" || d[1].Candidate["canaryReq.name"] != "required" {
+		t.Fatalf("unexpected divergence detail: %+v", d[1])
+	}
+	want := []string{"newly_failing:validate.canaryReq", "newly_passing:validate.canaryReq", "changed:validate.canaryReq"}
+	if strings.Join(kinds, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected metrics: %v", kinds)
+	}
+	if strings.Count(logs.String(), "canary validation diverged") != 3 {
+		t.Fatalf("unexpected logs: %q", logs.String())
+	}
+}
@@ -0,0 +1,147 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CaptchaTag is the tag registered by RegisterCaptcha.
+const CaptchaTag = "captcha"
+
+func init() {
+	registerBuiltin(func(e *DefaultEngine) {
+		e.RegisterMessages(CaptchaTag, map[string]string{DefaultMessageLocale: "verification failed"})
+	})
+}
+
+// CaptchaVerifier verifies a captcha response token with its provider. It
+// reports whether the token is valid; a non-nil error means the provider
+// could not answer.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token string) (bool, error)
+}
+
+// Siteverify endpoints of the supported captcha providers.
+const (
+	ReCAPTCHAEndpoint = "https://www.google.com/recaptcha/api/siteverify"
+	HCaptchaEndpoint  = "https://api.hcaptcha.com/siteverify"
+	TurnstileEndpoint = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+)
+
+// SiteVerify verifies tokens with a siteverify API, the protocol shared by
+// reCAPTCHA, hCaptcha and Cloudflare Turnstile: the secret and token are
+// posted as a form and the provider answers {"success": true|false}.
+type SiteVerify struct {
+	Client *http.Client
+	// Endpoint is the provider's siteverify URL.
+	Endpoint string
+	// Secret is the site's secret key.
+	Secret string
+	// MinScore rejects reCAPTCHA v3 tokens scoring below it. 0 ignores scores.
+	MinScore float64
+}
+
+// NewReCAPTCHA returns a Google reCAPTCHA verifier using client (nil for
+// http.DefaultClient).
+func NewReCAPTCHA(secret string, client *http.Client) *SiteVerify {
+	return &SiteVerify{Client: client, Endpoint: ReCAPTCHAEndpoint, Secret: secret}
+}
+
+// NewHCaptcha returns an hCaptcha verifier using client (nil for
+// http.DefaultClient).
+func NewHCaptcha(secret string, client *http.Client) *SiteVerify {
+	return &SiteVerify{Client: client, Endpoint: HCaptchaEndpoint, Secret: secret}
+}
+
+// NewTurnstile returns a Cloudflare Turnstile verifier using client (nil for
+// http.DefaultClient).
+func NewTurnstile(secret string, client *http.Client) *SiteVerify {
+	return &SiteVerify{Client: client, Endpoint: TurnstileEndpoint, Secret: secret}
+}
+
+// Verify posts token to the siteverify endpoint. Empty tokens are reported
+// invalid without a request.
+func (s *SiteVerify) Verify(ctx context.Context, token string) (bool, error) {
+	if strings.TrimSpace(token) == "" {
+		return false, nil
+	}
+	form := url.Values{"secret": {s.Secret}, "response": {token}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("siteverify: unexpected status %d", resp.StatusCode)
+	}
+	var out struct {
+		Success bool     `json:"success"`
+		Score   *float64 `json:"score"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return false, fmt.Errorf("siteverify: %w", err)
+	}
+	if out.Success && s.MinScore > 0 && out.Score != nil && *out.Score < s.MinScore {
+		return false, nil
+	}
+	return out.Success, nil
+}
+
+// CaptchaOptions configures RegisterCaptcha.
+type CaptchaOptions struct {
+	// Timeout bounds each verification, in addition to any deadline of the
+	// validation context. Default: 2s.
+	Timeout time.Duration
+	// FailOpen accepts tokens when the provider errors or times out. By
+	// default such tokens are rejected.
+	FailOpen bool
+	// Messages are the tag's messages by locale, as in
+	// RegisterValidationWithMessage. Default: "verification failed".
+	Messages map[string]string
+}
+
+// RegisterCaptcha registers the captcha tag on the global Validator, backed
+// by cv. The token is checked alongside the other fields, so a bot-gated form
+// gets a normal field error:
+//
+//	validate.RegisterCaptcha(validate.NewTurnstile(secret, nil), validate.CaptchaOptions{})
+//
+//	type Signup struct {
+//		Email        string `json:"email" validate:"required,email"`
+//		CaptchaToken string `json:"captcha_token" validate:"captcha"`
+//	}
+//	// {"captcha_token": "verification failed"}
+//
+// Tokens are single-use, so answers are never cached. Provider errors are
+// counted as MetricRemoteError.
+func RegisterCaptcha(cv CaptchaVerifier, opts CaptchaOptions) error {
+	return Default().RegisterCaptcha(cv, opts)
+}
+
+// RegisterCaptcha registers the captcha tag on e. See the package-level
+// RegisterCaptcha.
+func (e *DefaultEngine) RegisterCaptcha(cv CaptchaVerifier, opts CaptchaOptions) error {
+	if len(opts.Messages) == 0 {
+		opts.Messages = map[string]string{DefaultMessageLocale: "verification failed"}
+	}
+	return e.RegisterRemote(CaptchaTag, RemoteFunc(cv.Verify), RemoteOptions{
+		Timeout:    opts.Timeout,
+		FailClosed: !opts.FailOpen,
+		Messages:   opts.Messages,
+	})
+}
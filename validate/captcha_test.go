@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type captchaSignup struct {
+	Email        string `json:"email" validate:"required,email"`
+	CaptchaToken string `json:"captcha_token" validate:"captcha"`
+}
+
+func TestSiteVerify(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("secret") != "s3cret" {
+			t.Errorf("expected secret to be posted, got %q", r.FormValue("secret"))
+		}
+		switch r.FormValue("response") {
+		case "good":
+			_, _ = w.Write([]byte(`{"success":true,"score":0.9}`))
+		case "bot":
+			_, _ = w.Write([]byte(`{"success":true,"score":0.1}`))
+		case "down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+		}
+	}))
+	defer srv.Close()
+
+	for _, v := range []*SiteVerify{NewReCAPTCHA("s3cret", srv.Client()), NewHCaptcha("s3cret", srv.Client()), NewTurnstile("s3cret", srv.Client())} {
+		if v.Endpoint == "" {
+			t.Fatalf("expected a default endpoint")
+		}
+		v.Endpoint = srv.URL
+		if ok, err := v.Verify(context.Background(), "good"); err != nil || !ok {
+			t.Fatalf("expected valid token, got %v %v", ok, err)
+		}
+		if ok, err := v.Verify(context.Background(), "forged"); err != nil || ok {
+			t.Fatalf("expected invalid token, got %v %v", ok, err)
+		}
+		if _, err := v.Verify(context.Background(), "down"); err == nil {
+			t.Fatalf("expected error on provider failure")
+		}
+		if ok, err := v.Verify(context.Background(), ""); err != nil || ok {
+			t.Fatalf("expected empty token to be invalid without error")
+		}
+	}
+	v := NewReCAPTCHA("s3cret", srv.Client())
+	v.Endpoint, v.MinScore = srv.URL, 0.5
+	if ok, _ := v.Verify(context.Background(), "bot"); ok {
+		t.Fatalf("expected low score to be rejected")
+	}
+}
+
+type captchaFunc func(ctx context.Context, token string) (bool, error)
+
+func (f captchaFunc) Verify(ctx context.Context, token string) (bool, error) { return f(ctx, token) }
+
+func TestRegisterCaptcha(t *testing.T) {
+	e := NewEngine()
+	if err := e.RegisterCaptcha(captchaFunc(func(_ context.Context, token string) (bool, error) {
+		return token == "ok", nil
+	}), CaptchaOptions{}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	ctx := WithEngine(context.Background(), e)
+	m := ToFieldErrorsWithContext(ctx, StructCtx(ctx, captchaSignup{Email: "x", CaptchaToken: "bad"}))
+	if m["captcha_token"] != "verification failed" || m["email"] != "must be a valid email" {
+		t.Fatalf("expected captcha error alongside field errors, got %v", m)
+	}
+	if err := StructCtx(ctx, captchaSignup{Email: "a@b.co", CaptchaToken: "ok"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slow := captchaFunc(func(ctx context.Context, _ string) (bool, error) {
+		<-ctx.Done()
+		return false, ctx.Err()
+	})
+	for _, open := range []bool{false, true} {
+		e := NewEngine()
+		_ = e.RegisterCaptcha(slow, CaptchaOptions{Timeout: 10 * time.Millisecond, FailOpen: open})
+		if err := e.Struct(captchaSignup{Email: "a@b.co", CaptchaToken: "t"}); (err == nil) != open {
+			t.Fatalf("FailOpen=%v: unexpected result %v", open, err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package validate
+
+import (
+	"sort"
+	"strings"
+)
+
+// CatalogEntry describes one possible validation error.
+type CatalogEntry struct {
+	// Tag is the validation tag, e.g. "required" or "money_currency".
+	Tag string `json:"tag"`
+	// Param is set for messages registered for one parameter of the tag
+	// ("money_currency=JPY").
+	Param string `json:"param,omitempty"`
+	// Code is the error code (see ErrorCode), without route namespaces.
+	Code string `json:"code"`
+	// Template is the default message, with placeholders such as {param}
+	// and {label} unexpanded.
+	Template string `json:"template"`
+	// Translations are the registered messages by locale, if any.
+	Translations map[string]string `json:"translations,omitempty"`
+}
+
+// conditionalTags are the conditional tags with parameter-aware defaults.
+var conditionalTags = []string{
+	"required_if", "required_unless", "required_with", "required_with_all", "required_without", "required_without_all",
+	"excluded_if", "excluded_unless", "excluded_with", "excluded_with_all", "excluded_without", "excluded_without_all",
+}
+
+// Catalog returns every validation error the global Validator can report
+// with a known message: the built-in defaults and all messages registered
+// through this package, sorted by tag and parameter. Serve or export it as
+// JSON to generate client SDKs and support documentation:
+//
+//	json.NewEncoder(w).Encode(validate.Catalog())
+func Catalog() []CatalogEntry { return Default().Catalog() }
+
+// Catalog returns the error catalog of e. See the package-level Catalog.
+func (e *DefaultEngine) Catalog() []CatalogEntry {
+	entries := map[string]*CatalogEntry{}
+	add := func(tag, param, tmpl string) *CatalogEntry {
+		key := tag
+		if param != "" {
+			key += "=" + param
+		}
+		ce, ok := entries[key]
+		if !ok {
+			ce = &CatalogEntry{Tag: tag, Param: param, Code: ErrorCode(tag), Template: tmpl}
+			entries[key] = ce
+		}
+		return ce
+	}
+	for tag, tmpl := range defaultMessages {
+		add(tag, "", tmpl)
+	}
+	for _, tag := range conditionalTags {
+		param := "{fields}"
+		if strings.HasSuffix(tag, "_if") || strings.HasSuffix(tag, "_unless") {
+			param = "{field} {value}"
+		}
+		msg, _ := conditionalMessage(tag, param, defaultLabel)
+		add(tag, "", msg)
+	}
+	for tag := range crossFieldMessages {
+		msg, _ := crossFieldMessage(tag, "{label}", defaultLabel)
+		add(tag, "", msg)
+		if cs := strings.Replace(tag, "field", "csfield", 1); cs != tag && !strings.HasPrefix(tag, "field") {
+			add(cs, "", msg)
+		}
+	}
+
+	e.mu.RLock()
+	for key, m := range e.messages {
+		tag, param, _ := strings.Cut(key, "=")
+		ce := add(tag, param, "")
+		if msg := m[DefaultMessageLocale]; msg != "" {
+			ce.Template = msg
+		}
+		ce.Translations = make(map[string]string, len(m))
+		for locale, msg := range m {
+			ce.Translations[locale] = msg
+		}
+	}
+	e.mu.RUnlock()
+
+	out := make([]CatalogEntry, 0, len(entries))
+	for _, ce := range entries {
+		if ce.Template == "" {
+			ce.Template = defaultMessageFor(ce.Tag, "{param}")
+		}
+		out = append(out, *ce)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Tag != out[j].Tag {
+			return out[i].Tag < out[j].Tag
+		}
+		return out[i].Param < out[j].Param
+	})
+	return out
+}
@@ -0,0 +1,37 @@
+package validate
+
+import "testing"
+
+func TestCatalog(t *testing.T) {
+	e := NewEngine()
+	e.RegisterMessages("min", map[string]string{"es": "debe ser al menos {param}"})
+	e.RegisterMessages("money_currency=JPY", map[string]string{"en": "JPY amounts cannot have decimals"})
+	byKey := map[string]CatalogEntry{}
+	for _, ce := range e.Catalog() {
+		byKey[ce.Tag+"="+ce.Param] = ce
+	}
+	checks := map[string]string{
+		"required=":           "is required",
+		"min=":                "must be at least {param}",
+		"required_if=":        "is required when {field} is {value}",
+		"excluded_with=":      "must be empty when {fields} is present",
+		"eqfield=":            "must match {label}",
+		"gtcsfield=":          "must be greater than {label}",
+		"money_currency=JPY":  "JPY amounts cannot have decimals",
+		"idempotency_unique=": "has already been used",
+	}
+	for key, tmpl := range checks {
+		if byKey[key].Template != tmpl {
+			t.Fatalf("%s: expected template %q, got %+v", key, tmpl, byKey[key])
+		}
+	}
+	if ce := byKey["min="]; ce.Code != "MIN" || ce.Translations["es"] != "debe ser al menos {param}" {
+		t.Fatalf("expected code and translations, got %+v", ce)
+	}
+	if _, ok := byKey["fieldcscontains="]; ok {
+		t.Fatalf("unexpected cs variant of fieldcontains")
+	}
+	if got := Catalog(); len(got) == 0 || got[0].Tag > got[len(got)-1].Tag {
+		t.Fatalf("expected sorted global catalog")
+	}
+}
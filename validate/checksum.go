@@ -0,0 +1,119 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	checksumsMu sync.RWMutex
+	checksums   = map[string]func(string) bool{
+		"luhn":     luhnValid,
+		"mod97":    mod97Valid,
+		"verhoeff": func(s string) bool { d := digitsOf(s); return len(d) > 1 && verhoeffValid(d) },
+	}
+)
+
+func init() {
+	mustRegister("luhn", checksumTag("luhn"), map[string]string{DefaultMessageLocale: "must have a valid check digit"})
+	mustRegister("mod97", checksumTag("mod97"), map[string]string{DefaultMessageLocale: "must have a valid checksum"})
+	mustRegister("checksum", isChecksum, map[string]string{DefaultMessageLocale: "must have a valid checksum"})
+}
+
+// RegisterChecksum adds (or replaces) a checksum algorithm for
+// `checksum=<name>`:
+//
+//	validate.RegisterChecksum("order_ref", func(s string) bool {
+//		return mod11(s[:len(s)-1]) == s[len(s)-1]
+//	})
+//
+//	type Order struct {
+//		Ref string `json:"ref" validate:"required,checksum=order_ref"`
+//	}
+//
+// fn receives the value with spaces and dashes removed and letters
+// upper-cased, and is never called with "". Built in: luhn, mod97 and
+// verhoeff (luhn and mod97 are also tags of their own).
+func RegisterChecksum(name string, fn func(value string) bool) {
+	checksumsMu.Lock()
+	checksums[name] = fn
+	checksumsMu.Unlock()
+}
+
+// isChecksum applies the algorithm named in the parameter. Unknown names
+// reject everything.
+func isChecksum(fl validator.FieldLevel) bool {
+	return checksumTag(fl.Param())(fl)
+}
+
+// checksumTag returns a validation applying the named algorithm to string
+// and integer fields.
+func checksumTag(name string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		checksumsMu.RLock()
+		fn := checksums[name]
+		checksumsMu.RUnlock()
+		if fn == nil {
+			return false
+		}
+		var s string
+		field := fl.Field()
+		switch field.Kind() {
+		case reflect.String:
+			s = strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(field.String()))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s = strconv.FormatInt(field.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s = strconv.FormatUint(field.Uint(), 10)
+		default:
+			return false
+		}
+		return s != "" && fn(s)
+	}
+}
+
+// luhnValid reports whether the last digit of s is its Luhn (mod 10) check
+// digit, as on payment cards.
+func luhnValid(s string) bool {
+	d := digitsOf(s)
+	if len(d) < 2 {
+		return false
+	}
+	sum := 0
+	for i := range d {
+		n := d[len(d)-1-i]
+		if i%2 == 1 {
+			if n *= 2; n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+	}
+	return sum%10 == 0
+}
+
+// mod97Valid applies ISO 7064 MOD 97-10, the IBAN check: letters count as
+// 10 (A) to 35 (Z) and the value must leave a remainder of 1. Unlike the iban
+// tag, characters are not rearranged, so the check digits come last.
+func mod97Valid(s string) bool {
+	if len(s) < 3 {
+		return false
+	}
+	r := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			r = (r*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			r = (r*100 + int(c-'A'+10)) % 97
+		default:
+			return false
+		}
+	}
+	return r == 1
+}
@@ -0,0 +1,54 @@
+package validate
+
+import "testing"
+
+func TestChecksumTags(t *testing.T) {
+	cases := []struct {
+		value any
+		tag   string
+		ok    bool
+	}{
+		{"4539 1488 0343 6467", "luhn", true},
+		{"4539-1488-0343-6468", "luhn", false},
+		{int64(79927398713), "luhn", true},
+		{"7992739871x", "luhn", false},
+		{"ACME1234535", "mod97", true},
+		{"acme-1234535", "mod97", true},
+		{"ACME1234536", "mod97", false},
+		{"370400440532013000DE89", "mod97", true},
+		{"ACME!", "mod97", false},
+		{"2363", "checksum=verhoeff", true},
+		{"2364", "checksum=verhoeff", false},
+		{"79927398713", "checksum=luhn", true},
+		{"79927398713", "checksum=unknown", false},
+		{"", "luhn", false},
+		{1.5, "luhn", false},
+	}
+	for _, c := range cases {
+		if err := Var("ref", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%v %s: expected ok=%v, got %v", c.value, c.tag, c.ok, err)
+		}
+	}
+	if got := ToFieldErrors(Var("card", "1234", "luhn"))["card"]; got != "must have a valid check digit" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestRegisterChecksum(t *testing.T) {
+	var got string
+	RegisterChecksum("test_even", func(s string) bool {
+		got = s
+		return (s[len(s)-1]-'0')%2 == 0
+	})
+	defer func() {
+		checksumsMu.Lock()
+		delete(checksums, "test_even")
+		checksumsMu.Unlock()
+	}()
+	if err := Var("ref", "ab 12-4", "checksum=test_even"); err != nil || got != "AB124" {
+		t.Fatalf("expected normalized value to pass, got %q, %v", got, err)
+	}
+	if got := ToFieldErrors(Var("ref", "13", "checksum=test_even"))["ref"]; got != "must have a valid checksum" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
@@ -0,0 +1,167 @@
+package validate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldErrorDetail is the machine-readable form of one field failure.
+type FieldErrorDetail struct {
+	// Field is the key of the failure, as in ToFieldErrors.
+	Field string `json:"field"`
+	// Code identifies the failure for clients and support tooling, e.g.
+	// "REQUIRED" or, with a namespace, "USR_REQUIRED". See ErrorCode.
+	Code string `json:"code"`
+	// Tag and Param are the failing validation tag and its parameter.
+	Tag   string `json:"tag,omitempty"`
+	Param string `json:"param,omitempty"`
+	// Message is the localized message, as in ToFieldErrors.
+	Message string `json:"message"`
+	// Fingerprint identifies the failure across releases, even when message
+	// copy changes. See Fingerprint.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// InvalidCode is the code of failures whose tag is unknown, such as binding
+// errors already converted to FieldErrors.
+const InvalidCode = "INVALID"
+
+var (
+	codesMu    sync.RWMutex
+	errorCodes = map[string]string{}
+)
+
+// RegisterErrorCode sets the code reported for tag, replacing the default
+// (see ErrorCode). An empty code restores the default.
+func RegisterErrorCode(tag, code string) {
+	codesMu.Lock()
+	defer codesMu.Unlock()
+	if code == "" {
+		delete(errorCodes, tag)
+		return
+	}
+	errorCodes[tag] = code
+}
+
+// ErrorCode returns the code of tag: the code registered with
+// RegisterErrorCode, or the tag in upper case with other characters than
+// letters and digits replaced by "_" ("required_if" -> "REQUIRED_IF").
+func ErrorCode(tag string) string {
+	codesMu.RLock()
+	code, ok := errorCodes[tag]
+	codesMu.RUnlock()
+	if ok {
+		return code
+	}
+	if tag == "" {
+		return InvalidCode
+	}
+	b := []byte(strings.ToUpper(tag))
+	for i, c := range b {
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+// Context key for the error code namespace.
+type ctxKeyCodePrefix struct{}
+
+// WithErrorCodePrefix attaches a namespace prepended to the codes of
+// ToFieldErrorDetails, e.g. "USR_" for the user API, so codes stay unique
+// across a large API surface. Nested calls replace the prefix.
+func WithErrorCodePrefix(ctx context.Context, prefix string) context.Context {
+	return context.WithValue(ctx, ctxKeyCodePrefix{}, prefix)
+}
+
+// ErrorCodePrefix returns the namespace attached with WithErrorCodePrefix.
+func ErrorCodePrefix(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	p, _ := ctx.Value(ctxKeyCodePrefix{}).(string)
+	return p
+}
+
+// Context key for the route of the request being validated.
+type ctxKeyRoute struct{}
+
+// WithRoute attaches the route (e.g. "POST /users/:id") used to fingerprint
+// failures in ToFieldErrorDetails. Enforce sets it.
+func WithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, ctxKeyRoute{}, route)
+}
+
+// RouteFromContext returns the route attached with WithRoute.
+func RouteFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	r, _ := ctx.Value(ctxKeyRoute{}).(string)
+	return r
+}
+
+// Fingerprint returns a stable identifier of a failure of tag on field of
+// route: 16 hex characters of a SHA-256 hash. Slice and map indexes in field
+// are ignored ("items.3.sku" and "items.0.sku" share a fingerprint), so
+// dashboards can group failures regardless of position or message copy.
+func Fingerprint(route, field, tag string) string {
+	parts := strings.Split(field, ".")
+	for i, p := range parts {
+		if _, err := strconv.Atoi(p); err == nil {
+			parts[i] = "*"
+		}
+	}
+	sum := sha256.Sum256([]byte(route + "\x00" + strings.Join(parts, ".") + "\x00" + tag))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ToFieldErrorDetails converts a validation error into details sorted by
+// field, with messages resolved like ToFieldErrorsWithContext and codes
+// prefixed with the namespace of ctx (see WithErrorCodePrefix). Failures
+// without a tag, such as FieldErrors from binding, get InvalidCode.
+// Fingerprints use the route of ctx (see WithRoute).
+func ToFieldErrorDetails(ctx context.Context, err error) []FieldErrorDetail {
+	if err == nil {
+		return nil
+	}
+	var be *BindingError
+	if errors.As(err, &be) && be.Validation != nil {
+		out := append(ToFieldErrorDetails(ctx, be.Err), ToFieldErrorDetails(ctx, be.Validation)...)
+		sort.Slice(out, func(i, j int) bool { return out[i].Field < out[j].Field })
+		return out
+	}
+	prefix, route := ErrorCodePrefix(ctx), RouteFromContext(ctx)
+	var out []FieldErrorDetail
+	var vErrs validator.ValidationErrors
+	if errors.As(err, &vErrs) {
+		for _, fe := range vErrs {
+			msgs := ToFieldErrorsWithContext(ctx, validator.ValidationErrors{fe})
+			for field, msg := range msgs {
+				out = append(out, FieldErrorDetail{
+					Field: field, Code: prefix + ErrorCode(fe.Tag()),
+					Tag: fe.Tag(), Param: fe.Param(), Message: msg,
+					Fingerprint: Fingerprint(route, field, fe.Tag()),
+				})
+			}
+		}
+	} else {
+		for field, msg := range ToFieldErrorsWithContext(ctx, err) {
+			out = append(out, FieldErrorDetail{
+				Field: field, Code: prefix + InvalidCode, Message: msg,
+				Fingerprint: Fingerprint(route, field, ""),
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Field < out[j].Field })
+	return out
+}
@@ -0,0 +1,63 @@
+package validate
+
+import (
+	"context"
+	"testing"
+)
+
+type codedSignup struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age" validate:"gte=18"`
+}
+
+func TestErrorCode(t *testing.T) {
+	for tag, want := range map[string]string{"required": "REQUIRED", "required_if": "REQUIRED_IF", "e164": "E164", "": InvalidCode} {
+		if got := ErrorCode(tag); got != want {
+			t.Fatalf("ErrorCode(%q) = %q, want %q", tag, got, want)
+		}
+	}
+	RegisterErrorCode("email", "EMAIL_INVALID")
+	defer RegisterErrorCode("email", "")
+	if got := ErrorCode("email"); got != "EMAIL_INVALID" {
+		t.Fatalf("expected registered code, got %q", got)
+	}
+}
+
+func TestToFieldErrorDetails(t *testing.T) {
+	err := Struct(codedSignup{Email: "x", Age: 10})
+	ctx := WithRoute(WithErrorCodePrefix(context.Background(), "USR_"), "POST /users")
+	d := ToFieldErrorDetails(ctx, err)
+	want := []FieldErrorDetail{
+		{Field: "age", Code: "USR_GTE", Tag: "gte", Param: "18", Message: "must be greater than or equal to 18", Fingerprint: Fingerprint("POST /users", "age", "gte")},
+		{Field: "email", Code: "USR_EMAIL", Tag: "email", Message: "must be a valid email", Fingerprint: Fingerprint("POST /users", "email", "email")},
+		{Field: "name", Code: "USR_REQUIRED", Tag: "required", Message: "is required", Fingerprint: Fingerprint("POST /users", "name", "required")},
+	}
+	if len(d) != len(want) {
+		t.Fatalf("expected %v, got %v", want, d)
+	}
+	for i := range want {
+		if d[i] != want[i] {
+			t.Fatalf("detail %d: expected %+v, got %+v", i, want[i], d[i])
+		}
+	}
+	if d := ToFieldErrorDetails(context.Background(), FieldErrors{"body": "invalid JSON"}); len(d) != 1 || d[0].Code != InvalidCode {
+		t.Fatalf("expected INVALID for untagged errors, got %v", d)
+	}
+	if ToFieldErrorDetails(ctx, nil) != nil {
+		t.Fatalf("expected nil details for nil error")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	fp := Fingerprint("POST /orders", "items.3.sku", "required")
+	if len(fp) != 16 || fp != Fingerprint("POST /orders", "items.0.sku", "required") {
+		t.Fatalf("expected index-independent 16-character fingerprint, got %q", fp)
+	}
+	if fp == Fingerprint("POST /orders", "items.3.sku", "max") || fp == Fingerprint("PUT /orders", "items.3.sku", "required") {
+		t.Fatalf("expected tag and route to change the fingerprint")
+	}
+	if fp != Fingerprint("POST /orders", "items.3.sku", "required") {
+		t.Fatalf("expected deterministic fingerprint")
+	}
+}
@@ -0,0 +1,265 @@
+package validate
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2/ctx"
+)
+
+// CoerceOptions customizes Coerce.
+type CoerceOptions struct {
+	// TimeLayout is the default layout for time.Time fields without a
+	// `layout` struct tag. Default: time.RFC3339.
+	TimeLayout string
+	// Separator splits single values into slices ("a,b,c"). Repeated
+	// parameters (?tag=a&tag=b) are always accepted. Default: ",".
+	Separator string
+}
+
+// Coerce converts string values (query or form) into the fields of the struct
+// pointed to by dst, matched by json tag name (or field name). Supported field
+// types are strings, integers, floats, bools, time.Time (layout from the
+// `layout` struct tag or opts), time.Duration, types with a parser (see
+// RegisterParser, e.g. uuid.UUID), slices of those and pointers to them. Keys
+// without a matching field are ignored.
+//
+// Conversion failures are reported per field as FieldErrors with messages such
+// as "must be an integer", instead of opaque decoding errors.
+func Coerce(values url.Values, dst any, opts ...CoerceOptions) error {
+	var o CoerceOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	if o.TimeLayout == "" {
+		o.TimeLayout = time.RFC3339
+	}
+	if o.Separator == "" {
+		o.Separator = ","
+	}
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("validate: Coerce requires a non-nil pointer to a struct")
+	}
+	res := FieldErrors{}
+	coerceStruct(values, rv.Elem(), o, res)
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+// BindQuery coerces the request query string into dst and validates it.
+// Coercion failures are returned as a *BindingError (messages resolved with
+// the request context) carrying the validation error of the other fields; a
+// field that failed coercion is not additionally reported by validation.
+// Without coercion failures, the validation error is returned as is.
+func BindQuery(c ctx.Ctx, dst any, opts ...CoerceOptions) error {
+	return bindValues(c, c.Request().URL.Query(), dst, firstCoerceOptions(opts), nil)
+}
+
+// BindForm is like BindQuery for form bodies (urlencoded or multipart).
+func BindForm(c ctx.Ctx, dst any, opts ...CoerceOptions) error {
+	values, err := formValues(c)
+	if err != nil {
+		return &BindingError{Err: err}
+	}
+	return bindValues(c, values, dst, firstCoerceOptions(opts), nil)
+}
+
+// formValues parses the request form body.
+func formValues(c ctx.Ctx) (url.Values, error) {
+	r := c.Request()
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+	} else if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	return r.PostForm, nil
+}
+
+func firstCoerceOptions(opts []CoerceOptions) CoerceOptions {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return CoerceOptions{}
+}
+
+// bindValues coerces values into dst and validates it. onValidationError, if
+// set, observes the validation error.
+func bindValues(c ctx.Ctx, values url.Values, dst any, o CoerceOptions, onValidationError func(error)) error {
+	var coerced FieldErrors
+	if err := Coerce(values, dst, o); err != nil {
+		if !errors.As(err, &coerced) {
+			return err
+		}
+	}
+	vErr := StructCtx(c.Context(), dst)
+	if vErr != nil && onValidationError != nil {
+		onValidationError(vErr)
+	}
+	if coerced == nil {
+		return vErr
+	}
+	return &BindingError{Err: coerced, Validation: withoutFields(vErr, coerced)}
+}
+
+// withoutFields drops from a validation error the failures of fields already
+// reported in skip, returning nil when none are left.
+func withoutFields(err error, skip FieldErrors) error {
+	var vErrs validator.ValidationErrors
+	if !errors.As(err, &vErrs) {
+		return err
+	}
+	var out validator.ValidationErrors
+	for _, fe := range vErrs {
+		if _, ok := skip[fe.Field()]; !ok {
+			out = append(out, fe)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func coerceStruct(values url.Values, sv reflect.Value, o CoerceOptions, res FieldErrors) {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := sv.Field(i)
+		if sf.Anonymous && fv.Kind() == reflect.Struct {
+			coerceStruct(values, fv, o, res)
+			continue
+		}
+		name := jsonName(sf)
+		if name == "" {
+			continue
+		}
+		raw, ok := values[name]
+		if !ok || len(raw) == 0 {
+			continue
+		}
+		layout := sf.Tag.Get("layout")
+		if layout == "" {
+			layout = o.TimeLayout
+		}
+		if msg := coerceValue(fv, raw, layout, o.Separator); msg != "" {
+			res[name] = msg
+		}
+	}
+}
+
+// coerceValue sets fv from raw and returns a message on failure.
+func coerceValue(fv reflect.Value, raw []string, layout, sep string) string {
+	if fv.Kind() == reflect.Pointer {
+		elem := reflect.New(fv.Type().Elem())
+		if msg := coerceValue(elem.Elem(), raw, layout, sep); msg != "" {
+			return msg
+		}
+		fv.Set(elem)
+		return ""
+	}
+	if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+		var items []string
+		for _, r := range raw {
+			for _, item := range strings.Split(r, sep) {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+		}
+		out := reflect.MakeSlice(fv.Type(), len(items), len(items))
+		for i, item := range items {
+			if msg := coerceScalar(out.Index(i), item, layout); msg != "" {
+				return "item " + strconv.Itoa(i) + " " + msg
+			}
+		}
+		fv.Set(out)
+		return ""
+	}
+	return coerceScalar(fv, raw[0], layout)
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// coerceScalar parses s into fv and returns a message on failure.
+func coerceScalar(fv reflect.Value, s, layout string) string {
+	s = strings.TrimSpace(s)
+	switch fv.Type() {
+	case timeType:
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return "must be a time in format " + layout
+		}
+		fv.Set(reflect.ValueOf(t))
+		return ""
+	case durationType:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return "must be a duration"
+		}
+		fv.SetInt(int64(d))
+		return ""
+	}
+	if p, ok := parserFor(fv.Type()); ok {
+		v, err := p.parse(s)
+		if err != nil {
+			return p.message
+		}
+		fv.Set(reflect.ValueOf(v))
+		return ""
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return "must be a boolean"
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, fv.Type().Bits())
+		if err != nil {
+			return numError(err, "must be an integer")
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, fv.Type().Bits())
+		if err != nil {
+			return numError(err, "must be a non-negative integer")
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, fv.Type().Bits())
+		if err != nil {
+			return numError(err, "must be a number")
+		}
+		fv.SetFloat(f)
+	default:
+		return "unsupported type " + fv.Type().String()
+	}
+	return ""
+}
+
+// numError distinguishes range errors from syntax errors.
+func numError(err error, syntaxMsg string) string {
+	if errors.Is(err, strconv.ErrRange) {
+		return "value out of range"
+	}
+	return syntaxMsg
+}
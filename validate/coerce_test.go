@@ -0,0 +1,131 @@
+package validate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goflash/flash/v2"
+)
+
+type Paging struct {
+	Page int `json:"page" validate:"gte=1"`
+}
+
+type searchQuery struct {
+	Paging
+	Q       string        `json:"q" validate:"required"`
+	Limit   *uint8        `json:"limit"`
+	Exact   bool          `json:"exact"`
+	Score   float64       `json:"score"`
+	Since   time.Time     `json:"since" layout:"2006-01-02"`
+	Until   time.Time     `json:"until"`
+	Timeout time.Duration `json:"timeout"`
+	Tags    []string      `json:"tags"`
+	IDs     []int         `json:"ids"`
+	Secret  string        `json:"-"`
+	private string
+}
+
+func TestCoerce_ConvertsTypes(t *testing.T) {
+	vals := url.Values{
+		"page": {"2"}, "q": {" go "}, "limit": {"50"}, "exact": {"true"}, "score": {"0.5"},
+		"since": {"2024-01-31"}, "until": {"2024-02-01T10:00:00Z"}, "timeout": {"1m30s"},
+		"tags": {"a,b", "c"}, "ids": {"1, 2,3"}, "Secret": {"x"}, "private": {"x"},
+	}
+	var q searchQuery
+	if err := Coerce(vals, &q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Page != 2 || q.Q != "go" || q.Limit == nil || *q.Limit != 50 || !q.Exact || q.Score != 0.5 {
+		t.Fatalf("unexpected scalars: %+v", q)
+	}
+	if q.Since.Format("2006-01-02") != "2024-01-31" || q.Until.Hour() != 10 || q.Timeout != 90*time.Second {
+		t.Fatalf("unexpected time values: %+v", q)
+	}
+	if strings.Join(q.Tags, "|") != "a|b|c" || len(q.IDs) != 3 || q.IDs[2] != 3 {
+		t.Fatalf("unexpected slices: %+v", q)
+	}
+	if q.Secret != "" || q.private != "" {
+		t.Fatalf("excluded fields must not be set: %+v", q)
+	}
+}
+
+func TestCoerce_ReportsPerFieldErrors(t *testing.T) {
+	vals := url.Values{
+		"page": {"two"}, "limit": {"300"}, "exact": {"maybe"}, "score": {"x"},
+		"since": {"31/01/2024"}, "timeout": {"soon"}, "ids": {"1,x"},
+	}
+	var q searchQuery
+	err := Coerce(vals, &q)
+	var fe FieldErrors
+	if !errors.As(err, &fe) {
+		t.Fatalf("expected FieldErrors, got %v", err)
+	}
+	want := map[string]string{
+		"page":    "must be an integer",
+		"limit":   "value out of range",
+		"exact":   "must be a boolean",
+		"score":   "must be a number",
+		"since":   "must be a time in format 2006-01-02",
+		"timeout": "must be a duration",
+		"ids":     "item 1 must be an integer",
+	}
+	for k, msg := range want {
+		if fe[k] != msg {
+			t.Fatalf("field %q: expected %q, got %q (all: %v)", k, msg, fe[k], fe)
+		}
+	}
+}
+
+func TestCoerce_RejectsNonStructPointer(t *testing.T) {
+	var q searchQuery
+	if err := Coerce(url.Values{}, q); err == nil {
+		t.Fatalf("expected error for non-pointer")
+	}
+	type unsupported struct {
+		M map[string]string `json:"m"`
+	}
+	var u unsupported
+	if fe, _ := Coerce(url.Values{"m": {"x"}}, &u).(FieldErrors); fe["m"] != "unsupported type map[string]string" {
+		t.Fatalf("expected unsupported type error, got %v", fe)
+	}
+}
+
+func TestBindQuery_MergesCoercionAndValidation(t *testing.T) {
+	app := flash.New()
+	app.GET("/search", func(c flash.Ctx) error {
+		var q searchQuery
+		err := BindQuery(c, &q)
+		return c.JSON(ToFieldErrors(err))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/search?page=abc", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	body := rec.Body.String()
+	if !strings.Contains(body, `"page":"must be an integer"`) || !strings.Contains(body, `"q":"is required"`) {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
+
+func TestBindForm(t *testing.T) {
+	app := flash.New()
+	app.POST("/search", func(c flash.Ctx) error {
+		var q searchQuery
+		if err := BindForm(c, &q); err != nil {
+			return c.JSON(ToFieldErrors(err))
+		}
+		return c.JSON(map[string]any{"page": q.Page, "q": q.Q})
+	})
+	req := httptest.NewRequest(http.MethodPost, "/search", strings.NewReader("page=3&q=hello"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	if body := rec.Body.String(); !strings.Contains(body, `"page":3`) || !strings.Contains(body, `"q":"hello"`) {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
@@ -0,0 +1,112 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Slice validates every element of items (structs or pointers to structs) and
+// returns all failures as one validator.ValidationErrors with keys prefixed by
+// the element index, e.g. "0.name", "3.email". Nil elements are skipped.
+func Slice[T any](items []T) error { return SliceCtx(context.Background(), items) }
+
+// SliceCtx is like Slice but passes ctx to context-aware validation functions.
+func SliceCtx[T any](ctx context.Context, items []T) error {
+	var all validator.ValidationErrors
+	for i, item := range items {
+		if err := collectPrefixed(ctx, strconv.Itoa(i), item, &all); err != nil {
+			return err
+		}
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	return all
+}
+
+// MapValues validates every value of m (structs or pointers to structs) and
+// returns all failures as one validator.ValidationErrors with keys prefixed by
+// the map key, e.g. "alice.email". Keys are visited in sorted order.
+func MapValues[T any](m map[string]T) error { return MapValuesCtx(context.Background(), m) }
+
+// MapValuesCtx is like MapValues but passes ctx to context-aware validation
+// functions.
+func MapValuesCtx[T any](ctx context.Context, m map[string]T) error {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var all validator.ValidationErrors
+	for _, k := range keys {
+		if err := collectPrefixed(ctx, k, m[k], &all); err != nil {
+			return err
+		}
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	return all
+}
+
+// collectPrefixed validates v and appends its errors, renamed to
+// "prefix.path" (or "path" for an empty prefix, see FieldKey), to all. Nil values are
+// skipped; non-validation errors are returned.
+func collectPrefixed(ctx context.Context, prefix string, v any, all *validator.ValidationErrors) error {
+	if rv := reflect.ValueOf(v); !rv.IsValid() || rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil
+	}
+	err := StructCtx(ctx, v)
+	if err == nil {
+		return nil
+	}
+	var vErrs validator.ValidationErrors
+	if !errors.As(err, &vErrs) {
+		return err
+	}
+	for _, fe := range vErrs {
+		name := FieldKey(fe)
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		*all = append(*all, namedFieldError{FieldError: fe, name: name})
+	}
+	return nil
+}
+
+// Prefixed pairs a struct with the prefix its error keys get in All.
+type Prefixed struct {
+	Prefix string
+	Value  any
+}
+
+// All validates several structs (e.g. the body, query and path structs of one
+// request) and merges their failures into one validator.ValidationErrors,
+// keyed "prefix.field" ("field" when Prefix is empty):
+//
+//	err := validate.All(
+//		validate.Prefixed{Prefix: "query", Value: q},
+//		validate.Prefixed{Prefix: "body", Value: body},
+//	)
+//	// validate.ToFieldErrors(err) -> {"query.page": "...", "body.name": "..."}
+func All(pairs ...Prefixed) error { return AllCtx(context.Background(), pairs...) }
+
+// AllCtx is like All but passes ctx to context-aware validation functions.
+func AllCtx(ctx context.Context, pairs ...Prefixed) error {
+	var all validator.ValidationErrors
+	for _, p := range pairs {
+		if err := collectPrefixed(ctx, p.Prefix, p.Value, &all); err != nil {
+			return err
+		}
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	return all
+}
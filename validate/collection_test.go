@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"context"
+	"testing"
+)
+
+type lineItem struct {
+	SKU string `json:"sku" validate:"required"`
+	Qty int    `json:"qty" validate:"gte=1"`
+}
+
+func TestSlice_IndexedKeys(t *testing.T) {
+	items := []*lineItem{{SKU: "a", Qty: 1}, nil, {Qty: 0}}
+	fe := ToFieldErrors(Slice(items))
+	if len(fe) != 2 || fe["2.sku"] != "is required" || fe["2.qty"] != "must be greater than or equal to 1" {
+		t.Fatalf("unexpected errors: %v", fe)
+	}
+	if Slice([]lineItem{{SKU: "a", Qty: 2}}) != nil || Slice[lineItem](nil) != nil {
+		t.Fatalf("expected valid slices")
+	}
+}
+
+func TestSlice_NestedStructKeys(t *testing.T) {
+	type address struct {
+		City string `json:"city" validate:"required"`
+	}
+	type shipment struct {
+		From address   `json:"from"`
+		To   address   `json:"to"`
+		Legs []address `json:"legs" validate:"dive"`
+	}
+	fe := ToFieldErrors(Slice([]shipment{{From: address{City: "Oslo"}, Legs: []address{{}}}}))
+	want := map[string]string{"0.to.city": "is required", "0.legs[0].city": "is required"}
+	if len(fe) != len(want) || fe["0.to.city"] != want["0.to.city"] || fe["0.legs[0].city"] != want["0.legs[0].city"] {
+		t.Fatalf("expected %v, got %v", want, fe)
+	}
+}
+
+func TestMapValues_KeyedByMapKey(t *testing.T) {
+	m := map[string]lineItem{"alice": {SKU: "x", Qty: 1}, "bob": {SKU: "y"}}
+	fe := ToFieldErrors(MapValuesCtx(context.Background(), m))
+	if len(fe) != 1 || fe["bob.qty"] != "must be greater than or equal to 1" {
+		t.Fatalf("unexpected errors: %v", fe)
+	}
+	if err := SliceCtx(context.Background(), []string{"x"}); err == nil {
+		t.Fatalf("expected error for non-struct elements")
+	}
+}
+
+func TestAll_MergesWithPrefixes(t *testing.T) {
+	type query struct {
+		Page int `json:"page" validate:"gte=1"`
+	}
+	type path struct {
+		ID string `json:"id" validate:"required"`
+	}
+	err := AllCtx(context.Background(),
+		Prefixed{Prefix: "query", Value: query{}},
+		Prefixed{Prefix: "body", Value: &lineItem{Qty: 1}},
+		Prefixed{Value: path{}},
+		Prefixed{Prefix: "skipped", Value: (*lineItem)(nil)},
+	)
+	fe := ToFieldErrors(err)
+	want := map[string]string{
+		"query.page": "must be greater than or equal to 1",
+		"body.sku":   "is required",
+		"id":         "is required",
+	}
+	if len(fe) != len(want) {
+		t.Fatalf("unexpected errors: %v", fe)
+	}
+	for k, msg := range want {
+		if fe[k] != msg {
+			t.Fatalf("field %q: expected %q, got %q", k, msg, fe[k])
+		}
+	}
+	if All(Prefixed{Prefix: "q", Value: query{Page: 1}}) != nil {
+		t.Fatalf("expected nil for valid values")
+	}
+}
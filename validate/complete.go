@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Context key for the complete-errors mode.
+type ctxKeyCompleteErrors struct{}
+
+// WithCompleteErrors returns a context in which StructCtx also reports the
+// fields of nested structs that are missing altogether. The validator stops
+// at a nil pointer (or zero struct) failing required or a conditional
+// required_if, required_unless, required_with* or required_without* tag, so clients fix "address is required" only to learn about
+// "city is required" on the next round trip. In this mode such a struct is
+// validated as its zero value and its errors are added under the parent's
+// namespace:
+//
+//	type Signup struct {
+//		Address *Address `json:"address" validate:"required"`
+//	}
+//	err := validate.StructCtx(validate.WithCompleteErrors(ctx), Signup{})
+//	// {"address": "is required", "city": "is required", "zip": "is required"}
+//
+// Recursive types are expanded once per path. Applies to StructCtx and the
+// helpers built on it (binding helpers, Map, ...).
+func WithCompleteErrors(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeyCompleteErrors{}, true)
+}
+
+// completeErrors reports whether ctx enables the complete-errors mode.
+func completeErrors(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	on, _ := ctx.Value(ctxKeyCompleteErrors{}).(bool)
+	return on
+}
+
+// requiredTags are the built-in tags whose failure means a value is missing.
+var requiredTags = map[string]bool{
+	"required": true, "required_if": true, "required_unless": true,
+	"required_with": true, "required_with_all": true,
+	"required_without": true, "required_without_all": true,
+}
+
+// expandRequired adds to err the errors of the zero values of structs that
+// failed a required tag, validated with e.
+func expandRequired(ctx context.Context, e Engine, err error) error {
+	var vErrs validator.ValidationErrors
+	if !errors.As(err, &vErrs) {
+		return err
+	}
+	out := append(validator.ValidationErrors(nil), vErrs...)
+	return appendRequired(ctx, e, vErrs, out, map[reflect.Type]bool{})
+}
+
+func appendRequired(ctx context.Context, e Engine, vErrs, out validator.ValidationErrors, path map[reflect.Type]bool) validator.ValidationErrors {
+	for _, fe := range vErrs {
+		t := fe.Type()
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if !requiredTags[fe.Tag()] || t == nil || t.Kind() != reflect.Struct || path[t] || !isMissing(fe.Value()) {
+			continue
+		}
+		var nested validator.ValidationErrors
+		if !errors.As(e.StructCtx(ctx, reflect.New(t).Interface()), &nested) {
+			continue
+		}
+		for i, n := range nested {
+			nested[i] = nestedFieldError{FieldError: n, ns: fe.Namespace(), structNs: fe.StructNamespace()}
+		}
+		out = append(out, nested...)
+		path[t] = true
+		out = appendRequired(ctx, e, nested, out, path)
+		delete(path, t)
+	}
+	return out
+}
+
+// isMissing reports whether v, a failing field's value, is nil or zero. A
+// non-nil pointer was already validated by the validator.
+func isMissing(v any) bool {
+	rv := reflect.ValueOf(v)
+	return !rv.IsValid() || rv.IsZero()
+}
+
+// nestedFieldError is an error of a zero struct validated on its own, moved
+// under the namespace of the field holding it.
+type nestedFieldError struct {
+	validator.FieldError
+	ns, structNs string
+}
+
+func (e nestedFieldError) Namespace() string {
+	return reparent(e.ns, e.FieldError.Namespace())
+}
+
+func (e nestedFieldError) StructNamespace() string {
+	return reparent(e.structNs, e.FieldError.StructNamespace())
+}
+
+// reparent replaces the leading type name of ns ("Address.City") with parent.
+func reparent(parent, ns string) string {
+	if _, rest, ok := strings.Cut(ns, "."); ok {
+		return parent + "." + rest
+	}
+	return parent
+}
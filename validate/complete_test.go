@@ -0,0 +1,111 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type completeGeo struct {
+	Lat string `json:"lat" validate:"required"`
+}
+
+type completeAddress struct {
+	City string       `json:"city" validate:"required"`
+	Geo  *completeGeo `json:"geo" validate:"required"`
+}
+
+type completeSignup struct {
+	Name    string           `json:"name" validate:"required"`
+	Address *completeAddress `json:"address" validate:"required"`
+	Billing *completeAddress `json:"billing"`
+}
+
+type completeNode struct {
+	Value string        `json:"value" validate:"required"`
+	Next  *completeNode `json:"next" validate:"required"`
+}
+
+func TestWithCompleteErrors(t *testing.T) {
+	ctx := context.Background()
+	if got := ToFieldErrors(StructCtx(ctx, completeSignup{})); len(got) != 2 {
+		t.Fatalf("expected the validator to stop at address by default, got %v", got)
+	}
+
+	err := StructCtx(WithCompleteErrors(ctx), completeSignup{})
+	want := map[string]string{"name": "is required", "address": "is required", "address.city": "is required", "address.geo": "is required", "address.geo.lat": "is required"}
+	if got := ToFieldErrors(err); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	var vErrs validator.ValidationErrors
+	if !errors.As(err, &vErrs) {
+		t.Fatalf("expected ValidationErrors, got %T", err)
+	}
+	namespaces := map[string]bool{}
+	for _, fe := range vErrs {
+		namespaces[fe.Namespace()+"|"+fe.StructNamespace()] = true
+	}
+	if !namespaces["completeSignup.address.geo.lat|completeSignup.Address.Geo.Lat"] {
+		t.Fatalf("expected nested errors under the parent's namespace, got %v", namespaces)
+	}
+
+	err = StructCtx(WithCompleteErrors(ctx), completeNode{})
+	want = map[string]string{"value": "is required", "next": "is required", "next.value": "is required", "next.next": "is required"}
+	if got := ToFieldErrors(err); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected recursive types expanded once, got %v", got)
+	}
+
+	in := completeSignup{Name: "Ann", Address: &completeAddress{City: "Oslo", Geo: &completeGeo{Lat: "59.9"}}}
+	if err := StructCtx(WithCompleteErrors(ctx), in); err != nil {
+		t.Fatalf("expected valid input to pass, got %v", err)
+	}
+}
+
+type completeAdmin struct {
+	Role    string           `json:"role"`
+	Address *completeAddress `json:"address" validate:"required_if_admin"`
+	Office  *completeAddress `json:"office" validate:"required_if=Role admin"`
+}
+
+// missingFieldError is a required failure with a chosen value.
+type missingFieldError struct {
+	validator.FieldError
+	value any
+}
+
+func (e missingFieldError) Tag() string             { return "required" }
+func (e missingFieldError) Type() reflect.Type      { return reflect.TypeOf(e.value) }
+func (e missingFieldError) Value() any              { return e.value }
+func (e missingFieldError) Namespace() string       { return "x.address" }
+func (e missingFieldError) StructNamespace() string { return "x.Address" }
+
+func TestWithCompleteErrors_BuiltinRequiredTagsOnly(t *testing.T) {
+	if err := RegisterValidationWithMessage("required_if_admin", func(fl validator.FieldLevel) bool {
+		return fl.Parent().FieldByName("Role").String() != "admin" || !fl.Field().IsNil()
+	}, map[string]string{"en": "is required for admins"}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithCompleteErrors(context.Background())
+	got := ToFieldErrors(StructCtx(ctx, completeAdmin{Role: "admin"}))
+	want := map[string]string{"address": "is required for admins", "office": "is required when role is admin", "office.city": "is required", "office.geo": "is required", "office.geo.lat": "is required"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected only required_if to be expanded, got %v", got)
+	}
+	if got := ToFieldErrors(StructCtx(ctx, completeAdmin{Role: "admin", Office: &completeAddress{City: "Oslo", Geo: &completeGeo{Lat: "1"}}})); len(got) != 1 {
+		t.Fatalf("expected the custom required_* tag not to be expanded, got %v", got)
+	}
+}
+
+func TestExpandRequired_OnlyMissingValues(t *testing.T) {
+	present := validator.ValidationErrors{missingFieldError{value: &completeAddress{}}}
+	if out := appendRequired(context.Background(), Default(), present, nil, map[reflect.Type]bool{}); len(out) != 0 {
+		t.Fatalf("expected a non-nil struct not to be expanded, got %v", out)
+	}
+	missing := validator.ValidationErrors{missingFieldError{value: (*completeAddress)(nil)}}
+	if out := appendRequired(context.Background(), Default(), missing, nil, map[reflect.Type]bool{}); len(out) != 3 {
+		t.Fatalf("expected a nil struct to be expanded, got %v", out)
+	}
+}
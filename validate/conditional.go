@@ -0,0 +1,93 @@
+package validate
+
+import "strings"
+
+// crossFieldMessages are the default messages of the tags comparing a field
+// with another one, named by label.
+var crossFieldMessages = map[string]string{
+	"eqfield":       "must match %s",
+	"nefield":       "must differ from %s",
+	"gtfield":       "must be greater than %s",
+	"gtefield":      "must be greater than or equal to %s",
+	"ltfield":       "must be less than %s",
+	"ltefield":      "must be less than or equal to %s",
+	"fieldcontains": "must contain the value of %s",
+	"fieldexcludes": "must not contain the value of %s",
+}
+
+// crossFieldTemplates are crossFieldMessages split around the label.
+var crossFieldTemplates = splitTemplates(crossFieldMessages, "%s")
+
+// crossFieldMessage returns the default message of the cross-field tags
+// (eqfield, gtfield, ... and their cs variants), naming the other field by
+// its label (see RegisterFieldLabel and WithFieldLabels), e.g. "must match password_confirmation".
+// ok is false for other tags.
+func crossFieldMessage(tag, param string, label func(string) string) (string, bool) {
+	tmpl, ok := crossFieldTemplates[tag]
+	if !ok && strings.HasSuffix(tag, "csfield") {
+		tmpl, ok = crossFieldTemplates[strings.TrimSuffix(tag, "csfield")+"field"]
+	}
+	if !ok || param == "" {
+		return "", false
+	}
+	return tmpl.render(label(param)), true
+}
+
+// conditionalMessage returns the default message of the conditional
+// required_* and excluded_* tags, naming the referenced fields by their
+// labels (see RegisterFieldLabel and WithFieldLabels), e.g. "is required when payment_method is
+// card". ok is false for other tags.
+func conditionalMessage(tag, param string, label func(string) string) (msg string, ok bool) {
+	verb, cond, found := strings.Cut(tag, "_")
+	switch {
+	case !found:
+		return "", false
+	case verb == "required":
+		msg = "is required"
+	case verb == "excluded":
+		msg = "must be empty"
+	default:
+		return "", false
+	}
+	args := strings.Fields(param)
+	switch cond {
+	case "if", "unless":
+		var pairs []string
+		for i := 0; i+1 < len(args); i += 2 {
+			pairs = append(pairs, label(args[i])+" is "+strings.Trim(args[i+1], "'"))
+		}
+		if len(pairs) == 0 {
+			return "", false
+		}
+		word := " when "
+		if cond == "unless" {
+			word = " unless "
+		}
+		return msg + word + strings.Join(pairs, " and "), true
+	case "with", "with_all", "without", "without_all":
+		labels := make([]string, len(args))
+		for i, a := range args {
+			labels[i] = label(a)
+		}
+		state := " present"
+		if strings.HasPrefix(cond, "without") {
+			state = " missing"
+		}
+		if strings.HasSuffix(cond, "_all") {
+			if len(args) > 1 {
+				return msg + " when " + joinLabels(labels, " and ") + " are" + state, true
+			}
+			return msg + " when " + joinLabels(labels, " and ") + " is" + state, true
+		}
+		return msg + " when " + joinLabels(labels, " or ") + " is" + state, true
+	}
+	return "", false
+}
+
+// joinLabels joins labels as "a, b or c".
+func joinLabels(labels []string, last string) string {
+	if len(labels) <= 1 {
+		return strings.Join(labels, "")
+	}
+	return strings.Join(labels[:len(labels)-1], ", ") + last + labels[len(labels)-1]
+}
@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"context"
+	"testing"
+)
+
+type checkout struct {
+	PaymentMethod string `json:"payment_method"`
+	CardNumber    string `json:"card_number" validate:"required_if=PaymentMethod card"`
+	IBAN          string `json:"iban" validate:"required_unless=PaymentMethod card"`
+	Coupon        string `json:"coupon" validate:"excluded_with=GiftCardID"`
+	GiftCardID    string `json:"gift_card_id"`
+	Street        string `json:"street" validate:"required_with_all=City ZipCode"`
+	City          string `json:"city"`
+	ZipCode       string `json:"zip_code"`
+	Email         string `json:"email" validate:"required_without=Phone"`
+	Phone         string `json:"phone"`
+}
+
+func TestConditionalMessages(t *testing.T) {
+	m := ToFieldErrors(Struct(checkout{PaymentMethod: "card", Coupon: "X", GiftCardID: "g", City: "c", ZipCode: "z"}))
+	want := map[string]string{
+		"card_number": "is required when payment_method is card",
+		"coupon":      "must be empty when gift_card_id is present",
+		"street":      "is required when city and zip_code are present",
+		"email":       "is required when phone is missing",
+	}
+	for k, v := range want {
+		if m[k] != v {
+			t.Fatalf("%s: expected %q, got %q (all: %v)", k, v, m[k], m)
+		}
+	}
+	if m := ToFieldErrors(Struct(checkout{PaymentMethod: "sepa", Email: "a"})); m["iban"] != "is required unless payment_method is card" {
+		t.Fatalf("unexpected required_unless message: %v", m)
+	}
+}
+
+func TestConditionalMessages_Labels(t *testing.T) {
+	RegisterFieldLabel("PaymentMethod", map[string]string{"en": "payment method"})
+	defer RegisterFieldLabel("PaymentMethod", nil)
+	if msg := defaultMessageFor("required_if", "PaymentMethod card Country 'DE'"); msg != "is required when payment method is card and country is DE" {
+		t.Fatalf("unexpected message %q", msg)
+	}
+	if msg := defaultMessageFor("excluded_without_all", "A B C"); msg != "must be empty when a, b and c are missing" {
+		t.Fatalf("unexpected message %q", msg)
+	}
+	if msg := defaultMessageFor("required_if_admin", ""); msg != "failed required_if_admin" {
+		t.Fatalf("expected custom tags to keep the fallback, got %q", msg)
+	}
+}
+
+type passwordChange struct {
+	Password             string `json:"password" validate:"eqfield=PasswordConfirmation"`
+	PasswordConfirmation string `json:"password_confirmation"`
+	OldPassword          string `json:"old_password" validate:"nefield=Password"`
+	MinGuests            int    `json:"min_guests"`
+	MaxGuests            int    `json:"max_guests" validate:"gtefield=MinGuests"`
+}
+
+func TestCrossFieldMessages(t *testing.T) {
+	m := ToFieldErrors(Struct(passwordChange{Password: "a", PasswordConfirmation: "b", OldPassword: "a", MinGuests: 3, MaxGuests: 2}))
+	want := map[string]string{
+		"password":     "must match password_confirmation",
+		"old_password": "must differ from password",
+		"max_guests":   "must be greater than or equal to min_guests",
+	}
+	for k, v := range want {
+		if m[k] != v {
+			t.Fatalf("%s: expected %q, got %q (all: %v)", k, v, m[k], m)
+		}
+	}
+	if msg := defaultMessageFor("ltcsfield", "Inner.EndDate"); msg != "must be less than inner.end_date" {
+		t.Fatalf("unexpected cs message %q", msg)
+	}
+}
+
+func TestLabelPlaceholder(t *testing.T) {
+	RegisterFieldLabel("PasswordConfirmation", map[string]string{"en": "password confirmation", "es": "confirmación de contraseña"})
+	defer RegisterFieldLabel("PasswordConfirmation", nil)
+	e := NewEngine()
+	e.RegisterMessages("eqfield", map[string]string{"es": "debe coincidir con {label}"})
+	err := e.Struct(passwordChange{Password: "a", PasswordConfirmation: "b"})
+	ctx := WithLocale(WithEngine(context.Background(), e), "es")
+	if m := ToFieldErrorsWithContext(ctx, err); m["password"] != "debe coincidir con confirmación de contraseña" {
+		t.Fatalf("expected localized label, got %v", m)
+	}
+	if m := ToFieldErrors(err); m["password"] != "must match password confirmation" {
+		t.Fatalf("expected default label, got %v", m)
+	}
+}
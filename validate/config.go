@@ -0,0 +1,162 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ConfigFieldError describes a single invalid configuration setting.
+type ConfigFieldError struct {
+	// Key is the dotted path of the setting, e.g. "database.url".
+	Key string
+	// Rule is the failing validation rule, e.g. "required" or "max=65535".
+	Rule string
+	// Value is the offending value formatted with %v, only set with
+	// ConfigOptions.ShowValues; "[redacted]" for `secret:"true"` fields.
+	Value string
+	// Message is the human-readable message for the rule.
+	Message string
+}
+
+// ConfigError is returned by Config when one or more settings are invalid.
+// Its Error output lists every invalid setting on its own line.
+type ConfigError struct {
+	Fields []ConfigFieldError
+}
+
+func (e *ConfigError) Error() string {
+	var b strings.Builder
+	if len(e.Fields) == 1 {
+		b.WriteString("invalid configuration (1 setting):")
+	} else {
+		fmt.Fprintf(&b, "invalid configuration (%d settings):", len(e.Fields))
+	}
+	for _, f := range e.Fields {
+		fmt.Fprintf(&b, "\n  - %s: %s (rule: %s", f.Key, f.Message, f.Rule)
+		if f.Value != "" {
+			fmt.Fprintf(&b, ", value: %s", f.Value)
+		}
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// ConfigOptions customizes Config and MustConfig.
+type ConfigOptions struct {
+	// ShowValues includes the offending values in the report, to help spot
+	// typos. Off by default, since reports end up in logs: fields tagged
+	// `secret:"true"` (or inside such a field) are redacted regardless.
+	ShowValues bool
+}
+
+// redactedValue replaces the values of secret settings with ShowValues.
+const redactedValue = "[redacted]"
+
+// Config validates an application configuration struct (typically loaded from
+// env or files at boot) with the current engine (see SetEngine). It returns
+// nil, a *ConfigError listing every invalid setting, or the underlying error
+// if cfg is not a struct. Values are left out of the report unless
+// ConfigOptions.ShowValues is set.
+//
+// Keys use the json tag name when present and the Go field name otherwise,
+// joined with dots for nested structs.
+func Config(cfg any, opts ...ConfigOptions) error {
+	var o ConfigOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	err := CurrentEngine().Struct(cfg)
+	if err == nil {
+		return nil
+	}
+	var vErrs validator.ValidationErrors
+	if !errors.As(err, &vErrs) {
+		return err
+	}
+	ce := &ConfigError{Fields: make([]ConfigFieldError, 0, len(vErrs))}
+	for _, fe := range vErrs {
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+		f := ConfigFieldError{
+			Key:     configKey(fe),
+			Rule:    rule,
+			Message: humanMessage(fe),
+		}
+		if v := fe.Value(); o.ShowValues && v != nil && !reflect.ValueOf(v).IsZero() {
+			if configSecret(reflect.TypeOf(cfg), fe.StructNamespace()) {
+				f.Value = redactedValue
+			} else {
+				f.Value = fmt.Sprintf("%v", v)
+			}
+		}
+		ce.Fields = append(ce.Fields, f)
+	}
+	return ce
+}
+
+// configExit and configOutput are hooks so tests can observe MustConfig.
+var (
+	configExit             = os.Exit
+	configOutput io.Writer = os.Stderr
+)
+
+// MustConfig validates cfg like Config and, on failure, writes the report to
+// stderr and exits the process with status 1. Use it at startup:
+//
+//	validate.MustConfig(cfg)
+func MustConfig(cfg any, opts ...ConfigOptions) {
+	if err := Config(cfg, opts...); err != nil {
+		fmt.Fprintln(configOutput, err.Error())
+		configExit(1)
+	}
+}
+
+// configKey derives a dotted key from a FieldError namespace, dropping the
+// top-level struct name.
+func configKey(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if idx := strings.Index(ns, "."); idx >= 0 {
+		ns = ns[idx+1:]
+	}
+	if ns == "" {
+		ns = fe.Field()
+	}
+	return ns
+}
+
+// configSecret reports whether the field at structNs ("App.Database.Password")
+// in t, or a field containing it, is tagged `secret:"true"`.
+func configSecret(t reflect.Type, structNs string) bool {
+	parts := strings.Split(structNs, ".")
+	for _, name := range parts[1:] {
+		if i := strings.IndexByte(name, '['); i >= 0 {
+			name = name[:i]
+		}
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return false
+		}
+		sf, ok := t.FieldByName(name)
+		if !ok {
+			return false
+		}
+		if sf.Tag.Get("secret") == "true" {
+			return true
+		}
+		t = sf.Type
+		for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+			t = t.Elem()
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type dbConfig struct {
+	URL      string `json:"url" validate:"required,url"`
+	MaxConns int    `json:"max_conns" validate:"gte=1"`
+}
+
+type appConfig struct {
+	Port     int      `json:"port" validate:"min=1,max=65535"`
+	Env      string   `validate:"oneof=dev prod"`
+	Database dbConfig `json:"database"`
+}
+
+func TestConfig_Valid(t *testing.T) {
+	cfg := appConfig{Port: 8080, Env: "dev", Database: dbConfig{URL: "postgres://db", MaxConns: 5}}
+	if err := Config(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestConfig_ListsEverySetting(t *testing.T) {
+	err := Config(appConfig{Port: 70000, Env: "staging"})
+	var ce *ConfigError
+	if !errors.As(err, &ce) {
+		t.Fatalf("expected *ConfigError, got %T %v", err, err)
+	}
+	if len(ce.Fields) != 4 {
+		t.Fatalf("expected 4 invalid settings, got %+v", ce.Fields)
+	}
+	msg := err.Error()
+	for _, want := range []string{
+		"invalid configuration (4 settings):",
+		"  - port: must be at most 65535 (rule: max=65535)",
+		"  - Env: must be one of dev prod (rule: oneof=dev prod)",
+		"  - database.url: is required (rule: required)",
+		"  - database.max_conns: must be greater than or equal to 1 (rule: gte=1)",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Fatalf("expected %q in report:\n%s", want, msg)
+		}
+	}
+}
+
+type secretConfig struct {
+	Port     int           `json:"port" validate:"max=65535"`
+	Password string        `json:"password" secret:"true" validate:"min=12"`
+	Replicas []*replicaCfg `json:"replicas" validate:"dive"`
+	Vault    struct {
+		Token string `json:"token" validate:"len=8"`
+	} `json:"vault" secret:"true"`
+}
+
+type replicaCfg struct {
+	DSN string `json:"dsn" secret:"true" validate:"url"`
+}
+
+func TestConfig_Values(t *testing.T) {
+	cfg := secretConfig{Port: 70000, Password: "hunter2", Replicas: []*replicaCfg{{DSN: "pg pass host"}}}
+	cfg.Vault.Token = "s.abc"
+	if msg := Config(cfg).Error(); strings.Contains(msg, "value:") {
+		t.Fatalf("expected no values by default:\n%s", msg)
+	}
+
+	msg := Config(&cfg, ConfigOptions{ShowValues: true}).Error()
+	for _, want := range []string{
+		"  - port: must be at most 65535 (rule: max=65535, value: 70000)",
+		"  - password: must be at least 12 (rule: min=12, value: [redacted])",
+		"  - replicas[0].dsn: ",
+		"  - vault.token: must be length 8 (rule: len=8, value: [redacted])",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Fatalf("expected %q in report:\n%s", want, msg)
+		}
+	}
+	for _, secret := range []string{"hunter2", "pg pass host", "s.abc"} {
+		if strings.Contains(msg, secret) {
+			t.Fatalf("expected %q to be redacted:\n%s", secret, msg)
+		}
+	}
+}
+
+func TestConfig_UsesCurrentEngine(t *testing.T) {
+	SetEngine(NewNoop())
+	defer SetEngine(nil)
+	if err := Config(appConfig{Port: 70000}); err != nil {
+		t.Fatalf("expected the engine set with SetEngine to be used, got %v", err)
+	}
+	e := NewEngine()
+	e.RegisterStructValidationMapRules(map[string]string{"Env": "required"}, appConfig{})
+	SetEngine(e)
+	var ce *ConfigError
+	if err := Config(appConfig{Port: 8080, Database: dbConfig{URL: "postgres://db", MaxConns: 1}}); !errors.As(err, &ce) || len(ce.Fields) != 1 || ce.Fields[0].Rule != "required" {
+		t.Fatalf("expected the rules of the current engine, got %v", err)
+	}
+}
+
+func TestConfig_SingleSettingWording(t *testing.T) {
+	err := Config(dbConfig{URL: "postgres://db"})
+	if !strings.HasPrefix(err.Error(), "invalid configuration (1 setting):") {
+		t.Fatalf("unexpected report: %s", err)
+	}
+}
+
+func TestConfig_NonStruct(t *testing.T) {
+	err := Config("nope")
+	var ce *ConfigError
+	if err == nil || errors.As(err, &ce) {
+		t.Fatalf("expected raw validator error for non-struct, got %v", err)
+	}
+}
+
+func TestMustConfig(t *testing.T) {
+	var out bytes.Buffer
+	code := -1
+	origExit, origOut := configExit, configOutput
+	defer func() { configExit, configOutput = origExit, origOut }()
+	configExit = func(c int) { code = c }
+	configOutput = &out
+
+	MustConfig(appConfig{Port: 80, Env: "dev", Database: dbConfig{URL: "postgres://db", MaxConns: 1}})
+	if code != -1 || out.Len() != 0 {
+		t.Fatalf("expected no exit for valid config, got code=%d out=%q", code, out.String())
+	}
+
+	MustConfig(appConfig{})
+	if code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(out.String(), "database.url: is required") {
+		t.Fatalf("expected report on output, got %q", out.String())
+	}
+}
@@ -0,0 +1,195 @@
+package validate
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// currencyMinorUnits lists ISO 4217 currencies whose minor unit differs from
+// the default of 2 decimal places.
+var currencyMinorUnits = map[string]int32{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyMinorUnits returns the number of decimal places allowed for an
+// ISO 4217 currency code (2 unless the currency is known to differ).
+func CurrencyMinorUnits(currency string) int32 {
+	if n, ok := currencyMinorUnits[strings.ToUpper(currency)]; ok {
+		return n
+	}
+	return 2
+}
+
+func init() {
+	// Decimal and money tags work on strings, floats, integers and decimal types.
+	// Comparisons use arbitrary precision, so large amounts never overflow.
+	mustRegister("decimal_places", decimalPlaces, map[string]string{DefaultMessageLocale: "must have at most {param} decimal places"})
+	mustRegister("decimal_gt", decimalCmp(func(c int) bool { return c > 0 }), map[string]string{DefaultMessageLocale: "must be greater than {param}"})
+	mustRegister("decimal_gte", decimalCmp(func(c int) bool { return c >= 0 }), map[string]string{DefaultMessageLocale: "must be greater than or equal to {param}"})
+	mustRegister("decimal_lt", decimalCmp(func(c int) bool { return c < 0 }), map[string]string{DefaultMessageLocale: "must be less than {param}"})
+	mustRegister("decimal_lte", decimalCmp(func(c int) bool { return c <= 0 }), map[string]string{DefaultMessageLocale: "must be less than or equal to {param}"})
+	mustRegister("money", money, map[string]string{DefaultMessageLocale: "must be a valid {param} amount"})
+}
+
+// mustRegister registers a built-in tag with its messages and panics on
+// programmer error (invalid tag names).
+func mustRegister(tag string, fn validator.Func, messages map[string]string) {
+	registerBuiltin(func(e *DefaultEngine) {
+		if err := e.RegisterValidationWithMessage(tag, fn, messages); err != nil {
+			panic(err)
+		}
+	})
+}
+
+// mustRegisterCtx is mustRegister for context-aware validations.
+func mustRegisterCtx(tag string, fn validator.FuncCtx, messages map[string]string) {
+	registerBuiltin(func(e *DefaultEngine) {
+		if err := e.RegisterValidationCtx(tag, fn); err != nil {
+			panic(err)
+		}
+		e.RegisterMessages(tag, messages)
+	})
+}
+
+// maxDecimalExponent bounds the exponent of decimal strings ("1e5"), so a
+// short input cannot make comparisons build enormous numbers.
+const maxDecimalExponent = 1024
+
+// decimalNum is an exact decimal value with its significant decimal places.
+type decimalNum struct {
+	r     *big.Rat
+	scale int32
+}
+
+// decimalFromField converts the field under validation into a decimal.
+// Decimal types (shopspring/decimal, ...) are read through driver.Valuer or
+// encoding.TextMarshaler; a NULL value is not a decimal.
+func decimalFromField(field reflect.Value) (decimalNum, bool) {
+	if field.CanInterface() && field.Kind() == reflect.Struct {
+		switch d := field.Interface().(type) {
+		case driver.Valuer:
+			v, err := d.Value()
+			if v == nil || err != nil {
+				return decimalNum{}, false
+			}
+			return decimalFromField(reflect.ValueOf(v))
+		case encoding.TextMarshaler:
+			b, err := d.MarshalText()
+			if err != nil {
+				return decimalNum{}, false
+			}
+			return parseDecimal(string(b))
+		}
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return parseDecimal(strings.TrimSpace(field.String()))
+	case reflect.Float32:
+		return parseDecimal(strconv.FormatFloat(field.Float(), 'f', -1, 32))
+	case reflect.Float64:
+		return parseDecimal(strconv.FormatFloat(field.Float(), 'f', -1, 64))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decimalNum{r: new(big.Rat).SetInt64(field.Int())}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return decimalNum{r: new(big.Rat).SetUint64(field.Uint())}, true
+	}
+	return decimalNum{}, false
+}
+
+// decimalField returns the struct field under validation as declared, before
+// custom type functions converted it, so decimal types registered with a
+// numeric custom type func (as typesvalidate does for the built-in gt, lte,
+// ... tags) are still read exactly. Other fields are returned as validated.
+func decimalField(fl validator.FieldLevel) reflect.Value {
+	if p := fl.Parent(); p.Kind() == reflect.Struct {
+		if f := reflect.Indirect(p.FieldByName(fl.StructFieldName())); f.Kind() == reflect.Struct {
+			return f
+		}
+	}
+	return fl.Field()
+}
+
+// parseDecimal parses a plain decimal number: an optional sign, digits with
+// an optional fraction and an optional exponent ("-12.50", "1e3"). Fractions
+// ("1/3"), hex and underscores, which big.Rat also accepts, are rejected.
+func parseDecimal(s string) (decimalNum, bool) {
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e > maxDecimalExponent || e < -maxDecimalExponent {
+			return decimalNum{}, false
+		}
+		exp = e
+	}
+	if mantissa != "" && (mantissa[0] == '-' || mantissa[0] == '+') {
+		mantissa = mantissa[1:]
+	}
+	whole, frac, _ := strings.Cut(mantissa, ".")
+	if whole+frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return decimalNum{}, false
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return decimalNum{}, false
+	}
+	// Trailing zeros ("1.50") don't count as extra precision.
+	places := len(strings.TrimRight(frac, "0")) - exp
+	if places < 0 {
+		places = 0
+	}
+	return decimalNum{r: r, scale: int32(places)}, true
+}
+
+// isDigits reports whether s holds only ASCII digits (or is empty).
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func decimalPlaces(fl validator.FieldLevel) bool {
+	d, ok := decimalFromField(decimalField(fl))
+	if !ok {
+		return false
+	}
+	places, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		panic("validate: invalid decimal_places parameter " + strconv.Quote(fl.Param()))
+	}
+	return d.scale <= int32(places)
+}
+
+func decimalCmp(ok func(cmp int) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		d, valid := decimalFromField(decimalField(fl))
+		if !valid {
+			return false
+		}
+		bound, valid := parseDecimal(fl.Param())
+		if !valid {
+			panic("validate: invalid decimal parameter " + strconv.Quote(fl.Param()))
+		}
+		return ok(d.r.Cmp(bound.r))
+	}
+}
+
+func money(fl validator.FieldLevel) bool {
+	d, ok := decimalFromField(decimalField(fl))
+	if !ok {
+		return false
+	}
+	return d.scale <= CurrencyMinorUnits(fl.Param())
+}
@@ -0,0 +1,135 @@
+package validate
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type payment struct {
+	Amount   string  `json:"amount" validate:"decimal_places=2,decimal_gt=0"`
+	Fee      float64 `json:"fee" validate:"decimal_gte=0,decimal_lte=10"`
+	Yen      string  `json:"yen" validate:"omitempty,money=JPY"`
+	Dinar    string  `json:"dinar" validate:"omitempty,money=BHD"`
+	Cap      int64   `json:"cap" validate:"decimal_lt=100000000000000000000"`
+	Discount string  `json:"discount" validate:"omitempty,money=usd"`
+}
+
+func TestDecimalTags_Valid(t *testing.T) {
+	p := payment{Amount: "10.50", Fee: 2.25, Yen: "1500", Dinar: "1.125", Cap: 9223372036854775807, Discount: "0.10"}
+	if err := Struct(p); err != nil {
+		t.Fatalf("unexpected error: %v", ToFieldErrors(err))
+	}
+}
+
+func TestDecimalTags_Invalid(t *testing.T) {
+	p := payment{Amount: "10.555", Fee: 10.01, Yen: "1500.5", Dinar: "1.1255", Discount: "0.001"}
+	m := ToFieldErrors(Struct(p))
+	want := map[string]string{
+		"amount":   "must have at most 2 decimal places",
+		"fee":      "must be less than or equal to 10",
+		"yen":      "must be a valid JPY amount",
+		"dinar":    "must be a valid BHD amount",
+		"discount": "must be a valid usd amount",
+	}
+	for k, msg := range want {
+		if m[k] != msg {
+			t.Fatalf("field %q: expected %q, got %q (all: %v)", k, msg, m[k], m)
+		}
+	}
+}
+
+func TestDecimalTags_NonPositiveAndUnparseable(t *testing.T) {
+	m := ToFieldErrors(Struct(payment{Amount: "0"}))
+	if m["amount"] != "must be greater than 0" {
+		t.Fatalf("expected gt error, got %v", m)
+	}
+	m = ToFieldErrors(Struct(payment{Amount: "abc"}))
+	if m["amount"] != "must have at most 2 decimal places" {
+		t.Fatalf("expected unparseable amount to fail, got %v", m)
+	}
+}
+
+// textDecimal and valuerDecimal stand in for decimal types such as
+// shopspring/decimal's Decimal and NullDecimal.
+type textDecimal struct{ s string }
+
+func (d textDecimal) MarshalText() ([]byte, error) { return []byte(d.s), nil }
+
+type valuerDecimal struct {
+	s     string
+	valid bool
+}
+
+func (d valuerDecimal) Value() (driver.Value, error) {
+	if !d.valid {
+		return nil, nil
+	}
+	return d.s, nil
+}
+
+func TestDecimalTags_DecimalType(t *testing.T) {
+	type s struct {
+		Price textDecimal   `validate:"decimal_places=2,decimal_gt=0"`
+		Limit valuerDecimal `validate:"decimal_lte=100000000000000000000.01"`
+	}
+	if err := Validator.Struct(s{Price: textDecimal{"19.99"}, Limit: valuerDecimal{"100000000000000000000.01", true}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := ToFieldErrors(Validator.Struct(s{Price: textDecimal{"19.999"}, Limit: valuerDecimal{"100000000000000000000.02", true}}))
+	if m["Price"] != "must have at most 2 decimal places" || m["Limit"] == "" {
+		t.Fatalf("expected decimal_places and exact decimal_lte failures, got %v", m)
+	}
+	if err := Validator.Struct(s{Price: textDecimal{"1"}, Limit: valuerDecimal{}}); err == nil {
+		t.Fatalf("expected NULL decimal to fail")
+	}
+}
+
+func TestDecimalTags_CustomTypeFunc(t *testing.T) {
+	e := NewEngine()
+	e.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		f, _ := strconv.ParseFloat(field.Interface().(textDecimal).s, 64)
+		return f
+	}, textDecimal{})
+	type s struct {
+		Price textDecimal `validate:"gte=0,decimal_places=2,decimal_lte=100000000000000000000.01"`
+	}
+	if err := e.Struct(s{Price: textDecimal{"100000000000000000000.01"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := ToFieldErrors(e.Struct(s{Price: textDecimal{"100000000000000000000.02"}}))
+	if m["Price"] != "must be less than or equal to 100000000000000000000.01" {
+		t.Fatalf("expected the declared field to be compared exactly, got %v", m)
+	}
+	if m = ToFieldErrors(e.Struct(s{Price: textDecimal{"-1"}})); m["Price"] != "must be greater than or equal to 0" {
+		t.Fatalf("expected gte to compare the converted value, got %v", m)
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	cases := map[string]int32{"1": 0, "1.50": 1, "1.05": 2, "100": 0, "0.0001": 4, "-2.5": 1, "+3": 0, ".5": 1, "5.": 0, "1.5e3": 0, "12e-3": 3}
+	for in, want := range cases {
+		d, ok := parseDecimal(in)
+		if !ok || d.scale != want {
+			t.Fatalf("parseDecimal(%s) = %d %v, want %d", in, d.scale, ok, want)
+		}
+	}
+	for _, in := range []string{"", "-", ".", "1/2", "0x10", "1_000", "1e", "1e99999", "--1", "1.2.3", "NaN", "Inf", " 1"} {
+		if _, ok := parseDecimal(in); ok {
+			t.Fatalf("parseDecimal(%q): expected failure", in)
+		}
+	}
+}
+
+func TestDecimalTags_FloatExact(t *testing.T) {
+	if Var("fee", 0.1, "decimal_places=1,decimal_lte=0.1") != nil {
+		t.Fatalf("expected 0.1 to keep one decimal place and equal 0.1")
+	}
+}
+
+func TestCurrencyMinorUnits(t *testing.T) {
+	if CurrencyMinorUnits("jpy") != 0 || CurrencyMinorUnits("KWD") != 3 || CurrencyMinorUnits("EUR") != 2 {
+		t.Fatalf("unexpected minor units")
+	}
+}
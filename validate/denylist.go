@@ -0,0 +1,185 @@
+package validate
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MatchMode selects how a WordList matches values.
+type MatchMode int
+
+const (
+	// MatchExact matches values equal to a word, ignoring case.
+	MatchExact MatchMode = iota
+	// MatchPrefix matches values starting with a word, ignoring case, e.g.
+	// reserved "admin" also blocks "admin-team".
+	MatchPrefix
+	// MatchNormalized matches values containing a word after both are
+	// lower-cased, stripped of everything but letters and digits, and common
+	// look-alike digits and symbols are folded ("4dm1n" -> "admin").
+	MatchNormalized
+)
+
+// WordSource supplies the words of a WordList, e.g. from a file, database or
+// remote service.
+type WordSource interface {
+	Words(ctx context.Context) ([]string, error)
+}
+
+// WordSourceFunc adapts a function to WordSource.
+type WordSourceFunc func(ctx context.Context) ([]string, error)
+
+// Words calls f.
+func (f WordSourceFunc) Words(ctx context.Context) ([]string, error) { return f(ctx) }
+
+// WordList is a named, runtime-updatable list of denied words. Updates replace
+// the whole list atomically.
+type WordList struct {
+	mode  MatchMode
+	words atomic.Pointer[[]string]
+}
+
+var (
+	denylistsMu sync.RWMutex
+	denylists   = map[string]*WordList{}
+)
+
+func init() {
+	mustRegister("denylist", isNotDenied, map[string]string{DefaultMessageLocale: "is not allowed"})
+}
+
+// RegisterDenylist creates (or replaces) the list used by `denylist=<name>`:
+//
+//	validate.RegisterDenylist("usernames", validate.MatchPrefix, "admin", "root", "support")
+//
+//	type Signup struct {
+//		Username string `json:"username" validate:"required,denylist=usernames"`
+//	}
+//
+// Update it later with SetWords, UpdateFromReader or Refresh.
+func RegisterDenylist(name string, mode MatchMode, words ...string) *WordList {
+	l := &WordList{mode: mode}
+	l.SetWords(words)
+	denylistsMu.Lock()
+	denylists[name] = l
+	denylistsMu.Unlock()
+	return l
+}
+
+// Denylist returns the list registered under name, or nil.
+func Denylist(name string) *WordList {
+	denylistsMu.RLock()
+	defer denylistsMu.RUnlock()
+	return denylists[name]
+}
+
+// SetWords replaces the words of l.
+func (l *WordList) SetWords(words []string) {
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = l.normalize(w); w != "" {
+			out = append(out, w)
+		}
+	}
+	l.words.Store(&out)
+}
+
+// UpdateFromReader replaces the words of l with those read from r, one per
+// line. Blank lines and lines starting with "#" are ignored. On a read error
+// l is left unchanged.
+func (l *WordList) UpdateFromReader(r io.Reader) error {
+	var words []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	l.SetWords(words)
+	return nil
+}
+
+// Refresh replaces the words of l with those of src. On error l is left
+// unchanged.
+func (l *WordList) Refresh(ctx context.Context, src WordSource) error {
+	words, err := src.Words(ctx)
+	if err != nil {
+		return fmt.Errorf("validate: refresh denylist: %w", err)
+	}
+	l.SetWords(words)
+	return nil
+}
+
+// Match reports whether value is denied by l.
+func (l *WordList) Match(value string) bool {
+	words := l.words.Load()
+	if words == nil {
+		return false
+	}
+	v := l.normalize(value)
+	if v == "" {
+		return false
+	}
+	for _, w := range *words {
+		switch l.mode {
+		case MatchPrefix:
+			if strings.HasPrefix(v, w) {
+				return true
+			}
+		case MatchNormalized:
+			if strings.Contains(v, w) {
+				return true
+			}
+		default:
+			if v == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// leetFold maps look-alike characters to letters for MatchNormalized.
+var leetFold = map[rune]rune{'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's', '!': 'i'}
+
+func (l *WordList) normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if l.mode != MatchNormalized {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if f, ok := leetFold[r]; ok {
+			r = f
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isNotDenied rejects strings matched by the list named in the parameter.
+// Unknown lists deny nothing.
+func isNotDenied(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	l := Denylist(fl.Param())
+	return l == nil || !l.Match(field.String())
+}
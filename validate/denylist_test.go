@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDenylistTag(t *testing.T) {
+	RegisterDenylist("test_exact", MatchExact, "Admin", "root")
+	RegisterDenylist("test_prefix", MatchPrefix, "admin")
+	RegisterDenylist("test_norm", MatchNormalized, "badword")
+	defer func() {
+		denylistsMu.Lock()
+		delete(denylists, "test_exact")
+		delete(denylists, "test_prefix")
+		delete(denylists, "test_norm")
+		denylistsMu.Unlock()
+	}()
+
+	cases := []struct {
+		value, list string
+		denied      bool
+	}{
+		{"ADMIN", "test_exact", true},
+		{"admin-team", "test_exact", false},
+		{"admin-team", "test_prefix", true},
+		{"the-admin", "test_prefix", false},
+		{"xx_B4d-W0rd_xx", "test_norm", true},
+		{"goodword", "test_norm", false},
+		{"anything", "unknown_list", false},
+	}
+	for _, c := range cases {
+		err := Var("name", c.value, "denylist="+c.list)
+		if (err != nil) != c.denied {
+			t.Fatalf("%q in %s: expected denied=%v, got %v", c.value, c.list, c.denied, err)
+		}
+		if c.denied && ToFieldErrors(err)["name"] != "is not allowed" {
+			t.Fatalf("unexpected message: %v", ToFieldErrors(err))
+		}
+	}
+	if Var("name", 1, "denylist=test_exact") == nil {
+		t.Fatalf("expected non-string to fail")
+	}
+}
+
+func TestWordList_Updates(t *testing.T) {
+	l := RegisterDenylist("test_updates", MatchExact, "a")
+	defer func() {
+		denylistsMu.Lock()
+		delete(denylists, "test_updates")
+		denylistsMu.Unlock()
+	}()
+	if Denylist("test_updates") != l || !l.Match("A") {
+		t.Fatalf("expected registered list")
+	}
+	if err := l.UpdateFromReader(strings.NewReader("# reserved\nb\n\n")); err != nil || l.Match("a") || !l.Match("b") {
+		t.Fatalf("expected reader update, got %v", err)
+	}
+	src := WordSourceFunc(func(context.Context) ([]string, error) { return []string{"c"}, nil })
+	if err := l.Refresh(context.Background(), src); err != nil || !l.Match("c") || l.Match("b") {
+		t.Fatalf("expected refresh, got %v", err)
+	}
+	failing := WordSourceFunc(func(context.Context) ([]string, error) { return nil, errors.New("down") })
+	if err := l.Refresh(context.Background(), failing); err == nil || !l.Match("c") {
+		t.Fatalf("expected failed refresh to keep words")
+	}
+	if err := l.UpdateFromReader(errReader{}); err == nil || !l.Match("c") {
+		t.Fatalf("expected failed read to keep words")
+	}
+	if (&WordList{}).Match("x") || l.Match("  ") {
+		t.Fatalf("expected empty list and value to match nothing")
+	}
+}
@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// DeprecationWarnings returns a warning for every field of v that carries a
+// `deprecated` struct tag and is set (non-zero), keyed like ToFieldErrors
+// (nested fields joined with ".", slice elements by index):
+//
+//	type User struct {
+//		Name     string `json:"name"`
+//		Username string `json:"username" deprecated:"use name"`
+//	}
+//	// {"username": "use name"} when a client still sends username
+//
+// Deprecated fields never fail validation; return the warnings alongside the
+// response to help migrate clients. Each warning increments
+// MetricDeprecatedField.
+func DeprecationWarnings(v any) map[string]string {
+	res := map[string]string{}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return res
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Struct {
+		collectDeprecated(rv, "", rv.Type().Name(), res)
+	}
+	return res
+}
+
+func collectDeprecated(sv reflect.Value, prefix, typeName string, res map[string]string) {
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := sv.Field(i)
+		if sf.Anonymous {
+			if fv = indirectValue(fv); fv.Kind() == reflect.Struct {
+				collectDeprecated(fv, prefix, typeName, res)
+			}
+			continue
+		}
+		name := jsonName(sf)
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+		if note, ok := sf.Tag.Lookup("deprecated"); ok && !fv.IsZero() {
+			res[key] = note
+			incMetric(MetricDeprecatedField, map[string]string{"type": typeName, "field": key})
+		}
+
+		switch fv = indirectValue(fv); fv.Kind() {
+		case reflect.Struct:
+			collectDeprecated(fv, key+".", typeName, res)
+		case reflect.Slice, reflect.Array:
+			for j := 0; j < fv.Len(); j++ {
+				if ev := indirectValue(fv.Index(j)); ev.Kind() == reflect.Struct {
+					collectDeprecated(ev, key+"."+strconv.Itoa(j)+".", typeName, res)
+				}
+			}
+		}
+	}
+}
+
+// indirectValue dereferences pointers, returning an invalid Value for nil.
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
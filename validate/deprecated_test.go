@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type legacyAddress struct {
+	Zip    string `json:"zip" deprecated:"use postal_code"`
+	Postal string `json:"postal_code"`
+}
+
+type legacyUser struct {
+	Name      string          `json:"name" validate:"required"`
+	Username  string          `json:"username" deprecated:"use name"`
+	Age       *int            `json:"age" deprecated:"use birth_date"`
+	Home      *legacyAddress  `json:"home"`
+	Addresses []legacyAddress `json:"addresses"`
+	Created   time.Time       `json:"created"`
+}
+
+func TestDeprecationWarnings(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		counts = map[string]int{}
+	)
+	SetMetrics(func(metric string, labels map[string]string) {
+		mu.Lock()
+		counts[metric+":"+labels["type"]+":"+labels["field"]]++
+		mu.Unlock()
+	})
+	defer SetMetrics(nil)
+
+	age := 3
+	u := &legacyUser{
+		Username:  "ann",
+		Age:       &age,
+		Home:      &legacyAddress{Zip: "123"},
+		Addresses: []legacyAddress{{Postal: "1"}, {Zip: "9"}},
+	}
+	got := DeprecationWarnings(u)
+	want := map[string]string{
+		"username":        "use name",
+		"age":             "use birth_date",
+		"home.zip":        "use postal_code",
+		"addresses.1.zip": "use postal_code",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected warnings: %v", got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("warning %q: expected %q, got %q", k, v, got[k])
+		}
+	}
+	if counts[MetricDeprecatedField+":legacyUser:home.zip"] != 1 || len(counts) != 4 {
+		t.Fatalf("unexpected metrics: %v", counts)
+	}
+
+	// Deprecated fields never fail validation, and unset ones are silent.
+	if err := Struct(legacyUser{Name: "Ann", Username: "x"}); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	if w := DeprecationWarnings(legacyUser{Name: "Ann"}); len(w) != 0 {
+		t.Fatalf("expected no warnings, got %v", w)
+	}
+	if w := DeprecationWarnings((*legacyUser)(nil)); len(w) != 0 {
+		t.Fatalf("expected no warnings for nil, got %v", w)
+	}
+}
@@ -0,0 +1,237 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// immutable and writeonce are enforced by Diff; plain validation accepts
+	// any value, including nil pointers. readonly rejects any sent value.
+	registerBuiltin(func(e *DefaultEngine) {
+		for tag, rule := range map[string]struct {
+			fn  validator.Func
+			msg string
+		}{
+			"immutable": {acceptAny, "cannot be changed"},
+			"writeonce": {acceptAny, "cannot be changed once set"},
+			"readonly":  {isUnset, "is read-only"},
+		} {
+			if err := e.RegisterValidationWithMessage(tag, rule.fn, map[string]string{DefaultMessageLocale: rule.msg}, true); err != nil {
+				panic(err)
+			}
+		}
+	})
+}
+
+// acceptAny is the validation of tags enforced outside Struct.
+func acceptAny(validator.FieldLevel) bool { return true }
+
+// isUnset validates `readonly`: the field must not be sent, i.e. be nil or
+// the zero value.
+func isUnset(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Pointer, reflect.Interface:
+		return field.IsNil()
+	}
+	return field.IsZero()
+}
+
+// Diff reports fields of incoming whose `validate` tag marks them immutable
+// and whose value differs from the same field (matched by json name) of
+// existing, or writeonce fields that differ from a non-zero existing value,
+// so update handlers reject changes declaratively:
+//
+//	type UpdateUser struct {
+//		Email    *string `json:"email" validate:"omitempty,email,immutable"`
+//		TenantID string  `json:"tenant_id" validate:"immutable"`
+//		Handle   *string `json:"handle" validate:"omitempty,writeonce"`
+//		Name     *string `json:"name"`
+//	}
+//
+//	if err := validate.Diff(current, in); err != nil {
+//		return c.Status(422).JSON(err) // {"email": "cannot be changed"}
+//	}
+//
+// existing and incoming may be different struct types (a model and a request)
+// or pointers to them. Nil pointer fields of incoming count as not sent, so
+// PATCH payloads only fail for fields they actually change. Nested structs are
+// compared field by field and reported with dotted keys. Values are compared
+// with their Equal method when they have one (time.Time, decimal.Decimal),
+// otherwise with reflect.DeepEqual.
+//
+// Failures are returned as FieldErrors with messages resolved like
+// ToFieldErrors, or nil.
+func Diff(existing, incoming any) error {
+	return DiffCtx(context.Background(), existing, incoming)
+}
+
+// DiffCtx is like Diff but resolves messages for the request in ctx (locale
+// and message function).
+func DiffCtx(ctx context.Context, existing, incoming any) error {
+	in := indirectValue(reflect.ValueOf(incoming))
+	if in.Kind() != reflect.Struct {
+		return nil
+	}
+	var errs validator.ValidationErrors
+	diffStruct(indirectValue(reflect.ValueOf(existing)), in, "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return FieldErrors(ToFieldErrorsWithContext(ctx, errs))
+}
+
+// diffStruct compares the fields of in against cur, which may be invalid
+// when the existing value has no such struct.
+func diffStruct(cur, in reflect.Value, prefix string, errs *validator.ValidationErrors) {
+	st := in.Type()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		fv := in.Field(i)
+		if sf.Anonymous {
+			if fv = indirectValue(fv); fv.Kind() == reflect.Struct {
+				diffStruct(cur, fv, prefix, errs)
+			}
+			continue
+		}
+		name := jsonName(sf)
+		if name == "" {
+			continue
+		}
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			continue
+		}
+		key := prefix + name
+		old := fieldByJSONName(cur, name)
+		for _, tag := range updateTags(sf) {
+			switch tag {
+			case "immutable", "writeonce":
+				if tag == "writeonce" && (!old.IsValid() || old.IsZero()) {
+					continue
+				}
+				if !sameValue(old, fv) {
+					*errs = append(*errs, &updateFieldError{tag: tag, field: key, value: fv.Interface(), typ: sf.Type})
+				}
+			}
+		}
+		if fv = indirectValue(fv); fv.Kind() == reflect.Struct && !hasEqual(fv.Type()) {
+			diffStruct(old, fv, key+".", errs)
+		}
+	}
+}
+
+// updateTags returns the tags of sf's `validate` tag that apply to whole
+// values, stopping at dive.
+func updateTags(sf reflect.StructField) []string {
+	var tags []string
+	for _, t := range strings.Split(sf.Tag.Get("validate"), ",") {
+		if t == "dive" {
+			break
+		}
+		tags = append(tags, t)
+	}
+	return tags
+}
+
+// fieldByJSONName returns the dereferenced field of struct v with json name
+// name, or an invalid Value.
+func fieldByJSONName(v reflect.Value, name string) reflect.Value {
+	if v = indirectValue(v); v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	st := v.Type()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if sf.Anonymous {
+			if fv := fieldByJSONName(v.Field(i), name); fv.IsValid() {
+				return fv
+			}
+			continue
+		}
+		if jsonName(sf) == name {
+			return indirectValue(v.Field(i))
+		}
+	}
+	return reflect.Value{}
+}
+
+// sameValue reports whether the incoming value b equals the existing value a.
+// A missing existing value equals only the zero value.
+func sameValue(a, b reflect.Value) bool {
+	b = indirectValue(b)
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid() || (a.IsValid() && a.IsZero()) || (b.IsValid() && b.IsZero())
+	}
+	if a.Type() != b.Type() {
+		if !b.Type().ConvertibleTo(a.Type()) {
+			return false
+		}
+		b = b.Convert(a.Type())
+	}
+	if hasEqual(a.Type()) {
+		return a.MethodByName("Equal").Call([]reflect.Value{b})[0].Bool()
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// hasEqual reports whether t has a method Equal(t) bool.
+func hasEqual(t reflect.Type) bool {
+	m, ok := t.MethodByName("Equal")
+	return ok && m.Type.NumIn() == 2 && m.Type.In(1) == t &&
+		m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Bool
+}
+
+// updateFieldError is the validator.FieldError reported by Diff, also used as
+// a sample error by CheckTranslations.
+type updateFieldError struct {
+	tag   string
+	param string
+	field string
+	value any
+	typ   reflect.Type
+}
+
+func (e *updateFieldError) Tag() string             { return e.tag }
+func (e *updateFieldError) ActualTag() string       { return e.tag }
+func (e *updateFieldError) Namespace() string       { return e.field }
+func (e *updateFieldError) StructNamespace() string { return e.field }
+func (e *updateFieldError) Field() string           { return e.field }
+func (e *updateFieldError) StructField() string     { return e.field }
+func (e *updateFieldError) Value() any              { return e.value }
+func (e *updateFieldError) Param() string           { return e.param }
+func (e *updateFieldError) Kind() reflect.Kind      { return e.typ.Kind() }
+func (e *updateFieldError) Type() reflect.Type      { return e.typ }
+
+// Translate looks the message up in trans by tag, as the validator's default
+// translations register it: under the tag itself, or under tag-string,
+// tag-number and tag-items for size comparisons such as min and lte. It
+// returns Error when trans has none.
+func (e *updateFieldError) Translate(trans ut.Translator) string {
+	if trans == nil {
+		return e.Error()
+	}
+	for _, key := range []string{e.tag, e.tag + "-string", e.tag + "-number", e.tag + "-items"} {
+		if msg, err := trans.T(key, e.field, e.param); err == nil {
+			return msg
+		}
+	}
+	return e.Error()
+}
+
+func (e *updateFieldError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation for '%s' failed on the '%s' tag", e.field, e.field, e.tag)
+}
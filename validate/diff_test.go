@@ -0,0 +1,103 @@
+package validate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type userModel struct {
+	Email    string    `json:"email"`
+	TenantID int64     `json:"tenant_id"`
+	Name     string    `json:"name"`
+	Created  time.Time `json:"created"`
+	Address  struct {
+		Country string `json:"country"`
+	} `json:"address"`
+}
+
+type diffAddress struct {
+	Country string `json:"country" validate:"immutable"`
+}
+
+type updateUser struct {
+	Email    *string      `json:"email" validate:"omitempty,email,immutable"`
+	TenantID int64        `json:"tenant_id" validate:"immutable"`
+	Name     *string      `json:"name"`
+	Created  *time.Time   `json:"created" validate:"immutable"`
+	Address  *diffAddress `json:"address"`
+}
+
+func TestDiff_Immutable(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	cur := userModel{Email: "a@b.co", TenantID: 7, Name: "Ann", Created: created}
+	cur.Address.Country = "DE"
+
+	name, sameCreated := "Bob", created.In(time.FixedZone("X", 3600))
+	if err := Diff(cur, &updateUser{TenantID: 7, Name: &name, Created: &sameCreated}); err != nil {
+		t.Fatalf("expected unchanged immutable fields to pass, got %v", err)
+	}
+	email := "new@b.co"
+	fe, _ := Diff(&cur, updateUser{Email: &email, TenantID: 8, Address: &diffAddress{Country: "FR"}}).(FieldErrors)
+	want := FieldErrors{"email": "cannot be changed", "tenant_id": "cannot be changed", "address.country": "cannot be changed"}
+	if len(fe) != len(want) {
+		t.Fatalf("expected %v, got %v", want, fe)
+	}
+	for k, v := range want {
+		if fe[k] != v {
+			t.Fatalf("expected %v, got %v", want, fe)
+		}
+	}
+}
+
+func TestDiff_MissingExistingAndLocale(t *testing.T) {
+	e := NewEngine()
+	e.RegisterMessages("immutable", map[string]string{"en": "cannot be changed", "es": "no se puede cambiar"})
+	ctx := WithLocale(WithEngine(context.Background(), e), "es")
+	if fe, _ := DiffCtx(ctx, struct{}{}, updateUser{TenantID: 1}).(FieldErrors); fe["tenant_id"] != "no se puede cambiar" {
+		t.Fatalf("expected localized change error, got %v", fe)
+	}
+	if err := Diff(nil, updateUser{}); err != nil {
+		t.Fatalf("expected zero values to match a missing record, got %v", err)
+	}
+	if err := Struct(updateUser{TenantID: 1}); err != nil {
+		t.Fatalf("immutable must not fail plain validation, got %v", err)
+	}
+}
+
+type profileModel struct {
+	Handle string `json:"handle"`
+}
+
+type updateProfile struct {
+	ID     string  `json:"id" validate:"readonly"`
+	Handle *string `json:"handle" validate:"omitempty,writeonce"`
+	Score  *int    `json:"score" validate:"readonly"`
+}
+
+func TestReadonly(t *testing.T) {
+	if err := Struct(updateProfile{}); err != nil {
+		t.Fatalf("expected unset read-only fields to pass, got %v", err)
+	}
+	score := 3
+	m := ToFieldErrors(Struct(updateProfile{ID: "u_1", Score: &score}))
+	if m["id"] != "is read-only" || m["score"] != "is read-only" {
+		t.Fatalf("expected read-only errors, got %v", m)
+	}
+}
+
+func TestDiff_Writeonce(t *testing.T) {
+	handle, other := "ann", "bob"
+	if err := Diff(profileModel{}, updateProfile{Handle: &handle}); err != nil {
+		t.Fatalf("expected first write to pass, got %v", err)
+	}
+	if err := Diff(profileModel{Handle: "ann"}, updateProfile{Handle: &handle}); err != nil {
+		t.Fatalf("expected resending the same value to pass, got %v", err)
+	}
+	if fe, _ := Diff(profileModel{Handle: "ann"}, updateProfile{Handle: &other}).(FieldErrors); fe["handle"] != "cannot be changed once set" {
+		t.Fatalf("expected write-once error, got %v", fe)
+	}
+	if err := Diff(profileModel{Handle: "ann"}, updateProfile{}); err != nil {
+		t.Fatalf("expected omitted field to pass, got %v", err)
+	}
+}
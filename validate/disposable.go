@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-playground/validator/v10"
+)
+
+//go:embed disposable_domains.txt
+var disposableDomainsTxt string
+
+// DomainList is a set of domains that matches a domain and its subdomains.
+// Updates replace the whole list atomically, so it can be refreshed while
+// requests are validated.
+type DomainList struct {
+	set atomic.Pointer[map[string]struct{}]
+}
+
+// NewDomainList returns a list holding domains.
+func NewDomainList(domains ...string) *DomainList {
+	l := &DomainList{}
+	l.SetList(domains)
+	return l
+}
+
+// DisposableDomains is the list behind `email_not_disposable`, initialized
+// from an embedded list of common disposable email providers.
+var DisposableDomains = func() *DomainList {
+	l := &DomainList{}
+	_ = l.UpdateFromReader(strings.NewReader(disposableDomainsTxt))
+	return l
+}()
+
+func init() {
+	mustRegister("email_not_disposable", emailNotDisposable, map[string]string{DefaultMessageLocale: "disposable email addresses are not allowed"})
+}
+
+// SetList replaces the domains of l. Domains are matched case-insensitively.
+func (l *DomainList) SetList(domains []string) {
+	set := make(map[string]struct{}, len(domains))
+	for _, d := range domains {
+		if d = normalizeDomain(d); d != "" {
+			set[d] = struct{}{}
+		}
+	}
+	l.set.Store(&set)
+}
+
+// UpdateFromReader replaces the domains of l with those read from r, one per
+// line. Blank lines and lines starting with "#" are ignored. On a read error
+// l is left unchanged.
+func (l *DomainList) UpdateFromReader(r io.Reader) error {
+	var domains []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	l.SetList(domains)
+	return nil
+}
+
+// Contains reports whether domain or one of its parent domains is listed.
+func (l *DomainList) Contains(domain string) bool {
+	set := l.set.Load()
+	if set == nil {
+		return false
+	}
+	for d := normalizeDomain(domain); d != ""; {
+		if _, ok := (*set)[d]; ok {
+			return true
+		}
+		i := strings.IndexByte(d, '.')
+		if i < 0 {
+			break
+		}
+		d = d[i+1:]
+	}
+	return false
+}
+
+// Len returns the number of listed domains.
+func (l *DomainList) Len() int {
+	if set := l.set.Load(); set != nil {
+		return len(*set)
+	}
+	return 0
+}
+
+func normalizeDomain(d string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d)), ".")
+}
+
+// emailNotDisposable rejects addresses at a domain in DisposableDomains.
+// Values without "@" pass; combine with `email` to check the address itself.
+func emailNotDisposable(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	s := field.String()
+	i := strings.LastIndexByte(s, '@')
+	if i < 0 {
+		return true
+	}
+	return !DisposableDomains.Contains(s[i+1:])
+}
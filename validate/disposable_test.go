@@ -0,0 +1,46 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("boom") }
+
+func TestEmailNotDisposable(t *testing.T) {
+	if DisposableDomains.Len() == 0 {
+		t.Fatalf("expected embedded domains")
+	}
+	for _, v := range []string{"ann@example.com", "not-an-email"} {
+		if err := Var("email", v, "email_not_disposable"); err != nil {
+			t.Fatalf("%q: unexpected error %v", v, err)
+		}
+	}
+	for _, v := range []string{"bot@mailinator.com", "Bot@Inbox.YOPMAIL.com"} {
+		if m := ToFieldErrors(Var("email", v, "email_not_disposable")); m["email"] != "disposable email addresses are not allowed" {
+			t.Fatalf("%q: expected disposable error, got %v", v, m)
+		}
+	}
+	if Var("email", 1, "email_not_disposable") == nil {
+		t.Fatalf("expected non-string to fail")
+	}
+}
+
+func TestDomainList(t *testing.T) {
+	l := NewDomainList("Example.COM.", " ")
+	if !l.Contains("a.b.example.com") || l.Contains("example.org") || l.Len() != 1 {
+		t.Fatalf("unexpected matching")
+	}
+	if err := l.UpdateFromReader(strings.NewReader("# comment\n\nfoo.io\nbar.io\n")); err != nil || l.Len() != 2 || l.Contains("example.com") || !l.Contains("x.bar.io") {
+		t.Fatalf("expected list to be replaced, got %v (len %d)", err, l.Len())
+	}
+	if err := l.UpdateFromReader(errReader{}); err == nil || l.Len() != 2 {
+		t.Fatalf("expected read errors to keep the list")
+	}
+	if (&DomainList{}).Contains("x") {
+		t.Fatalf("expected empty list to match nothing")
+	}
+}
@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DNSResolver resolves host names. *net.Resolver implements it.
+type DNSResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// DNSOptions configures EnableDNSChecks.
+type DNSOptions struct {
+	// Resolver performs lookups. Default: net.DefaultResolver.
+	Resolver DNSResolver
+	// Timeout bounds each lookup within the context deadline. Default: 2s.
+	Timeout time.Duration
+	// CacheTTL keeps answers per name. Default: 10 minutes.
+	CacheTTL time.Duration
+	// FailClosed rejects names when the lookup errors or times out (other
+	// than "not found"). By default such names pass.
+	FailClosed bool
+}
+
+// dnsState holds the enabled DNS checks.
+type dnsState struct {
+	resolvable *remoteCheck
+}
+
+var dnsChecks atomic.Pointer[dnsState]
+
+func init() {
+	mustRegisterCtx("fqdn_resolvable", fqdnResolvable, map[string]string{DefaultMessageLocale: "must be a resolvable host name"})
+}
+
+// EnableDNSChecks turns on DNS lookups for `fqdn_resolvable`. Lookups only
+// run when the validation context has a deadline (e.g. a request context with
+// a timeout, passed to StructCtx), so validation without one never blocks on
+// the network; answers are cached like other remote checks (see
+// RegisterRemote).
+func EnableDNSChecks(opts DNSOptions) {
+	r := opts.Resolver
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = 10 * time.Minute
+	}
+	ro := RemoteOptions{Timeout: opts.Timeout, CacheTTL: opts.CacheTTL, FailClosed: opts.FailClosed}
+	dnsChecks.Store(&dnsState{
+		resolvable: newRemoteCheck("fqdn_resolvable", RemoteFunc(func(ctx context.Context, host string) (bool, error) {
+			addrs, err := r.LookupHost(ctx, host)
+			if err != nil {
+				return false, notFoundAsInvalid(err)
+			}
+			return len(addrs) > 0, nil
+		}), ro),
+	})
+}
+
+// DisableDNSChecks turns DNS lookups off again; `fqdn_resolvable` then only
+// checks the syntax.
+func DisableDNSChecks() { dnsChecks.Store(nil) }
+
+// notFoundAsInvalid maps "no such host" to an invalid answer (nil error) and
+// keeps other errors, which are treated as the service being unavailable.
+func notFoundAsInvalid(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil
+	}
+	return err
+}
+
+// fqdnResolvable accepts fully qualified domain names that, with DNS checks
+// enabled and a context deadline, resolve to at least one address.
+func fqdnResolvable(ctx context.Context, fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(field.String())), ".")
+	if bakedIn.Var(host, "fqdn") != nil {
+		return false
+	}
+	st := dnsChecks.Load()
+	if st == nil || ctx == nil {
+		return true
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		return true
+	}
+	return st.resolvable.check(ctx, host)
+}
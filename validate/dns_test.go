@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeResolver struct {
+	calls atomic.Int32
+	hosts map[string][]string
+	err   error
+}
+
+func (r *fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	r.calls.Add(1)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestFQDNResolvable(t *testing.T) {
+	r := &fakeResolver{hosts: map[string][]string{"db.example.com": {"10.0.0.5"}}}
+	EnableDNSChecks(DNSOptions{Resolver: r})
+	defer DisableDNSChecks()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for i := 0; i < 2; i++ {
+		if err := VarCtx(ctx, "host", "DB.example.com.", "fqdn_resolvable"); err != nil {
+			t.Fatalf("expected resolvable host, got %v", err)
+		}
+	}
+	if r.calls.Load() != 1 {
+		t.Fatalf("expected cached lookups, got %d", r.calls.Load())
+	}
+	if m := ToFieldErrors(VarCtx(ctx, "host", "missing.example.com", "fqdn_resolvable")); m["host"] != "must be a resolvable host name" {
+		t.Fatalf("expected resolution failure, got %v", m)
+	}
+	if err := VarCtx(context.Background(), "host", "other.example.com", "fqdn_resolvable"); err != nil {
+		t.Fatalf("expected no lookup without a deadline, got %v", err)
+	}
+	if VarCtx(ctx, "host", "not a host", "fqdn_resolvable") == nil || VarCtx(ctx, "host", 1, "fqdn_resolvable") == nil {
+		t.Fatalf("expected syntax errors")
+	}
+}
+
+func TestFQDNResolvable_DisabledAndErrors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := VarCtx(ctx, "host", "anything.example.com", "fqdn_resolvable"); err != nil {
+		t.Fatalf("expected syntax-only check when disabled, got %v", err)
+	}
+	r := &fakeResolver{err: errors.New("timeout")}
+	EnableDNSChecks(DNSOptions{Resolver: r})
+	if err := VarCtx(ctx, "host", "a.example.com", "fqdn_resolvable"); err != nil {
+		t.Fatalf("expected fail-open on resolver errors, got %v", err)
+	}
+	EnableDNSChecks(DNSOptions{Resolver: r, FailClosed: true})
+	defer DisableDNSChecks()
+	if VarCtx(ctx, "host", "a.example.com", "fqdn_resolvable") == nil {
+		t.Fatalf("expected fail-closed on resolver errors")
+	}
+}
@@ -0,0 +1,153 @@
+package validate
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	mustRegister("goduration", isGoDuration, map[string]string{
+		DefaultMessageLocale: "must be a valid duration, e.g. 1h30m",
+		"es":                 "debe ser una duración válida, p. ej. 1h30m",
+		"pt":                 "deve ser uma duração válida, p. ex. 1h30m",
+		"fr":                 "doit être une durée valide, p. ex. 1h30m",
+		"de":                 "muss eine gültige Dauer sein, z. B. 1h30m",
+	})
+	mustRegister("iso8601_duration", isISO8601Duration, map[string]string{
+		DefaultMessageLocale: "must be an ISO 8601 duration, e.g. PT1H30M",
+		"es":                 "debe ser una duración ISO 8601, p. ej. PT1H30M",
+		"pt":                 "deve ser uma duração ISO 8601, p. ex. PT1H30M",
+		"fr":                 "doit être une durée ISO 8601, p. ex. PT1H30M",
+		"de":                 "muss eine ISO-8601-Dauer sein, z. B. PT1H30M",
+	})
+	mustRegister("duration_min", durationMin, map[string]string{
+		DefaultMessageLocale: "must be a duration of at least {param}",
+		"es":                 "debe ser una duración de al menos {param}",
+		"pt":                 "deve ser uma duração de pelo menos {param}",
+		"fr":                 "doit être une durée d'au moins {param}",
+		"de":                 "muss eine Dauer von mindestens {param} sein",
+	})
+	mustRegister("duration_max", durationMax, map[string]string{
+		DefaultMessageLocale: "must be a duration of at most {param}",
+		"es":                 "debe ser una duración de como máximo {param}",
+		"pt":                 "deve ser uma duração de no máximo {param}",
+		"fr":                 "doit être une durée d'au plus {param}",
+		"de":                 "muss eine Dauer von höchstens {param} sein",
+	})
+}
+
+// isGoDuration accepts strings in time.ParseDuration format.
+func isGoDuration(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	_, err := time.ParseDuration(fl.Field().String())
+	return err == nil
+}
+
+// isISO8601Duration accepts strings such as "P1DT12H" or "PT0.5S".
+func isISO8601Duration(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	_, ok := parseISO8601Duration(fl.Field().String())
+	return ok
+}
+
+// durationMin accepts string durations (Go or ISO 8601 format) of at least
+// the parameter, itself in either format: `duration_min=1m`.
+func durationMin(fl validator.FieldLevel) bool {
+	d, limit, ok := durationAndParam(fl)
+	return ok && d >= limit
+}
+
+// durationMax accepts string durations of at most the parameter:
+// `duration_max=24h`.
+func durationMax(fl validator.FieldLevel) bool {
+	d, limit, ok := durationAndParam(fl)
+	return ok && d <= limit
+}
+
+func durationAndParam(fl validator.FieldLevel) (d, limit time.Duration, ok bool) {
+	if fl.Field().Kind() != reflect.String {
+		return 0, 0, false
+	}
+	if d, ok = parseAnyDuration(fl.Field().String()); !ok {
+		return 0, 0, false
+	}
+	limit, ok = parseAnyDuration(fl.Param())
+	return d, limit, ok
+}
+
+// parseAnyDuration parses Go or ISO 8601 durations.
+func parseAnyDuration(s string) (time.Duration, bool) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, true
+	}
+	return parseISO8601Duration(s)
+}
+
+// iso8601Units are the designators of ISO 8601 durations in order, for the
+// date part and the time part. Years and months count as 365 and 30 days.
+var iso8601Units = [2][]struct {
+	unit byte
+	d    time.Duration
+}{
+	{{'Y', 365 * 24 * time.Hour}, {'M', 30 * 24 * time.Hour}, {'W', 7 * 24 * time.Hour}, {'D', 24 * time.Hour}},
+	{{'H', time.Hour}, {'M', time.Minute}, {'S', time.Second}},
+}
+
+// parseISO8601Duration parses PnYnMnWnDTnHnMnS durations with an optional
+// sign; only the last component may have a fraction.
+func parseISO8601Duration(s string) (time.Duration, bool) {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	if !strings.HasPrefix(s, "P") || len(s) < 2 {
+		return 0, false
+	}
+	date, clock, hasT := strings.Cut(s[1:], "T")
+	if hasT && clock == "" {
+		return 0, false
+	}
+	var total float64
+	fraction := false
+	for part, str := range [2]string{date, clock} {
+		units := iso8601Units[part]
+		next := 0
+		for str != "" {
+			if fraction {
+				return 0, false
+			}
+			i := strings.IndexFunc(str, func(r rune) bool { return (r < '0' || r > '9') && r != '.' && r != ',' })
+			if i <= 0 {
+				return 0, false
+			}
+			n, err := strconv.ParseFloat(strings.Replace(str[:i], ",", ".", 1), 64)
+			if err != nil {
+				return 0, false
+			}
+			fraction = n != math.Trunc(n)
+			for next < len(units) && units[next].unit != str[i] {
+				next++
+			}
+			if next == len(units) {
+				return 0, false
+			}
+			total += n * float64(units[next].d)
+			next++
+			str = str[i+1:]
+		}
+	}
+	if total > math.MaxInt64 {
+		return 0, false
+	}
+	if neg {
+		total = -total
+	}
+	return time.Duration(total), true
+}
@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseISO8601Duration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"PT1H30M":    90 * time.Minute,
+		"P1DT12H":    36 * time.Hour,
+		"P2W":        14 * 24 * time.Hour,
+		"PT0.5S":     500 * time.Millisecond,
+		"PT1,5M":     90 * time.Second,
+		"-PT10S":     -10 * time.Second,
+		"P1Y":        365 * 24 * time.Hour,
+		"P1M":        30 * 24 * time.Hour,
+		"P1Y2M3DT4H": (365+60+3)*24*time.Hour + 4*time.Hour,
+	}
+	for in, want := range valid {
+		if got, ok := parseISO8601Duration(in); !ok || got != want {
+			t.Fatalf("%s: expected %v, got %v (ok=%v)", in, want, got, ok)
+		}
+	}
+	for _, in := range []string{"", "P", "PT", "1H", "PT1H30", "P1H", "PT1M1H", "PT1.5M30S", "P1D2D", "PTxS"} {
+		if _, ok := parseISO8601Duration(in); ok {
+			t.Fatalf("%q: expected invalid", in)
+		}
+	}
+}
+
+func TestDurationTags(t *testing.T) {
+	cases := []struct {
+		value any
+		tag   string
+		ok    bool
+	}{
+		{"1h30m", "goduration", true},
+		{"90", "goduration", false},
+		{"PT1H", "iso8601_duration", true},
+		{"1h", "iso8601_duration", false},
+		{"12h", "duration_max=24h", true},
+		{"P2D", "duration_max=24h", false},
+		{"PT24H", "duration_max=P1D", true},
+		{"30s", "duration_min=1m", false},
+		{"PT5M", "duration_min=1m", true},
+		{"soon", "duration_max=24h", false},
+		{"1h", "duration_max=forever", false},
+		{time.Hour, "goduration", false},
+	}
+	for _, c := range cases {
+		if err := Var("ttl", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%v %s: expected ok=%v, got %v", c.value, c.tag, c.ok, err)
+		}
+	}
+}
+
+func TestDurationMessages(t *testing.T) {
+	if got := ToFieldErrors(Var("ttl", "48h", "duration_max=24h"))["ttl"]; got != "must be a duration of at most 24h" {
+		t.Fatalf("unexpected message %q", got)
+	}
+	ctx := WithLocale(context.Background(), "de")
+	if got := ToFieldErrorsWithContext(ctx, Var("ttl", "x", "iso8601_duration"))["ttl"]; got != "muss eine ISO-8601-Dauer sein, z. B. PT1H30M" {
+		t.Fatalf("unexpected localized message %q", got)
+	}
+}
@@ -0,0 +1,342 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Engine validates structs and single values. *validator.Validate (and so the
+// global Validator) satisfies it; NewNoop and validatetest.Mock provide test
+// doubles.
+type Engine interface {
+	Struct(s any) error
+	StructCtx(ctx context.Context, s any) error
+	Var(field any, tag string) error
+	VarCtx(ctx context.Context, field any, tag string) error
+}
+
+type engineHolder struct{ e Engine }
+
+var currentEngine atomic.Pointer[engineHolder]
+
+// SetEngine replaces the engine used by Struct, StructCtx, Var, VarCtx, Map
+// and the binding helpers. When e is a *DefaultEngine, its registered messages are
+// used when mapping errors. Passing nil restores the global engine.
+//
+// Example (handler unit test):
+//
+//	validate.SetEngine(validate.NewNoop())
+//	defer validate.SetEngine(nil)
+func SetEngine(e Engine) {
+	if e == nil {
+		currentEngine.Store(nil)
+		return
+	}
+	currentEngine.Store(&engineHolder{e: e})
+}
+
+// CurrentEngine returns the engine installed with SetEngine, or Default.
+func CurrentEngine() Engine {
+	if h := currentEngine.Load(); h != nil {
+		return h.e
+	}
+	return Default()
+}
+
+// Context key for storing a per-request engine.
+type ctxKeyEngine struct{}
+
+// WithEngine attaches an engine to a non-nil context and returns the derived
+// context. The context-aware helpers (StructCtx, VarCtx, Map, the binding
+// helpers, ...) validate with it, and ToFieldErrorsWithContext uses its
+// registered messages when it is a *DefaultEngine. Middleware uses it to swap
+// the whole engine per request, e.g. by API version or tenant.
+func WithEngine(ctx context.Context, e Engine) context.Context {
+	return context.WithValue(ctx, ctxKeyEngine{}, e)
+}
+
+// EngineFromContext retrieves an engine from context if present.
+func EngineFromContext(ctx context.Context) Engine {
+	if ctx == nil {
+		return nil
+	}
+	if e, ok := ctx.Value(ctxKeyEngine{}).(Engine); ok {
+		return e
+	}
+	return nil
+}
+
+// engineFor returns the engine from ctx, or the current engine.
+func engineFor(ctx context.Context) Engine {
+	if e := EngineFromContext(ctx); e != nil {
+		return e
+	}
+	return CurrentEngine()
+}
+
+// messageEngine returns the engine whose registered messages apply: the
+// engine for ctx when it is a *DefaultEngine, otherwise the global engine.
+func messageEngine(ctx context.Context) *DefaultEngine {
+	if e, ok := engineFor(ctx).(*DefaultEngine); ok {
+		return e
+	}
+	return Default()
+}
+
+// StructCtx validates a struct with the engine from ctx (see WithEngine) or the
+// current engine, passing ctx to context-aware validation functions. Tags
+// skipped in ctx (see SkipTagIf) are not reported; see WithCompleteErrors for
+// reporting the fields of missing nested structs.
+func StructCtx(ctx context.Context, s any) error {
+	e := engineFor(ctx)
+	err := e.StructCtx(ctx, s)
+	if err != nil && completeErrors(ctx) {
+		err = expandRequired(ctx, e, err)
+	}
+	return filterSkipped(ctx, err)
+}
+
+// noopEngine accepts every value.
+type noopEngine struct{}
+
+// NewNoop returns an Engine that never reports validation errors, for tests
+// that exercise handlers without constructing valid payloads.
+func NewNoop() Engine { return noopEngine{} }
+
+func (noopEngine) Struct(any) error                          { return nil }
+func (noopEngine) StructCtx(context.Context, any) error      { return nil }
+func (noopEngine) Var(any, string) error                     { return nil }
+func (noopEngine) VarCtx(context.Context, any, string) error { return nil }
+
+// DefaultEngine is the Engine backed by go-playground validator. Besides
+// validating, it owns the messages registered for its tags and records every
+// registration made through its methods, so Clone can build an independent
+// copy. Registrations made directly on the underlying *validator.Validate
+// (see Validate) are not recorded and are not carried over by Clone.
+//
+// The package-level Register* helpers operate on the default engine (see
+// Default), which wraps the global Validator unless SetDefault replaced it.
+type DefaultEngine struct {
+	v *validator.Validate
+
+	mu            sync.RWMutex
+	registrations []func(*validator.Validate) error
+	messages      map[string]map[string]string // tag -> lowercased locale -> message
+	messageFunc   func(validator.FieldError) string
+	tags          map[string]string            // custom tag -> alias expansion ("" for functions)
+	english       atomic.Pointer[englishTable] // set in English-only mode
+}
+
+// The default engine is chosen on first use (see Default), so SetDefault
+// can replace it before any registration happens. globalEngine wraps the
+// global Validator and receives the built-ins as the package initializes, so
+// Validator behaves the same whether or not Default has been called.
+var (
+	defaultOnce   sync.Once
+	defaultEngine atomic.Pointer[DefaultEngine]
+	globalEngine  = newDefaultEngine(Validator)
+)
+
+// ErrDefaultInitialized is returned by SetDefault once the default engine is
+// in use.
+var ErrDefaultInitialized = errors.New("validate: default engine already initialized")
+
+// builtins are the registrations every engine starts with (json tag names,
+// built-in tags and their messages).
+var builtins []func(e *DefaultEngine)
+
+func newDefaultEngine(v *validator.Validate) *DefaultEngine {
+	return &DefaultEngine{v: v, messages: map[string]map[string]string{}, tags: map[string]string{}}
+}
+
+// registerBuiltin records fn for every engine created with NewEngine and
+// applies it to the global Validator right away. Package init functions call
+// it, so the built-ins come before any registration of the application.
+func registerBuiltin(fn func(e *DefaultEngine)) {
+	builtins = append(builtins, fn)
+	fn(globalEngine)
+}
+
+// NewEngine returns an independent engine with the built-in configuration
+// (json tag names, built-in tags and messages) but none of the application's
+// registrations on the default engine.
+func NewEngine() *DefaultEngine {
+	e := newDefaultEngine(validator.New())
+	for _, fn := range builtins {
+		fn(e)
+	}
+	return e
+}
+
+// Default returns the default engine, the target of the package-level
+// Register* helpers and the engine Struct, Var and the binding helpers use
+// unless replaced with SetEngine or WithEngine. It is chosen on first use,
+// safely from any goroutine: the global Validator with the built-in
+// configuration, unless SetDefault provided another engine first.
+func Default() *DefaultEngine {
+	defaultOnce.Do(func() { defaultEngine.Store(globalEngine) })
+	return defaultEngine.Load()
+}
+
+// SetDefault makes e the default engine, e.g. a NewEngine configured by the
+// application, replacing the global Validator (which then refers to
+// e.Validate()). It must run before the first use of the default engine,
+// typically first thing in main; afterwards it returns ErrDefaultInitialized
+// and changes nothing. Importing this package configures the global
+// Validator but does not choose the default engine; registrations in init
+// functions of other packages do.
+func SetDefault(e *DefaultEngine) error {
+	if e == nil {
+		return errors.New("validate: SetDefault requires a non-nil engine")
+	}
+	installed := false
+	defaultOnce.Do(func() {
+		Validator = e.v
+		defaultEngine.Store(e)
+		installed = true
+	})
+	if !installed {
+		return ErrDefaultInitialized
+	}
+	return nil
+}
+
+// Global returns Default().
+func Global() *DefaultEngine { return Default() }
+
+// Validate returns the underlying validator.
+func (e *DefaultEngine) Validate() *validator.Validate { return e.v }
+
+// Struct validates a struct.
+func (e *DefaultEngine) Struct(s any) error { return e.v.Struct(s) }
+
+// StructCtx validates a struct, passing ctx to context-aware validations.
+func (e *DefaultEngine) StructCtx(ctx context.Context, s any) error { return e.v.StructCtx(ctx, s) }
+
+// Var validates a single value against tag.
+func (e *DefaultEngine) Var(field any, tag string) error { return e.v.Var(field, tag) }
+
+// VarCtx validates a single value, passing ctx to context-aware validations.
+func (e *DefaultEngine) VarCtx(ctx context.Context, field any, tag string) error {
+	return e.v.VarCtx(ctx, field, tag)
+}
+
+// Clone returns a deep copy of the engine: a new validator with every recorded
+// registration replayed, a copy of the registered messages and the same
+// message function (see SetMessageFunc). Changes to the clone do not affect e
+// and vice versa.
+func (e *DefaultEngine) Clone() *DefaultEngine {
+	e.mu.RLock()
+	regs := append([]func(*validator.Validate) error(nil), e.registrations...)
+	msgs := make(map[string]map[string]string, len(e.messages))
+	for tag, m := range e.messages {
+		cp := make(map[string]string, len(m))
+		for k, v := range m {
+			cp[k] = v
+		}
+		msgs[tag] = cp
+	}
+	tags := make(map[string]string, len(e.tags))
+	for tag, alias := range e.tags {
+		tags[tag] = alias
+	}
+	msgFunc := e.messageFunc
+	e.mu.RUnlock()
+
+	c := newDefaultEngine(validator.New())
+	for _, reg := range regs {
+		// Registrations succeeded once with the same input.
+		_ = reg(c.v)
+	}
+	c.registrations = regs
+	c.messages = msgs
+	c.tags = tags
+	c.messageFunc = msgFunc
+	if e.EnglishOnly() {
+		c.english.Store(c.buildEnglish())
+	}
+	return c
+}
+
+// register applies fn to the validator and records it for Clone on success.
+func (e *DefaultEngine) register(fn func(*validator.Validate) error) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := fn(e.v); err != nil {
+		return err
+	}
+	e.registrations = append(e.registrations, fn)
+	return nil
+}
+
+// RegisterValidation registers a validation function for tag. The tag honors
+// SkipTagIf.
+func (e *DefaultEngine) RegisterValidation(tag string, fn validator.Func, callValidationEvenIfNull ...bool) error {
+	return e.RegisterValidationCtx(tag, func(_ context.Context, fl validator.FieldLevel) bool { return fn(fl) }, callValidationEvenIfNull...)
+}
+
+// RegisterValidationCtx registers a context-aware validation function for tag.
+// The tag honors SkipTagIf.
+func (e *DefaultEngine) RegisterValidationCtx(tag string, fn validator.FuncCtx, callValidationEvenIfNull ...bool) error {
+	err := e.register(func(v *validator.Validate) error {
+		return v.RegisterValidationCtx(tag, skippable(tag, fn), callValidationEvenIfNull...)
+	})
+	if err == nil {
+		e.recordTag(tag, "")
+	}
+	return err
+}
+
+// recordTag records a custom tag for RegisteredTags.
+func (e *DefaultEngine) recordTag(tag, alias string) {
+	e.mu.Lock()
+	e.tags[tag] = alias
+	e.mu.Unlock()
+}
+
+// RegisterTagNameFunc sets the function used to name fields in errors.
+func (e *DefaultEngine) RegisterTagNameFunc(fn validator.TagNameFunc) {
+	_ = e.register(func(v *validator.Validate) error {
+		v.RegisterTagNameFunc(fn)
+		return nil
+	})
+}
+
+// RegisterStructValidation registers a struct-level validation for types.
+func (e *DefaultEngine) RegisterStructValidation(fn validator.StructLevelFunc, types ...any) {
+	_ = e.register(func(v *validator.Validate) error {
+		v.RegisterStructValidation(fn, types...)
+		return nil
+	})
+}
+
+// RegisterStructValidationCtx registers a context-aware struct-level
+// validation for types.
+func (e *DefaultEngine) RegisterStructValidationCtx(fn validator.StructLevelFuncCtx, types ...any) {
+	_ = e.register(func(v *validator.Validate) error {
+		v.RegisterStructValidationCtx(fn, types...)
+		return nil
+	})
+}
+
+// RegisterCustomTypeFunc registers a function that extracts the value to
+// validate from types.
+func (e *DefaultEngine) RegisterCustomTypeFunc(fn validator.CustomTypeFunc, types ...any) {
+	_ = e.register(func(v *validator.Validate) error {
+		v.RegisterCustomTypeFunc(fn, types...)
+		return nil
+	})
+}
+
+// RegisterStructValidationMapRules sets field rules for types, keyed by Go
+// field name, overriding their `validate` tags.
+func (e *DefaultEngine) RegisterStructValidationMapRules(rules map[string]string, types ...any) {
+	_ = e.register(func(v *validator.Validate) error {
+		v.RegisterStructValidationMapRules(rules, types...)
+		return nil
+	})
+}
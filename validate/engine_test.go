@@ -0,0 +1,208 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type engineUser struct {
+	Name string `json:"name" validate:"required"`
+}
+
+func TestSetEngine_Noop(t *testing.T) {
+	if Struct(engineUser{}) == nil {
+		t.Fatalf("expected default engine to report errors")
+	}
+	SetEngine(NewNoop())
+	defer SetEngine(nil)
+
+	ctx := context.Background()
+	if Struct(engineUser{}) != nil || StructCtx(ctx, engineUser{}) != nil || Var("name", "", "required") != nil || VarCtx(ctx, "name", "", "required") != nil {
+		t.Fatalf("expected noop engine to accept everything")
+	}
+	SetEngine(nil)
+	if CurrentEngine() != Global() || Var("name", "", "required") == nil {
+		t.Fatalf("expected global engine after reset")
+	}
+}
+
+type cloneReq struct {
+	Code  string `json:"code" validate:"clone_code"`
+	Label string `json:"label" validate:"omitempty,clone_label"`
+}
+
+func TestDefaultEngine_CloneIsIndependent(t *testing.T) {
+	base := NewEngine()
+	if err := base.RegisterValidationWithMessage("clone_code", func(fl validator.FieldLevel) bool {
+		return fl.Field().String() == "ok"
+	}, map[string]string{"en": "must be ok"}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	base.RegisterAlias("clone_label", "min=3")
+	base.SetMessageFunc(func(fe validator.FieldError) string { return "base: " + fe.Tag() })
+
+	clone := base.Clone()
+	clone.RegisterMessages("clone_code", map[string]string{"en": "must be OK (clone)"})
+	if err := clone.RegisterValidation("clone_code", func(validator.FieldLevel) bool { return true }); err != nil {
+		t.Fatalf("register on clone: %v", err)
+	}
+
+	SetEngine(base)
+	fe := ToFieldErrors(Struct(cloneReq{Code: "no", Label: "ab"}))
+	if fe["code"] != "must be ok" || fe["label"] != "must be at least 3" {
+		t.Fatalf("unexpected base errors: %v", fe)
+	}
+	SetEngine(clone)
+	fe = ToFieldErrors(Struct(cloneReq{Code: "no", Label: "ab"}))
+	if len(fe) != 1 || fe["label"] != "must be at least 3" {
+		t.Fatalf("unexpected clone errors: %v", fe)
+	}
+	SetEngine(nil)
+
+	ctx := WithEngine(context.Background(), clone)
+	if fe := ToFieldErrorsWithContext(ctx, clone.Var("x", "email")); fe[""] != "base: email" {
+		t.Fatalf("expected the message func on the clone, got %v", fe)
+	}
+	clone.SetMessageFunc(nil)
+	if base.fallbackMessage(base.Var("x", "email").(validator.ValidationErrors)[0]) != "base: email" {
+		t.Fatalf("expected clearing the clone's message func to leave base alone")
+	}
+
+	// A fresh engine has the builtins.
+	if NewEngine().Var("1.5", "decimal_places=0") == nil {
+		t.Fatalf("expected builtin tags on new engines")
+	}
+}
+
+func TestGlobal_CloneCarriesPackageRegistrations(t *testing.T) {
+	RegisterAlias("clone_global", "len=2")
+	c := Global().Clone()
+	if err := c.Var("abc", "clone_global"); err == nil {
+		t.Fatalf("expected alias on the clone")
+	}
+	if fe := c.Struct(engineUser{}).(validator.ValidationErrors)[0]; fe.Field() != "name" {
+		t.Fatalf("expected json tag names on the clone, got %q", fe.Field())
+	}
+}
+
+func TestWithEngine_ContextEngineAndMessages(t *testing.T) {
+	e := NewEngine()
+	e.RegisterMessages("required", map[string]string{"en": "can't be blank"})
+	ctx := WithEngine(context.Background(), e)
+	if EngineFromContext(ctx) != e || EngineFromContext(context.Background()) != nil {
+		t.Fatalf("unexpected context engine")
+	}
+
+	fe := ToFieldErrorsWithContext(ctx, StructCtx(ctx, engineUser{}))
+	if fe["name"] != "can't be blank" {
+		t.Fatalf("expected context engine messages, got %v", fe)
+	}
+	if fe := ToFieldErrors(Struct(engineUser{})); fe["name"] != "is required" {
+		t.Fatalf("expected global messages without context, got %v", fe)
+	}
+
+	noop := WithEngine(context.Background(), NewNoop())
+	if StructCtx(noop, engineUser{}) != nil || VarCtx(noop, "name", "", "required") != nil {
+		t.Fatalf("expected context engine to validate")
+	}
+}
+
+// resetDefault simulates a process that has initialized the package but not
+// used the default engine yet: a fresh global Validator with the built-ins.
+// It returns a func restoring the current state.
+func resetDefault() (restore func()) {
+	prev, prevGlobal, prevValidator := Default(), globalEngine, Validator
+	Validator = validator.New()
+	globalEngine = newDefaultEngine(Validator)
+	for _, fn := range builtins {
+		fn(globalEngine)
+	}
+	defaultOnce = sync.Once{}
+	defaultEngine.Store(nil)
+	return func() {
+		defaultOnce = sync.Once{}
+		defaultOnce.Do(func() {})
+		defaultEngine.Store(prev)
+		globalEngine, Validator = prevGlobal, prevValidator
+	}
+}
+
+func TestValidator_BuiltinsBeforeDefault(t *testing.T) {
+	restore := resetDefault()
+	defer restore()
+	var vErrs validator.ValidationErrors
+	if !errors.As(Validator.Struct(engineUser{}), &vErrs) || vErrs[0].Field() != "name" {
+		t.Fatalf("expected json tag names before Default is used, got %v", vErrs)
+	}
+	if defaultEngine.Load() != nil {
+		t.Fatalf("expected the default engine not to be chosen yet")
+	}
+}
+
+func TestValidator_TagNameFuncBeforeDefault(t *testing.T) {
+	restore := resetDefault()
+	defer restore()
+	Validator.RegisterTagNameFunc(func(sf reflect.StructField) string { return "x_" + sf.Name })
+	if Default().Validate() != Validator {
+		t.Fatalf("expected the global Validator as default engine")
+	}
+	if fe := ToFieldErrors(Struct(engineUser{})); fe["x_Name"] != "is required" {
+		t.Fatalf("expected the application's tag name func to be kept, got %v", fe)
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	if err := SetDefault(NewEngine()); !errors.Is(err, ErrDefaultInitialized) {
+		t.Fatalf("expected ErrDefaultInitialized once in use, got %v", err)
+	}
+
+	restore := resetDefault()
+	defer restore()
+	e := NewEngine()
+	if err := SetDefault(e); err != nil {
+		t.Fatal(err)
+	}
+	if Default() != e || Global() != e || CurrentEngine() != e || Validator != e.Validate() {
+		t.Fatalf("expected e to replace the default engine and Validator")
+	}
+	if err := RegisterValidationWithMessage("only_default", func(validator.FieldLevel) bool { return false }, nil); err != nil {
+		t.Fatal(err)
+	}
+	if e.Var("x", "only_default") == nil {
+		t.Fatalf("expected package-level registrations on the replaced default")
+	}
+	if err := SetDefault(NewEngine()); !errors.Is(err, ErrDefaultInitialized) {
+		t.Fatalf("expected second SetDefault to fail, got %v", err)
+	}
+	if SetDefault(nil) == nil {
+		t.Fatalf("expected nil engine to be rejected")
+	}
+}
+
+func TestDefault_Concurrent(t *testing.T) {
+	restore := resetDefault()
+	defer restore()
+	engines := make([]*DefaultEngine, 8)
+	var wg sync.WaitGroup
+	for i := range engines {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			engines[i] = Default()
+		}(i)
+	}
+	wg.Wait()
+	for _, e := range engines {
+		if e == nil || e != engines[0] {
+			t.Fatalf("expected one default engine, got %v", engines)
+		}
+	}
+	if engines[0].Var("Ab", "alpha,len=2") != nil || Struct(engineUser{}) == nil {
+		t.Fatalf("expected the built-in configuration on the default engine")
+	}
+}
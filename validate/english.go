@@ -0,0 +1,105 @@
+package validate
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// englishTable is the precomputed message table of an English-only engine.
+type englishTable struct {
+	byTag   map[string]englishMessage            // tag -> message
+	byParam map[string]map[string]englishMessage // tag -> param -> message ("tag=param" registrations)
+}
+
+// englishMessage is a message template with its placeholders classified
+// up front, so static messages are returned as is.
+type englishMessage struct {
+	msg       string
+	static    bool // no placeholders
+	paramOnly bool // {param} is the only placeholder
+}
+
+func newEnglishMessage(msg string) englishMessage {
+	n := strings.Count(msg, "{")
+	return englishMessage{msg: msg, static: n == 0, paramOnly: n > 0 && n == strings.Count(msg, "{param}")}
+}
+
+// SetEnglishOnly switches e to a fast path for services that do not need
+// i18n. Validation errors resolved with e are rendered from a table of its
+// default (DefaultMessageLocale) registered messages and the built-in ones,
+// precomputed when the mode is enabled and on each RegisterMessages call.
+// The locale, request labels (WithFieldLabels), message functions
+// (SetMessageFunc, WithMessageFunc, ToFieldErrorsWith) and translators
+// (WithTranslator) are never looked up.
+//
+//	validate.Global().SetEnglishOnly(true)
+//
+// Messages equal those of a request without a locale or message function.
+// Clone carries the mode over.
+func (e *DefaultEngine) SetEnglishOnly(on bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !on {
+		e.english.Store(nil)
+		return
+	}
+	e.english.Store(e.buildEnglish())
+}
+
+// EnglishOnly reports whether e is in English-only mode.
+func (e *DefaultEngine) EnglishOnly() bool { return e.english.Load() != nil }
+
+// buildEnglish computes the message table of e. e.mu must be held.
+func (e *DefaultEngine) buildEnglish() *englishTable {
+	t := &englishTable{
+		byTag:   make(map[string]englishMessage, len(defaultMessages)+len(e.messages)),
+		byParam: map[string]map[string]englishMessage{},
+	}
+	for tag, msg := range defaultMessages {
+		t.byTag[tag] = newEnglishMessage(msg)
+	}
+	for key, m := range e.messages {
+		msg := m[DefaultMessageLocale]
+		if msg == "" {
+			continue
+		}
+		tag, param, ok := strings.Cut(key, "=")
+		if !ok {
+			t.byTag[tag] = newEnglishMessage(msg)
+			continue
+		}
+		if t.byParam[tag] == nil {
+			t.byParam[tag] = map[string]englishMessage{}
+		}
+		t.byParam[tag][param] = newEnglishMessage(msg)
+	}
+	return t
+}
+
+// message renders fe from the table, like localizedMessage without a locale
+// or message function.
+func (t *englishTable) message(fe validator.FieldError) string {
+	tag, param := fe.Tag(), fe.Param()
+	if tag == StructRuleTag {
+		return param
+	}
+	m, ok := t.byParam[tag][param]
+	if !ok {
+		m, ok = t.byTag[tag]
+	}
+	switch {
+	case !ok:
+		return defaultMessageWith(tag, param, defaultLabel)
+	case m.static:
+		return m.msg
+	case m.paramOnly:
+		return strings.ReplaceAll(m.msg, "{param}", param)
+	}
+	msg := m.msg
+	if strings.Contains(msg, "{label}") {
+		other, _, _ := strings.Cut(param, " ")
+		msg = strings.ReplaceAll(msg, "{label}", defaultLabel(other))
+	}
+	return expandMessage(msg, fe)
+}
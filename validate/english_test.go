@@ -0,0 +1,73 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type englishSignup struct {
+	Name  string `json:"name" validate:"required,min=2"`
+	Email string `json:"email" validate:"required,email"`
+	Code  string `json:"code" validate:"sku"`
+	Size  string `json:"size" validate:"oneof=s m"`
+	Plan  string `json:"plan" validate:"required_if=Size xl"`
+}
+
+func TestDefaultEngine_SetEnglishOnly(t *testing.T) {
+	e := NewEngine()
+	if err := e.RegisterValidation("sku", func(validator.FieldLevel) bool { return false }); err != nil {
+		t.Fatal(err)
+	}
+	e.RegisterMessages("sku", map[string]string{"en": "{field} must be a valid SKU", "es": "SKU inválido"})
+	in := englishSignup{Name: "A", Email: "x", Size: "xl"}
+	err := e.Struct(in)
+	plain := ToFieldErrorsWithContext(WithEngine(context.Background(), e), err)
+
+	e.SetEnglishOnly(true)
+	if !e.EnglishOnly() {
+		t.Fatalf("expected English-only mode")
+	}
+	ctx := WithLocale(WithEngine(context.Background(), e), "es")
+	ctx = WithMessageFunc(ctx, func(validator.FieldError) string { return "translated" })
+	got := ToFieldErrorsWithContext(ctx, err)
+	if !reflect.DeepEqual(got, plain) || got["code"] != "code must be a valid SKU" {
+		t.Fatalf("expected messages without locale or message func %v, got %v", plain, got)
+	}
+
+	e.RegisterMessages("oneof=s m", map[string]string{"en": "must be small or medium"})
+	if got := ToFieldErrorsWithContext(ctx, err); got["size"] != "must be small or medium" {
+		t.Fatalf("expected table rebuilt on RegisterMessages, got %v", got)
+	}
+	if got := ToFieldErrorsWithContext(WithEngine(context.Background(), e.Clone()), err); got["size"] != "must be small or medium" {
+		t.Fatalf("expected Clone to keep English-only mode, got %v", got)
+	}
+
+	e.SetEnglishOnly(false)
+	if got := ToFieldErrorsWithContext(ctx, err); got["name"] != "translated" {
+		t.Fatalf("expected message funcs once disabled, got %v", got)
+	}
+}
+
+func benchmarkEnglish(b *testing.B, englishOnly bool) {
+	e := NewEngine()
+	e.SetEnglishOnly(englishOnly)
+	err := e.Struct(signupBench{})
+	ctx := WithEngine(context.Background(), e)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ToFieldErrorsWithContext(ctx, err)
+	}
+}
+
+type signupBench struct {
+	Name  string `json:"name" validate:"required,min=2"`
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age" validate:"gte=18"`
+}
+
+func BenchmarkToFieldErrors_Localized(b *testing.B)   { benchmarkEnglish(b, false) }
+func BenchmarkToFieldErrors_EnglishOnly(b *testing.B) { benchmarkEnglish(b, true) }
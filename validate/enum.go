@@ -0,0 +1,105 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// enumDef is a registered enum: its allowed values keyed by their underlying
+// string, signed or unsigned value, and their labels in registration order.
+type enumDef struct {
+	values map[any]bool
+	labels []string
+}
+
+var (
+	enumsMu sync.RWMutex
+	enums   = map[string]enumDef{}
+)
+
+func init() {
+	mustRegister("enum", isEnum, map[string]string{DefaultMessageLocale: "must be one of {values}"})
+}
+
+// RegisterEnum registers the allowed values of a Go enum type under name, for
+// use with the `enum=<name>` tag:
+//
+//	validate.RegisterEnum("status", StatusActive, StatusPaused)
+//
+//	type Account struct {
+//		Status Status `json:"status" validate:"required,enum=status"`
+//	}
+//
+// Without values, T's Values() []T method supplies them, so validation stays
+// in sync with the enum. The error message lists the allowed values using
+// their String method if T implements fmt.Stringer ({values} in registered
+// messages). T must have a string or integer underlying type. Registering a
+// name again replaces it.
+func RegisterEnum[T comparable](name string, values ...T) {
+	if len(values) == 0 {
+		var zero T
+		if v, ok := any(zero).(interface{ Values() []T }); ok {
+			values = v.Values()
+		}
+	}
+	if len(values) == 0 {
+		panic(fmt.Sprintf("validate: RegisterEnum(%q): no values", name))
+	}
+	def := enumDef{values: make(map[any]bool, len(values))}
+	for _, v := range values {
+		key, ok := enumKey(reflect.ValueOf(v))
+		if !ok {
+			panic(fmt.Sprintf("validate: RegisterEnum(%q): unsupported type %T", name, v))
+		}
+		def.values[key] = true
+		if s, ok := any(v).(fmt.Stringer); ok {
+			def.labels = append(def.labels, s.String())
+		} else {
+			def.labels = append(def.labels, fmt.Sprint(v))
+		}
+	}
+	enumsMu.Lock()
+	enums[name] = def
+	enumsMu.Unlock()
+}
+
+// EnumValues returns the labels of the enum registered under name, or nil.
+func EnumValues(name string) []string {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	return append([]string(nil), enums[name].labels...)
+}
+
+func isEnum(fl validator.FieldLevel) bool {
+	key, ok := enumKey(fl.Field())
+	if !ok {
+		return false
+	}
+	enumsMu.RLock()
+	def, ok := enums[fl.Param()]
+	enumsMu.RUnlock()
+	return ok && def.values[key]
+}
+
+// enumKey normalizes v to a string, int64 or uint64 so enum types and their
+// underlying types compare equal.
+func enumKey(v reflect.Value) (any, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), true
+	}
+	return nil, false
+}
+
+// enumLabels joins the labels of the enum registered under name.
+func enumLabels(name string) string {
+	return strings.Join(EnumValues(name), ", ")
+}
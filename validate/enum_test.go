@@ -0,0 +1,68 @@
+package validate
+
+import "testing"
+
+type enumStatus string
+
+const (
+	enumActive enumStatus = "active"
+	enumPaused enumStatus = "paused"
+)
+
+type enumLevel int
+
+func (l enumLevel) String() string { return [...]string{"low", "high"}[l] }
+
+func (enumLevel) Values() []enumLevel { return []enumLevel{0, 1} }
+
+type enumAccount struct {
+	Status enumStatus  `json:"status" validate:"required,enum=test_status"`
+	Level  enumLevel   `json:"level" validate:"enum=test_level"`
+	Raw    string      `json:"raw" validate:"omitempty,enum=test_status"`
+	Ptr    *enumStatus `json:"ptr" validate:"omitempty,enum=test_status"`
+}
+
+func TestRegisterEnum(t *testing.T) {
+	RegisterEnum("test_status", enumActive, enumPaused)
+	RegisterEnum[enumLevel]("test_level")
+
+	paused := enumPaused
+	if err := Struct(enumAccount{Status: enumActive, Level: 1, Raw: "paused", Ptr: &paused}); err != nil {
+		t.Fatalf("unexpected error: %v", ToFieldErrors(err))
+	}
+	bad := enumStatus("gone")
+	m := ToFieldErrors(Struct(enumAccount{Status: "deleted", Level: 2, Raw: "x", Ptr: &bad}))
+	want := map[string]string{
+		"status": "must be one of active, paused",
+		"level":  "must be one of low, high",
+		"raw":    "must be one of active, paused",
+		"ptr":    "must be one of active, paused",
+	}
+	for k, msg := range want {
+		if m[k] != msg {
+			t.Fatalf("field %q: expected %q, got %q (all: %v)", k, msg, m[k], m)
+		}
+	}
+	if got := EnumValues("test_level"); len(got) != 2 || got[1] != "high" {
+		t.Fatalf("unexpected values: %v", got)
+	}
+	if Var("x", "active", "enum=unknown") == nil || Var("x", 1.5, "enum=test_level") == nil {
+		t.Fatalf("expected unknown enum and unsupported kinds to fail")
+	}
+}
+
+func TestRegisterEnum_Panics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"no values":   func() { RegisterEnum[enumStatus]("x") },
+		"unsupported": func() { RegisterEnum("x", 1.5) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s: expected panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// The messages name the problem through {invalid}.
+	mustRegister("safe_filename", isSafeFilename, map[string]string{DefaultMessageLocale: "is not a safe file name: {invalid}"})
+	mustRegister("safe_relpath", isSafeRelPath, map[string]string{DefaultMessageLocale: "is not a safe relative path: {invalid}"})
+}
+
+// isSafeFilename accepts a single path element that is safe to create on
+// common file systems: no separators, "." or "..", control characters,
+// reserved Windows device names or trailing dots and spaces.
+func isSafeFilename(fl validator.FieldLevel) bool {
+	return fl.Field().Kind() == reflect.String && unsafeFilename(fl.Field().String()) == ""
+}
+
+// isSafeRelPath accepts relative paths, such as storage keys, whose elements
+// are all safe file names; "/" and "\" both separate elements.
+func isSafeRelPath(fl validator.FieldLevel) bool {
+	return fl.Field().Kind() == reflect.String && unsafeRelPath(fl.Field().String()) == ""
+}
+
+// unsafeFilename returns why name is not a safe file name, or "".
+func unsafeFilename(name string) string {
+	switch {
+	case name == "":
+		return ""
+	case len(name) > 255:
+		return "longer than 255 bytes"
+	case strings.ContainsAny(name, "/\\"):
+		return "contains a path separator"
+	}
+	return unsafeElement(name)
+}
+
+// unsafeRelPath returns why p is not a safe relative path, or "".
+func unsafeRelPath(p string) string {
+	switch {
+	case p == "":
+		return ""
+	case len(p) > 4096:
+		return "longer than 4096 bytes"
+	case p[0] == '/' || p[0] == '\\' || len(p) >= 2 && p[1] == ':' && isASCIILetter(p[0]):
+		return "is an absolute path"
+	}
+	for _, elem := range strings.FieldsFunc(p, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if why := unsafeElement(elem); why != "" {
+			return why
+		}
+	}
+	return ""
+}
+
+// unsafeElement checks a single path element.
+func unsafeElement(name string) string {
+	if name == "." || name == ".." {
+		return "contains '" + name + "'"
+	}
+	for i := 0; i < len(name); i++ {
+		switch {
+		case name[i] == 0:
+			return "contains a NUL byte"
+		case name[i] < ' ' || name[i] == 0x7f:
+			return "contains control characters"
+		}
+	}
+	if strings.HasSuffix(name, ".") || strings.HasSuffix(name, " ") {
+		return "ends with a dot or space"
+	}
+	base, _, _ := strings.Cut(name, ".")
+	if reservedFilenames[strings.ToUpper(strings.TrimSpace(base))] {
+		return "is a reserved name"
+	}
+	return ""
+}
+
+// reservedFilenames are Windows device names, reserved with any extension.
+var reservedFilenames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
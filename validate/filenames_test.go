@@ -0,0 +1,61 @@
+package validate
+
+import "testing"
+
+func TestSafeFilename(t *testing.T) {
+	cases := map[string]string{
+		"report.pdf":              "",
+		".env":                    "",
+		"":                        "",
+		"../etc/passwd":           "contains a path separator",
+		`a\b.txt`:                 "contains a path separator",
+		"..":                      "contains '..'",
+		"a\x00.txt":               "contains a NUL byte",
+		"a\nb":                    "contains control characters",
+		"CON":                     "is a reserved name",
+		"lpt1.txt":                "is a reserved name",
+		"name.":                   "ends with a dot or space",
+		string(make([]byte, 256)): "longer than 255 bytes",
+	}
+	for in, want := range cases {
+		err := Var("name", in, "safe_filename")
+		if want == "" {
+			if err != nil {
+				t.Fatalf("%q: expected valid, got %v", in, err)
+			}
+			continue
+		}
+		if got := ToFieldErrors(err)["name"]; got != "is not a safe file name: "+want {
+			t.Fatalf("%q: expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestSafeRelPath(t *testing.T) {
+	cases := map[string]string{
+		"uploads/2024/report.pdf": "",
+		`docs\a.txt`:              "",
+		"/etc/passwd":             "is an absolute path",
+		`\\server\share`:          "is an absolute path",
+		"C:/Windows":              "is an absolute path",
+		"a/../../b":               "contains '..'",
+		`a\..\b`:                  "contains '..'",
+		"a/nul/b":                 "is a reserved name",
+		"a/b\x00":                 "contains a NUL byte",
+	}
+	for in, want := range cases {
+		err := Var("key", in, "safe_relpath")
+		if want == "" {
+			if err != nil {
+				t.Fatalf("%q: expected valid, got %v", in, err)
+			}
+			continue
+		}
+		if got := ToFieldErrors(err)["key"]; got != "is not a safe relative path: "+want {
+			t.Fatalf("%q: expected %q, got %q", in, want, got)
+		}
+	}
+	if Var("key", 1, "safe_relpath") == nil || Var("name", 1, "safe_filename") == nil {
+		t.Fatalf("expected non-strings to fail")
+	}
+}
@@ -0,0 +1,228 @@
+package validate
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Violations are reported under sub-paths of the field (see
+	// geoJSONFieldErrors); this message covers values that are not JSON
+	// objects.
+	mustRegister("geojson", isGeoJSON, map[string]string{DefaultMessageLocale: "must be a valid GeoJSON object"})
+}
+
+// geometryTypes are the GeoJSON geometry types and their coordinate depth:
+// 0 for a position, 1 for a list of positions and so on.
+var geometryTypes = map[string]int{
+	"Point": 0, "MultiPoint": 1, "LineString": 1, "MultiLineString": 2,
+	"Polygon": 2, "MultiPolygon": 3,
+}
+
+const geoJSONTypes = "Point, MultiPoint, LineString, MultiLineString, Polygon, MultiPolygon, GeometryCollection, Feature, FeatureCollection"
+
+// ValidateGeoJSON validates a GeoJSON geometry, Feature or FeatureCollection
+// (RFC 7946): object types, coordinate arity, linear ring closure and
+// longitude/latitude bounds. It returns nil or FieldErrors keyed by paths
+// inside the document, e.g. {"geometry.coordinates.0.1": "must be a valid
+// latitude"}; "_error" reports documents that are not JSON objects.
+func ValidateGeoJSON(doc []byte) error {
+	var v any
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return FieldErrors{"_error": "must be a valid GeoJSON object"}
+	}
+	if out := geoJSONViolations(v); len(out) > 0 {
+		if msg, ok := out[""]; ok {
+			delete(out, "")
+			out["_error"] = msg
+		}
+		return FieldErrors(out)
+	}
+	return nil
+}
+
+// isGeoJSON accepts string, []byte or json.RawMessage documents and decoded
+// objects (map[string]any) that are valid GeoJSON.
+func isGeoJSON(fl validator.FieldLevel) bool {
+	v, ok := geoJSONValue(fl.Field())
+	return ok && len(geoJSONViolations(v)) == 0
+}
+
+// geoJSONFieldErrors expands a geojson failure into messages keyed by the
+// field joined with paths inside the document.
+func geoJSONFieldErrors(field string, fe validator.FieldError) (map[string]string, bool) {
+	v, ok := geoJSONValue(reflect.ValueOf(fe.Value()))
+	if !ok {
+		return nil, false
+	}
+	violations := geoJSONViolations(v)
+	if len(violations) == 0 {
+		return nil, false
+	}
+	return prefixPaths(field, violations), true
+}
+
+// geoJSONValue returns the decoded document held by a field.
+func geoJSONValue(field reflect.Value) (any, bool) {
+	if !field.IsValid() || !field.CanInterface() {
+		return nil, false
+	}
+	var doc []byte
+	switch v := field.Interface().(type) {
+	case map[string]any:
+		return v, true
+	case string:
+		doc = []byte(v)
+	case []byte:
+		doc = v
+	case json.RawMessage:
+		doc = v
+	default:
+		return nil, false
+	}
+	var v any
+	if err := json.Unmarshal(doc, &v); err != nil {
+		return nil, false
+	}
+	if _, ok := v.(map[string]any); !ok {
+		return nil, false
+	}
+	return v, true
+}
+
+// geoJSONViolations validates a decoded GeoJSON object, keyed by path ("" for
+// the root).
+func geoJSONViolations(v any) map[string]string {
+	out := map[string]string{}
+	checkGeoJSON(v, "", out, true)
+	return out
+}
+
+// checkGeoJSON validates any GeoJSON object at path; features reports whether
+// Feature and FeatureCollection are allowed there.
+func checkGeoJSON(v any, path string, out map[string]string, features bool) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		out[path] = "must be a GeoJSON object"
+		return
+	}
+	typ, ok := obj["type"].(string)
+	if !ok {
+		out[joinPath(path, "type")] = "is required"
+		return
+	}
+	switch typ {
+	case "Feature":
+		if !features {
+			break
+		}
+		geometry, ok := obj["geometry"]
+		if !ok {
+			out[joinPath(path, "geometry")] = "is required"
+		} else if geometry != nil {
+			checkGeoJSON(geometry, joinPath(path, "geometry"), out, false)
+		}
+		if p, ok := obj["properties"]; ok && p != nil {
+			if _, isObj := p.(map[string]any); !isObj {
+				out[joinPath(path, "properties")] = "must be an object"
+			}
+		}
+		return
+	case "FeatureCollection":
+		if !features {
+			break
+		}
+		list, ok := obj["features"].([]any)
+		if !ok {
+			out[joinPath(path, "features")] = "must be an array"
+			return
+		}
+		for i, f := range list {
+			p := joinPath(path, "features."+strconv.Itoa(i))
+			if fo, ok := f.(map[string]any); !ok || fo["type"] != "Feature" {
+				out[p] = "must be a Feature"
+				continue
+			}
+			checkGeoJSON(f, p, out, true)
+		}
+		return
+	case "GeometryCollection":
+		list, ok := obj["geometries"].([]any)
+		if !ok {
+			out[joinPath(path, "geometries")] = "must be an array"
+			return
+		}
+		for i, g := range list {
+			checkGeoJSON(g, joinPath(path, "geometries."+strconv.Itoa(i)), out, false)
+		}
+		return
+	default:
+		if depth, ok := geometryTypes[typ]; ok {
+			coords, ok := obj["coordinates"]
+			if !ok {
+				out[joinPath(path, "coordinates")] = "is required"
+				return
+			}
+			checkCoordinates(typ, coords, depth, joinPath(path, "coordinates"), out)
+			return
+		}
+	}
+	out[joinPath(path, "type")] = "must be one of " + geoJSONTypes
+}
+
+// checkCoordinates validates nested coordinate arrays of the given depth.
+// Polygon rings are the lists of positions at depth 1 below a Polygon or
+// MultiPolygon.
+func checkCoordinates(typ string, v any, depth int, path string, out map[string]string) {
+	if depth == 0 {
+		checkPosition(v, path, out)
+		return
+	}
+	list, ok := v.([]any)
+	if !ok {
+		out[path] = "must be an array"
+		return
+	}
+	for i, item := range list {
+		checkCoordinates(typ, item, depth-1, joinPath(path, strconv.Itoa(i)), out)
+	}
+	if depth != 1 {
+		return
+	}
+	switch typ {
+	case "LineString", "MultiLineString":
+		if len(list) < 2 {
+			out[path] = "must contain at least 2 positions"
+		}
+	case "Polygon", "MultiPolygon":
+		if len(list) < 4 {
+			out[path] = "must contain at least 4 positions"
+		} else if first, last := list[0], list[len(list)-1]; !reflect.DeepEqual(first, last) {
+			out[path] = "linear ring must be closed"
+		}
+	}
+}
+
+// checkPosition validates a [longitude, latitude(, altitude)] position.
+func checkPosition(v any, path string, out map[string]string) {
+	pos, ok := v.([]any)
+	if !ok || len(pos) < 2 || len(pos) > 3 {
+		out[path] = "must be a position with 2 or 3 coordinates"
+		return
+	}
+	for i, c := range pos {
+		n, ok := c.(float64)
+		p := joinPath(path, strconv.Itoa(i))
+		switch {
+		case !ok:
+			out[p] = "must be a number"
+		case i == 0 && (n < -180 || n > 180):
+			out[p] = "must be a valid longitude"
+		case i == 1 && (n < -90 || n > 90):
+			out[p] = "must be a valid latitude"
+		}
+	}
+}
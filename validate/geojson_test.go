@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeoJSONTag_SubPathErrors(t *testing.T) {
+	type place struct {
+		Location string `json:"location" validate:"required,geojson"`
+	}
+	valid := []string{
+		`{"type":"Point","coordinates":[13.4,52.5]}`,
+		`{"type":"LineString","coordinates":[[0,0],[1,1,10]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+		`{"type":"Feature","geometry":null,"properties":{"name":"x"}}`,
+		`{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"MultiPoint","coordinates":[[1,2]]}}]}`,
+		`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[0,0]}]}`,
+	}
+	for _, doc := range valid {
+		if err := Struct(place{Location: doc}); err != nil {
+			t.Fatalf("%s: expected valid, got %v", doc, ToFieldErrors(err))
+		}
+	}
+
+	cases := map[string]map[string]string{
+		`{"type":"Feature","geometry":{"type":"LineString","coordinates":[[0,0],[1,95]]}}`: {
+			"location.geometry.coordinates.1.1": "must be a valid latitude",
+		},
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`: {
+			"location.coordinates.0": "linear ring must be closed",
+		},
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[0,0]]]}`: {
+			"location.coordinates.0": "must contain at least 4 positions",
+		},
+		`{"type":"Point","coordinates":[200]}`: {
+			"location.coordinates": "must be a position with 2 or 3 coordinates",
+		},
+		`{"type":"Circle"}`: {
+			"location.type": "must be one of " + geoJSONTypes,
+		},
+		`{"type":"Feature","properties":1}`: {
+			"location.geometry":   "is required",
+			"location.properties": "must be an object",
+		},
+		`{"type":"Point"}`: {
+			"location.coordinates": "is required",
+		},
+		`{"coordinates":[0,0]}`: {
+			"location.type": "is required",
+		},
+	}
+	for doc, want := range cases {
+		got := ToFieldErrors(Struct(place{Location: doc}))
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %v, got %v", doc, want, got)
+		}
+		for k, msg := range want {
+			if got[k] != msg {
+				t.Fatalf("%s: expected %v, got %v", doc, want, got)
+			}
+		}
+	}
+	if got := ToFieldErrors(Struct(place{Location: "[1,2]"})); got["location"] != "must be a valid GeoJSON object" {
+		t.Fatalf("expected non-object message, got %v", got)
+	}
+	if Var("location", map[string]any{"type": "Point", "coordinates": []any{1.0, 2.0}}, "geojson") != nil {
+		t.Fatalf("expected decoded objects to validate")
+	}
+	if Var("location", json.RawMessage(`{"type":"Point","coordinates":[1,2]}`), "geojson") != nil {
+		t.Fatalf("expected raw messages to validate")
+	}
+}
+
+func TestValidateGeoJSON(t *testing.T) {
+	if err := ValidateGeoJSON([]byte(`{"type":"Point","coordinates":[1,2]}`)); err != nil {
+		t.Fatalf("expected valid, got %v", err)
+	}
+	fe, ok := ValidateGeoJSON([]byte(`{"type":"MultiPoint","coordinates":[[1,2],[190,0]]}`)).(FieldErrors)
+	if !ok || fe["coordinates.1.0"] != "must be a valid longitude" {
+		t.Fatalf("expected longitude error, got %v", fe)
+	}
+	fe, _ = ValidateGeoJSON([]byte(`nope`)).(FieldErrors)
+	if fe["_error"] != "must be a valid GeoJSON object" {
+		t.Fatalf("expected _error, got %v", fe)
+	}
+	fe, _ = ValidateGeoJSON([]byte(`[]`)).(FieldErrors)
+	if fe["_error"] != "must be a GeoJSON object" {
+		t.Fatalf("expected root error as _error, got %v", fe)
+	}
+}
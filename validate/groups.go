@@ -0,0 +1,111 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2/ctx"
+)
+
+// ErrorGroups partitions field errors by origin, so clients can tell "your
+// JSON is malformed" from "your values are wrong" and from rule violations
+// of the application.
+type ErrorGroups struct {
+	// Binding holds errors decoding the request: malformed JSON, unknown
+	// fields, type mismatches, ...
+	Binding map[string]string `json:"binding_errors,omitempty"`
+	// Validation holds failed `validate` rules.
+	Validation map[string]string `json:"validation_errors,omitempty"`
+	// Business holds errors reported by application code, such as
+	// FieldErrors returned by a handler ("email is already taken").
+	Business map[string]string `json:"business_errors,omitempty"`
+}
+
+// BindingError marks err as a failure to decode the request. BindAndValidate,
+// BindQuery and BindForm return it for malformed or oversized payloads and
+// values that do not convert to their field; wrap FieldErrors built from
+// binding failures with it too, since FieldErrors otherwise count as
+// business errors in ToErrorGroups. The converters report the messages of
+// Err followed by those of Validation.
+type BindingError struct {
+	Err error
+	// Validation is the validation error of the fields that did convert, for
+	// query and form input, or nil. Fields reported in Err are left out.
+	Validation error
+}
+
+func (e *BindingError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error.
+func (e *BindingError) Unwrap() error { return e.Err }
+
+// StatusCode returns the HTTP status of binding errors: 422 Unprocessable
+// Entity, like FieldErrors.
+func (e *BindingError) StatusCode() int { return http.StatusUnprocessableEntity }
+
+// ToErrorGroups converts err like ToFieldErrorsWithContext, but partitions
+// the messages by origin:
+//   - binding: BindingError, flash ctx.FieldErrors, JSON syntax and type
+//     errors, errors understood by a RegisterBindingErrorParser parser and
+//     other decoding errors;
+//   - validation: validator.ValidationErrors;
+//   - business: FieldErrors and any other error.
+//
+// Errors wrapped with fmt.Errorf or ValidationError are classified by what
+// they wrap.
+func ToErrorGroups(c context.Context, err error) ErrorGroups {
+	var g ErrorGroups
+	if err == nil {
+		return g
+	}
+	var (
+		be     *BindingError
+		vErrs  validator.ValidationErrors
+		fields FieldErrors
+		ctxFE  ctx.FieldErrors
+		syntax *json.SyntaxError
+		typ    *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &be):
+		g.Binding = ToFieldErrorsWithContext(c, unwrapFieldErrors(be.Err))
+		if be.Validation != nil {
+			g.Validation = ToFieldErrorsWithContext(c, unwrapFieldErrors(be.Validation))
+		}
+	case errors.As(err, &vErrs):
+		g.Validation = ToFieldErrorsWithContext(c, vErrs)
+	case errors.As(err, &ctxFE):
+		g.Binding = ToFieldErrorsWithContext(c, ctxFE)
+	case errors.As(err, &fields):
+		g.Business = ToFieldErrorsWithContext(c, fields)
+	case errors.As(err, &syntax), errors.As(err, &typ), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		g.Binding = ToFieldErrorsWithContext(c, err)
+	default:
+		if res, ok := parseBindingError(err); ok {
+			g.Binding = res
+		} else if res := parseStructuredErrors(err.Error()); len(res) > 0 {
+			g.Binding = res
+		} else {
+			g.Business = ToFieldErrorsWithContext(c, err)
+		}
+	}
+	return g
+}
+
+// unwrapFieldErrors returns the FieldErrors or ValidationErrors in err's
+// chain, which the converters only recognize unwrapped, or err.
+func unwrapFieldErrors(err error) error {
+	var fields FieldErrors
+	if errors.As(err, &fields) {
+		return fields
+	}
+	var vErrs validator.ValidationErrors
+	if errors.As(err, &vErrs) {
+		return vErrs
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestToErrorGroups(t *testing.T) {
+	ctx := context.Background()
+	type user struct {
+		Name string `json:"name" validate:"required"`
+	}
+	g := ToErrorGroups(ctx, fmt.Errorf("create: %w", Struct(user{})))
+	if g.Validation["name"] != "is required" || g.Binding != nil || g.Business != nil {
+		t.Fatalf("expected validation group, got %+v", g)
+	}
+
+	g = ToErrorGroups(ctx, FieldErrors{"email": "is already taken"})
+	if g.Business["email"] != "is already taken" || g.Validation != nil {
+		t.Fatalf("expected business group, got %+v", g)
+	}
+
+	g = ToErrorGroups(ctx, &BindingError{Err: FieldErrors{"age": "must be a number"}})
+	if g.Binding["age"] != "must be a number" || g.Business != nil {
+		t.Fatalf("expected binding group, got %+v", g)
+	}
+
+	var v map[string]any
+	g = ToErrorGroups(ctx, json.Unmarshal([]byte(`{"a":`), &v))
+	if len(g.Binding) == 0 {
+		t.Fatalf("expected JSON syntax errors in binding group, got %+v", g)
+	}
+
+	g = ToErrorGroups(ctx, NewValidationError(FieldErrors{"sku": "is unknown"}, 409))
+	if g.Business["sku"] != "is unknown" {
+		t.Fatalf("expected wrapped FieldErrors in business group, got %+v", g)
+	}
+
+	g = ToErrorGroups(ctx, errors.New("database unavailable"))
+	if g.Business["_error"] != "database unavailable" {
+		t.Fatalf("expected other errors in business group, got %+v", g)
+	}
+
+	if g := ToErrorGroups(ctx, nil); g.Binding != nil || g.Validation != nil || g.Business != nil {
+		t.Fatalf("expected empty groups for nil, got %+v", g)
+	}
+
+	b, _ := json.Marshal(ErrorGroups{Validation: map[string]string{"name": "is required"}})
+	if string(b) != `{"validation_errors":{"name":"is required"}}` {
+		t.Fatalf("unexpected JSON: %s", b)
+	}
+}
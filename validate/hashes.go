@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Stored-credential formats, so imports cannot carry plaintext secrets.
+	mustRegister("bcrypt_hash", stringTag(isBcryptHash), map[string]string{DefaultMessageLocale: "must be a bcrypt hash"})
+	mustRegister("argon2id_hash", stringTag(isArgon2idHash), map[string]string{DefaultMessageLocale: "must be an Argon2id hash"})
+	mustRegister("sha256_hex", stringTag(isSHA256Hex), map[string]string{DefaultMessageLocale: "must be a SHA-256 hex digest"})
+}
+
+// stringTag adapts a string predicate to a validation that rejects other
+// kinds.
+func stringTag(fn func(string) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return fl.Field().Kind() == reflect.String && fn(fl.Field().String())
+	}
+}
+
+// isBcryptHash accepts modular crypt bcrypt hashes: "$2a$", "$2b$", "$2x$" or
+// "$2y$", a two-digit cost from 04 to 31 and 53 characters of salt and hash.
+func isBcryptHash(s string) bool {
+	if len(s) != 60 || s[0] != '$' || s[1] != '2' || !strings.ContainsRune("abxy", rune(s[2])) || s[3] != '$' || s[6] != '$' {
+		return false
+	}
+	cost, err := strconv.Atoi(s[4:6])
+	if err != nil || cost < 4 || cost > 31 {
+		return false
+	}
+	for i := 7; i < len(s); i++ {
+		c := s[i]
+		if !(c == '.' || c == '/' || c >= '0' && c <= '9' || isASCIILetter(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isArgon2idHash accepts PHC strings such as
+// "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>" with positive parameters,
+// an optional version, and unpadded base64 salt (8+ bytes) and hash (4+
+// bytes).
+func isArgon2idHash(s string) bool {
+	parts := strings.Split(s, "$")
+	if len(parts) == 6 && strings.HasPrefix(parts[2], "v=") {
+		if v, err := strconv.Atoi(parts[2][2:]); err != nil || v <= 0 {
+			return false
+		}
+		parts = append(parts[:2], parts[3:]...)
+	}
+	if len(parts) != 5 || parts[0] != "" || parts[1] != "argon2id" {
+		return false
+	}
+	seen := map[string]bool{}
+	for _, kv := range strings.Split(parts[2], ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		n, err := strconv.ParseUint(v, 10, 32)
+		if !ok || err != nil || n == 0 || seen[k] || len(k) != 1 || !strings.Contains("mtp", k) {
+			return false
+		}
+		seen[k] = true
+	}
+	if len(seen) != 3 {
+		return false
+	}
+	salt, err1 := base64.RawStdEncoding.DecodeString(parts[3])
+	hash, err2 := base64.RawStdEncoding.DecodeString(parts[4])
+	return err1 == nil && err2 == nil && len(salt) >= 8 && len(hash) >= 4
+}
+
+// isSHA256Hex accepts 64 hexadecimal characters in either case.
+func isSHA256Hex(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
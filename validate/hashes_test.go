@@ -0,0 +1,41 @@
+package validate
+
+import "testing"
+
+func TestHashFormatTags(t *testing.T) {
+	const (
+		bcrypt = "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"
+		argon  = "$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG"
+	)
+	cases := []struct {
+		value any
+		tag   string
+		ok    bool
+	}{
+		{bcrypt, "bcrypt_hash", true},
+		{"$2y$12$" + bcrypt[7:], "bcrypt_hash", true},
+		{"$2a$03$" + bcrypt[7:], "bcrypt_hash", false},
+		{"$2c$10$" + bcrypt[7:], "bcrypt_hash", false},
+		{bcrypt[:59] + "!", "bcrypt_hash", false},
+		{"hunter2", "bcrypt_hash", false},
+		{argon, "argon2id_hash", true},
+		{"$argon2id$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "argon2id_hash", true},
+		{"$argon2i$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "argon2id_hash", false},
+		{"$argon2id$v=19$m=65536,t=0,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "argon2id_hash", false},
+		{"$argon2id$v=19$m=65536,t=3$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "argon2id_hash", false},
+		{"$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "argon2id_hash", false},
+		{"$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$not base64", "argon2id_hash", false},
+		{"E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855", "sha256_hex", true},
+		{"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b85", "sha256_hex", false},
+		{"g3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", "sha256_hex", false},
+		{42, "sha256_hex", false},
+	}
+	for _, c := range cases {
+		if err := Var("hash", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%v %s: expected ok=%v, got %v", c.value, c.tag, c.ok, err)
+		}
+	}
+	if got := ToFieldErrors(Var("password_hash", "hunter2", "bcrypt_hash"))["password_hash"]; got != "must be a bcrypt hash" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
@@ -0,0 +1,225 @@
+package validate
+
+import (
+	"html"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// HTMLPolicy allowlists the markup accepted by `safe_html`. Event handler
+// attributes (on*) and URLs with schemes outside URLSchemes are always
+// rejected, whatever the allowlist says.
+type HTMLPolicy struct {
+	// Elements maps lower-case element names to their allowed attributes.
+	Elements map[string][]string
+	// URLSchemes allowed in URL attributes such as href and src; relative
+	// URLs are always allowed. Default: http, https and mailto.
+	URLSchemes []string
+}
+
+// DefaultHTMLPolicy is used by `safe_html` without a parameter: basic rich
+// text formatting, links and images.
+var DefaultHTMLPolicy = HTMLPolicy{
+	Elements: map[string][]string{
+		"p": nil, "br": nil, "hr": nil, "b": nil, "strong": nil, "i": nil, "em": nil,
+		"u": nil, "s": nil, "sub": nil, "sup": nil, "code": nil, "pre": nil,
+		"blockquote": nil, "ul": nil, "ol": nil, "li": nil, "span": nil,
+		"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+		"a":   {"href", "title"},
+		"img": {"src", "alt", "title", "width", "height"},
+	},
+}
+
+var (
+	htmlPoliciesMu sync.RWMutex
+	htmlPolicies   = map[string]HTMLPolicy{}
+)
+
+// urlAttributes are checked against HTMLPolicy.URLSchemes.
+var urlAttributes = map[string]bool{
+	"href": true, "src": true, "action": true, "formaction": true,
+	"background": true, "poster": true, "cite": true, "xlink:href": true,
+}
+
+func init() {
+	// The message names the first offending construct through {invalid}.
+	mustRegister("safe_html", isSafeHTML, map[string]string{DefaultMessageLocale: "contains disallowed HTML: {invalid}"})
+}
+
+// RegisterHTMLPolicy registers p under name for `safe_html=<name>`:
+//
+//	validate.RegisterHTMLPolicy("comment", validate.HTMLPolicy{
+//		Elements: map[string][]string{"b": nil, "i": nil, "a": {"href"}},
+//	})
+//
+//	type Comment struct {
+//		Body string `json:"body" validate:"required,safe_html=comment"`
+//	}
+func RegisterHTMLPolicy(name string, p HTMLPolicy) {
+	htmlPoliciesMu.Lock()
+	htmlPolicies[name] = p
+	htmlPoliciesMu.Unlock()
+}
+
+// htmlPolicy returns the policy named by a `safe_html` parameter.
+func htmlPolicy(name string) (HTMLPolicy, bool) {
+	if name == "" {
+		return DefaultHTMLPolicy, true
+	}
+	htmlPoliciesMu.RLock()
+	defer htmlPoliciesMu.RUnlock()
+	p, ok := htmlPolicies[name]
+	return p, ok
+}
+
+// isSafeHTML accepts strings whose markup is allowed by the policy named in
+// the parameter. Unknown policies reject everything.
+func isSafeHTML(fl validator.FieldLevel) bool {
+	return fl.Field().Kind() == reflect.String && unsafeHTML(fl.Field().String(), fl.Param()) == ""
+}
+
+// unsafeHTML returns the first construct of s not allowed by the named policy,
+// e.g. "<script>", "onclick attribute" or "javascript: URL", or "" if s is safe.
+func unsafeHTML(s, policy string) string {
+	p, ok := htmlPolicy(policy)
+	if !ok {
+		return "unknown policy " + policy
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '<' || i+1 == len(s) {
+			continue
+		}
+		rest := s[i+1:]
+		switch {
+		case rest[0] == '!' || rest[0] == '?':
+			return "comments and declarations"
+		case rest[0] == '/':
+			name, _ := htmlName(rest[1:])
+			if name == "" {
+				continue
+			}
+			if _, ok := p.Elements[name]; !ok {
+				return "</" + name + ">"
+			}
+		case isASCIILetter(rest[0]):
+			name, n := htmlName(rest)
+			allowed, ok := p.Elements[name]
+			if !ok {
+				return "<" + name + ">"
+			}
+			end, bad := p.checkAttributes(rest[n:], allowed)
+			if bad != "" {
+				return bad
+			}
+			i += n + end
+		}
+	}
+	return ""
+}
+
+// checkAttributes scans the attributes of a start tag up to its closing '>'
+// and returns the offset just past it, or the first disallowed construct.
+func (p HTMLPolicy) checkAttributes(s string, allowed []string) (int, string) {
+	i := 0
+	for {
+		for i < len(s) && (isHTMLSpace(s[i]) || s[i] == '/') {
+			i++
+		}
+		if i == len(s) {
+			return i, "unterminated tag"
+		}
+		if s[i] == '>' {
+			return i + 1, ""
+		}
+		start := i
+		for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		name := strings.ToLower(s[start:i])
+		var value string
+		for i < len(s) && isHTMLSpace(s[i]) {
+			i++
+		}
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isHTMLSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				q := s[i]
+				end := strings.IndexByte(s[i+1:], q)
+				if end < 0 {
+					return len(s), "unterminated tag"
+				}
+				value = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start := i
+				for i < len(s) && !isHTMLSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[start:i]
+			}
+		}
+		if strings.HasPrefix(name, "on") || !containsString(allowed, name) {
+			return i, name + " attribute"
+		}
+		if urlAttributes[name] {
+			if scheme := p.disallowedScheme(value); scheme != "" {
+				return i, scheme + ": URL"
+			}
+		}
+	}
+}
+
+// disallowedScheme returns the scheme of an attribute URL if the policy does
+// not allow it. Entities, whitespace and control characters are removed first
+// since browsers ignore them ("jav&#x09;ascript:").
+func (p HTMLPolicy) disallowedScheme(value string) string {
+	v := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, html.UnescapeString(value))
+	colon := strings.IndexByte(v, ':')
+	if colon < 0 || strings.ContainsAny(v[:colon], "/?#") {
+		return ""
+	}
+	scheme := strings.ToLower(v[:colon])
+	schemes := p.URLSchemes
+	if schemes == nil {
+		schemes = []string{"http", "https", "mailto"}
+	}
+	if containsString(schemes, scheme) {
+		return ""
+	}
+	return scheme
+}
+
+// htmlName reads a lower-cased element name and returns it with its length.
+func htmlName(s string) (string, int) {
+	n := 0
+	for n < len(s) && (isASCIILetter(s[n]) || s[n] >= '0' && s[n] <= '9' || s[n] == '-' || s[n] == ':') {
+		n++
+	}
+	return strings.ToLower(s[:n]), n
+}
+
+func isASCIILetter(c byte) bool { return c|0x20 >= 'a' && c|0x20 <= 'z' }
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package validate
+
+import "testing"
+
+func TestSafeHTML_DefaultPolicy(t *testing.T) {
+	cases := map[string]string{
+		"plain text, 1 < 2":                             "",
+		`<p>Hi <b>there</b><br/><a href="/x">x</a></p>`: "",
+		`<a href="https://example.com" title='t'>x</a>`: "",
+		"<script>alert(1)</script>":                     "<script>",
+		`<iframe src="https://evil.test"></iframe>`:     "<iframe>",
+		`<img src=x onerror=alert(1)>`:                  "onerror attribute",
+		`<p style="color:red">x</p>`:                    "style attribute",
+		`<a href="javascript:alert(1)">x</a>`:           "javascript: URL",
+		`<a href="jav&#x09;ascript:alert(1)">x</a>`:     "javascript: URL",
+		`<a href="data:text/html,x">x</a>`:              "data: URL",
+		"<!-- hidden -->":                               "comments and declarations",
+		`<p title="x`:                                   "unterminated tag",
+		"</script>":                                     "</script>",
+	}
+	for in, want := range cases {
+		err := Var("body", in, "safe_html")
+		if want == "" {
+			if err != nil {
+				t.Fatalf("%q: expected valid, got %v", in, err)
+			}
+			continue
+		}
+		if got := ToFieldErrors(err)["body"]; got != "contains disallowed HTML: "+want {
+			t.Fatalf("%q: expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestSafeHTML_NamedPolicy(t *testing.T) {
+	RegisterHTMLPolicy("test_comment", HTMLPolicy{
+		Elements:   map[string][]string{"b": nil, "a": {"href"}},
+		URLSchemes: []string{"https"},
+	})
+	defer func() {
+		htmlPoliciesMu.Lock()
+		delete(htmlPolicies, "test_comment")
+		htmlPoliciesMu.Unlock()
+	}()
+	if err := Var("body", `<B>ok</B> <a href="https://x.test">x</a>`, "safe_html=test_comment"); err != nil {
+		t.Fatalf("expected valid, got %v", err)
+	}
+	if got := ToFieldErrors(Var("body", "<p>x</p>", "safe_html=test_comment"))["body"]; got != "contains disallowed HTML: <p>" {
+		t.Fatalf("unexpected message %q", got)
+	}
+	if Var("body", `<a href="http://x.test">x</a>`, "safe_html=test_comment") == nil {
+		t.Fatalf("expected scheme outside the policy to fail")
+	}
+	if Var("body", "text", "safe_html=missing") == nil || Var("body", 1, "safe_html") == nil {
+		t.Fatalf("expected unknown policy and non-string to fail")
+	}
+}
@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2/ctx"
+)
+
+func init() {
+	mustRegister("idempotency_key", isIdempotencyKey, map[string]string{DefaultMessageLocale: "must be a valid idempotency key"})
+	mustRegisterCtx("idempotency_unique", isUnseenIdempotencyKey, map[string]string{DefaultMessageLocale: "has already been used"})
+}
+
+// IdempotencyHeader is the request header read by IdempotencyKey.
+const IdempotencyHeader = "Idempotency-Key"
+
+// IdempotencyStore remembers idempotency keys. Seen records key and reports
+// whether it had been recorded before; implementations must do both
+// atomically so concurrent duplicates are caught.
+type IdempotencyStore interface {
+	Seen(ctx context.Context, key string) bool
+}
+
+var idempotencyStore atomic.Pointer[IdempotencyStore]
+
+// SetIdempotencyStore installs the store consulted by the idempotency_unique
+// tag. Nil (the default) disables the check.
+func SetIdempotencyStore(s IdempotencyStore) {
+	if s == nil {
+		idempotencyStore.Store(nil)
+		return
+	}
+	idempotencyStore.Store(&s)
+}
+
+// isIdempotencyKey validates `idempotency_key`: 16 to 255 characters of
+// letters, digits and "-_.:", or a UUID with `idempotency_key=uuid`.
+func isIdempotencyKey(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	s := field.String()
+	if fl.Param() == "uuid" {
+		return isCanonicalUUID(s)
+	}
+	if len(s) < 16 || len(s) > 255 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !isASCIILetter(c) && !(c >= '0' && c <= '9') && c != '-' && c != '_' && c != '.' && c != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// isCanonicalUUID reports whether s is a UUID in the canonical 8-4-4-4-12
+// hex form, in either case.
+func isCanonicalUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if c := s[i]; !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isUnseenIdempotencyKey validates `idempotency_unique` against the store
+// installed with SetIdempotencyStore. Empty keys and a missing store pass.
+func isUnseenIdempotencyKey(ctx context.Context, fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	s := idempotencyStore.Load()
+	if s == nil || field.String() == "" {
+		return true
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return !(*s).Seen(ctx, field.String())
+}
+
+// IdempotencyKey reads and validates the Idempotency-Key header. Malformed
+// keys fail with "idempotency_key" and replayed keys (see
+// SetIdempotencyStore) with "idempotency_unique", both as FieldErrors keyed
+// by the header name:
+//
+//	key, err := validate.IdempotencyKey(c)
+//	if err != nil {
+//		return c.Status(http.StatusUnprocessableEntity).JSON(err)
+//	}
+//	// {"Idempotency-Key": "has already been used"}
+//
+// Body fields use the same tags: `validate:"required,idempotency_key,idempotency_unique"`.
+func IdempotencyKey(c ctx.Ctx) (string, error) {
+	key := c.Request().Header.Get(IdempotencyHeader)
+	if err := VarCtx(c.Context(), IdempotencyHeader, key, "required,idempotency_key,idempotency_unique"); err != nil {
+		return "", FieldErrors(ToFieldErrorsWithContext(c.Context(), err))
+	}
+	return key, nil
+}
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore that forgets keys
+// after a TTL. Use a shared store (e.g. Redis SET NX) when running several
+// instances.
+type MemoryIdempotencyStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	keys    map[string]time.Time
+	sweepAt int
+}
+
+// NewMemoryIdempotencyStore returns a store remembering keys for ttl.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{ttl: ttl, now: time.Now, keys: map[string]time.Time{}, sweepAt: 1024}
+}
+
+// Seen records key and reports whether it was recorded within the TTL.
+func (s *MemoryIdempotencyStore) Seen(_ context.Context, key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if exp, ok := s.keys[key]; ok && now.Before(exp) {
+		return true
+	}
+	if len(s.keys) >= s.sweepAt {
+		// Drop expired keys, sweeping again once the live set doubles.
+		for k, exp := range s.keys {
+			if !now.Before(exp) {
+				delete(s.keys, k)
+			}
+		}
+		s.sweepAt = max(1024, 2*len(s.keys))
+	}
+	s.keys[key] = now.Add(s.ttl)
+	return false
+}
@@ -0,0 +1,90 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goflash/flash/v2"
+)
+
+type chargeRequest struct {
+	Key string `json:"key" validate:"required,idempotency_key,idempotency_unique"`
+}
+
+func TestIdempotencyKeyTag(t *testing.T) {
+	for _, key := range []string{"order-2024-0001:retry", "0f8fad5b-d9cb-469f-a165-70867728950e"} {
+		if err := Var("key", key, "idempotency_key"); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", key, err)
+		}
+	}
+	for _, key := range []string{"short", "has spaces in the key!", string(make([]byte, 256))} {
+		if m := ToFieldErrors(Var("key", key, "idempotency_key")); m["key"] != "must be a valid idempotency key" {
+			t.Fatalf("expected %q to be invalid, got %v", key, m)
+		}
+	}
+	if Var("key", "0f8fad5b-d9cb-469f-a165-70867728950e", "idempotency_key=uuid") != nil ||
+		Var("key", "order-2024-0001:retry", "idempotency_key=uuid") == nil {
+		t.Fatalf("expected idempotency_key=uuid to require a UUID")
+	}
+}
+
+func TestIdempotencyUnique(t *testing.T) {
+	if err := Struct(chargeRequest{Key: "order-2024-0001:retry"}); err != nil {
+		t.Fatalf("expected keys to pass without a store, got %v", err)
+	}
+	store := NewMemoryIdempotencyStore(time.Minute)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	SetIdempotencyStore(store)
+	defer SetIdempotencyStore(nil)
+
+	if err := Struct(chargeRequest{Key: "order-2024-0001:retry"}); err != nil {
+		t.Fatalf("expected first submission to pass, got %v", err)
+	}
+	if m := ToFieldErrors(Struct(chargeRequest{Key: "order-2024-0001:retry"})); m["key"] != "has already been used" {
+		t.Fatalf("expected duplicate to be rejected, got %v", m)
+	}
+	now = now.Add(2 * time.Minute)
+	if err := Struct(chargeRequest{Key: "order-2024-0001:retry"}); err != nil {
+		t.Fatalf("expected key to be forgotten after the TTL, got %v", err)
+	}
+}
+
+func TestIdempotencyKeyHeader(t *testing.T) {
+	SetIdempotencyStore(NewMemoryIdempotencyStore(time.Minute))
+	defer SetIdempotencyStore(nil)
+
+	var (
+		key string
+		fe  FieldErrors
+	)
+	app := flash.New()
+	app.POST("/charges", func(c flash.Ctx) error {
+		var err error
+		key, err = IdempotencyKey(c)
+		fe, _ = err.(FieldErrors)
+		return c.String(http.StatusOK, "ok")
+	})
+	send := func(header string) {
+		req := httptest.NewRequest(http.MethodPost, "/charges", nil)
+		if header != "" {
+			req.Header.Set(IdempotencyHeader, header)
+		}
+		app.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	send("")
+	if fe[IdempotencyHeader] != "is required" {
+		t.Fatalf("expected missing header error, got %v", fe)
+	}
+	send("0f8fad5b-d9cb-469f-a165-70867728950e")
+	if fe != nil || key != "0f8fad5b-d9cb-469f-a165-70867728950e" {
+		t.Fatalf("expected valid key, got %q %v", key, fe)
+	}
+	send("0f8fad5b-d9cb-469f-a165-70867728950e")
+	if fe[IdempotencyHeader] != "has already been used" {
+		t.Fatalf("expected replay error, got %v", fe)
+	}
+}
@@ -0,0 +1,151 @@
+package validate
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// idNow is the clock for ID timestamp checks; tests replace it.
+var idNow = time.Now
+
+// idClockSkew tolerates IDs minted by hosts whose clocks run slightly ahead.
+const idClockSkew = time.Minute
+
+func init() {
+	// ulid replaces the library's tag to add the timestamp check.
+	mustRegister("ulid", isULID, map[string]string{DefaultMessageLocale: "must be a valid ULID"})
+	mustRegister("ksuid", isKSUID, map[string]string{DefaultMessageLocale: "must be a valid KSUID"})
+	mustRegister("nanoid", isNanoID, map[string]string{DefaultMessageLocale: "must be a valid NanoID"})
+	mustRegister("snowflake", isSnowflake, map[string]string{DefaultMessageLocale: "must be a valid Snowflake ID"})
+}
+
+// notFuture reports whether t is not after now (plus clock skew).
+func notFuture(t time.Time) bool { return !t.After(idNow().Add(idClockSkew)) }
+
+// crockford is the ULID alphabet.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// isULID accepts 26-character Crockford base32 ULIDs in either case.
+// `ulid=past` also rejects timestamps in the future.
+func isULID(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	s := strings.ToUpper(fl.Field().String())
+	if len(s) != 26 || s[0] > '7' {
+		return false
+	}
+	var ms int64
+	for i := 0; i < len(s); i++ {
+		n := strings.IndexByte(crockford, s[i])
+		if n < 0 {
+			return false
+		}
+		if i < 10 {
+			ms = ms<<5 | int64(n)
+		}
+	}
+	return fl.Param() != "past" || notFuture(time.UnixMilli(ms))
+}
+
+const (
+	base62     = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	maxKSUID   = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+	ksuidEpoch = 1400000000
+)
+
+// isKSUID accepts 27-character base62 KSUIDs. `ksuid=past` also rejects
+// timestamps in the future.
+func isKSUID(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	s := fl.Field().String()
+	if len(s) != 27 || s > maxKSUID {
+		return false
+	}
+	n := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base62, s[i])
+		if d < 0 {
+			return false
+		}
+		n.Mul(n, big.NewInt(62)).Add(n, big.NewInt(int64(d)))
+	}
+	if fl.Param() != "past" {
+		return true
+	}
+	// The timestamp is the first 4 of the 20 bytes.
+	secs := new(big.Int).Rsh(n, 128).Int64()
+	return notFuture(time.Unix(secs+ksuidEpoch, 0))
+}
+
+// isNanoID accepts NanoIDs of the parameter's length (default 21) over the
+// URL-safe alphabet A-Za-z0-9_-.
+func isNanoID(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	size := 21
+	if p := fl.Param(); p != "" {
+		var err error
+		if size, err = strconv.Atoi(p); err != nil {
+			return false
+		}
+	}
+	s := fl.Field().String()
+	if len(s) != size {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !isASCIILetter(c) && (c < '0' || c > '9') && c != '_' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// isSnowflake accepts positive 63-bit Snowflake IDs as integers or decimal
+// strings. With an epoch in Unix milliseconds as parameter
+// (`snowflake=1288834974657` for Twitter, 1420070400000 for Discord), the
+// embedded timestamp must also not be in the future.
+func isSnowflake(fl validator.FieldLevel) bool {
+	var id int64
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int64:
+		id = field.Int()
+	case reflect.Uint, reflect.Uint64:
+		if field.Uint() > 1<<63-1 {
+			return false
+		}
+		id = int64(field.Uint())
+	case reflect.String:
+		if !intString(fl) || strings.HasPrefix(field.String(), "+") {
+			return false
+		}
+		var err error
+		if id, err = strconv.ParseInt(field.String(), 10, 64); err != nil {
+			return false
+		}
+	default:
+		return false
+	}
+	if id <= 0 {
+		return false
+	}
+	if fl.Param() == "" {
+		return true
+	}
+	epoch, err := strconv.ParseInt(fl.Param(), 10, 64)
+	if err != nil {
+		return false
+	}
+	return notFuture(time.UnixMilli(id>>22 + epoch))
+}
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIDTags(t *testing.T) {
+	defer func(now func() time.Time) { idNow = now }(idNow)
+	idNow = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	cases := []struct {
+		value any
+		tag   string
+		ok    bool
+	}{
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "ulid", true},
+		{"01arz3ndektsv4rrffq69g5fav", "ulid=past", true},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", "ulid", true},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", "ulid=past", false},
+		{"8ZZZZZZZZZZZZZZZZZZZZZZZZZ", "ulid", false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAU", "ulid", false},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FA", "ulid", false},
+		{"0ujtsYcgvSTl8PAuAdqWYSMnLOv", "ksuid", true},
+		{"0ujtsYcgvSTl8PAuAdqWYSMnLOv", "ksuid=past", true},
+		{"aWgEPTl1tmebfsQzFP4bxwgy80V", "ksuid", true},
+		{"aWgEPTl1tmebfsQzFP4bxwgy80V", "ksuid=past", false},
+		{"aWgEPTl1tmebfsQzFP4bxwgy80W", "ksuid", false},
+		{"0ujtsYcgvSTl8PAuAdqWYSMnLO!", "ksuid", false},
+		{"V1StGXR8_Z5jdHi6B-myT", "nanoid", true},
+		{"V1StGXR8_Z5jdHi6B-myT", "nanoid=21", true},
+		{"V1StGXR8_Z", "nanoid=10", true},
+		{"V1StGXR8_Z", "nanoid", false},
+		{"V1StGXR8_Z5jdHi6B+myT", "nanoid", false},
+		{"1212092628029698048", "snowflake", true},
+		{int64(1212092628029698048), "snowflake=1288834974657", true},
+		{uint64(175928847299117063), "snowflake=1420070400000", true},
+		{int64(9000000000000000000), "snowflake=1288834974657", false},
+		{"-5", "snowflake", false},
+		{"+5", "snowflake", false},
+		{"12a", "snowflake", false},
+		{0, "snowflake", false},
+		{1.5, "snowflake", false},
+	}
+	for _, c := range cases {
+		if err := Var("id", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%v %s: expected ok=%v, got %v", c.value, c.tag, c.ok, err)
+		}
+	}
+	for tag, msg := range map[string]string{
+		"ulid":      "must be a valid ULID",
+		"ksuid":     "must be a valid KSUID",
+		"nanoid":    "must be a valid NanoID",
+		"snowflake": "must be a valid Snowflake ID",
+	} {
+		if got := ToFieldErrors(Var("id", "?", tag))["id"]; got != msg {
+			t.Fatalf("%s: expected %q, got %q", tag, msg, got)
+		}
+	}
+}
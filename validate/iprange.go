@@ -0,0 +1,151 @@
+package validate
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	ipRangesMu sync.RWMutex
+	ipRanges   = map[string][]netip.Prefix{}
+)
+
+func init() {
+	mustRegister("ip_in", ipIn, map[string]string{
+		DefaultMessageLocale: "must be an IP address in an allowed range",
+		"es":                 "debe ser una dirección IP de un rango permitido",
+		"pt":                 "deve ser um endereço IP de um intervalo permitido",
+		"fr":                 "doit être une adresse IP d'une plage autorisée",
+		"de":                 "muss eine IP-Adresse aus einem erlaubten Bereich sein",
+	})
+	mustRegister("ip_not_in", ipNotIn, map[string]string{
+		DefaultMessageLocale: "must not be an IP address in a restricted range",
+		"es":                 "no debe ser una dirección IP de un rango restringido",
+		"pt":                 "não deve ser um endereço IP de um intervalo restrito",
+		"fr":                 "ne doit pas être une adresse IP d'une plage restreinte",
+		"de":                 "darf keine IP-Adresse aus einem gesperrten Bereich sein",
+	})
+}
+
+// RegisterIPRanges names a set of CIDR ranges (or single addresses) so tags
+// can refer to ranges configured at runtime, e.g. `ip_in=office vpn`.
+// Registering a name again replaces its ranges. It returns an error for
+// malformed ranges and leaves the name unchanged.
+func RegisterIPRanges(name string, ranges ...string) error {
+	prefixes := make([]netip.Prefix, 0, len(ranges))
+	for _, r := range ranges {
+		p, err := parseIPRange(r)
+		if err != nil {
+			return err
+		}
+		prefixes = append(prefixes, p)
+	}
+	ipRangesMu.Lock()
+	ipRanges[name] = prefixes
+	ipRangesMu.Unlock()
+	return nil
+}
+
+// ipIn accepts addresses within one of the space-separated ranges of the
+// parameter: CIDRs, single addresses or names registered with
+// RegisterIPRanges, e.g. `ip_in=10.0.0.0/8 192.168.0.0/16`.
+func ipIn(fl validator.FieldLevel) bool {
+	in, ok := ipInRanges(fl.Field(), fl.Param())
+	return ok && in
+}
+
+// ipNotIn accepts addresses outside all ranges of the parameter.
+func ipNotIn(fl validator.FieldLevel) bool {
+	in, ok := ipInRanges(fl.Field(), fl.Param())
+	return ok && !in
+}
+
+// ipInRanges reports whether the address held by field is in the ranges of
+// param. ok is false for values that are not addresses, malformed ranges and
+// unknown names.
+func ipInRanges(field reflect.Value, param string) (in, ok bool) {
+	addr, ok := ipAddr(field)
+	if !ok {
+		return false, false
+	}
+	for _, tok := range strings.Fields(param) {
+		prefixes, ok := ipRangesFor(tok)
+		if !ok {
+			return false, false
+		}
+		for _, p := range prefixes {
+			if p.Contains(addr) {
+				in = true
+			}
+		}
+	}
+	return in, true
+}
+
+// ipRangesFor resolves a parameter token to a literal range or the ranges
+// registered under that name.
+func ipRangesFor(tok string) ([]netip.Prefix, bool) {
+	if p, err := parseIPRange(tok); err == nil {
+		return []netip.Prefix{p}, true
+	}
+	ipRangesMu.RLock()
+	defer ipRangesMu.RUnlock()
+	prefixes, ok := ipRanges[tok]
+	return prefixes, ok
+}
+
+// parseIPRange parses a CIDR or a single address as a full-length prefix.
+func parseIPRange(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return netip.PrefixFrom(p.Addr().Unmap(), p.Bits()-unmappedBits(p.Addr())).Masked(), nil
+	}
+	a, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	a = a.Unmap()
+	return netip.PrefixFrom(a, a.BitLen()), nil
+}
+
+// unmappedBits is the prefix length lost when unmapping an IPv4-mapped IPv6
+// address.
+func unmappedBits(a netip.Addr) int {
+	if a.Is4In6() {
+		return 96
+	}
+	return 0
+}
+
+// ipAddr reads an address from a string or net.IP field.
+// IPv4-mapped IPv6 addresses are treated as IPv4.
+func ipAddr(field reflect.Value) (netip.Addr, bool) {
+	if !field.IsValid() || !field.CanInterface() {
+		return netip.Addr{}, false
+	}
+	var a netip.Addr
+	switch v := field.Interface().(type) {
+	case net.IP:
+		var ok bool
+		if a, ok = netip.AddrFromSlice(v); !ok {
+			return netip.Addr{}, false
+		}
+	default:
+		if field.Kind() != reflect.String {
+			return netip.Addr{}, false
+		}
+		var err error
+		if a, err = netip.ParseAddr(field.String()); err != nil {
+			return netip.Addr{}, false
+		}
+	}
+	return a.Unmap(), a.IsValid()
+}
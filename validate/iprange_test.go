@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+func TestIPInAndNotIn(t *testing.T) {
+	cases := []struct {
+		value any
+		tag   string
+		ok    bool
+	}{
+		{"10.1.2.3", "ip_in=10.0.0.0/8 192.168.0.0/16", true},
+		{"192.168.4.4", "ip_in=10.0.0.0/8 192.168.0.0/16", true},
+		{"8.8.8.8", "ip_in=10.0.0.0/8 192.168.0.0/16", false},
+		{"::ffff:10.0.0.1", "ip_in=10.0.0.0/8", true},
+		{"2001:db8::1", "ip_in=2001:db8::/32", true},
+		{"203.0.113.7", "ip_in=203.0.113.7", true},
+		{net.ParseIP("10.0.0.1"), "ip_in=10.0.0.0/8", true},
+		{net.ParseIP("172.16.0.1"), "ip_in=10.0.0.0/8", false},
+		{"8.8.8.8", "ip_not_in=10.0.0.0/8 127.0.0.0/8", true},
+		{"127.0.0.1", "ip_not_in=10.0.0.0/8 127.0.0.0/8", false},
+		{"not-an-ip", "ip_not_in=10.0.0.0/8", false},
+		{"10.0.0.1", "ip_in=10.0.0.0/33", false},
+		{1, "ip_in=10.0.0.0/8", false},
+	}
+	for _, c := range cases {
+		if err := Var("ip", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%v %s: expected ok=%v, got %v", c.value, c.tag, c.ok, err)
+		}
+	}
+}
+
+func TestRegisterIPRanges(t *testing.T) {
+	if err := RegisterIPRanges("test_office", "198.51.100.0/24", "203.0.113.9"); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	defer func() {
+		ipRangesMu.Lock()
+		delete(ipRanges, "test_office")
+		ipRangesMu.Unlock()
+	}()
+	if Var("ip", "198.51.100.20", "ip_in=test_office") != nil || Var("ip", "203.0.113.10", "ip_in=test_office") == nil {
+		t.Fatalf("expected named ranges to apply")
+	}
+	if Var("ip", "198.51.100.20", "ip_in=unknown_ranges") == nil {
+		t.Fatalf("expected unknown name to fail")
+	}
+	if RegisterIPRanges("test_office", "nope") == nil || Var("ip", "198.51.100.20", "ip_in=test_office") != nil {
+		t.Fatalf("expected malformed range to fail and keep the previous ranges")
+	}
+}
+
+func TestIPRangeMessages(t *testing.T) {
+	err := Var("ip", "8.8.8.8", "ip_in=10.0.0.0/8")
+	if got := ToFieldErrors(err)["ip"]; got != "must be an IP address in an allowed range" {
+		t.Fatalf("unexpected message %q", got)
+	}
+	ctx := WithLocale(context.Background(), "es")
+	if got := ToFieldErrorsWithContext(ctx, Var("ip", "10.0.0.1", "ip_not_in=10.0.0.0/8"))["ip"]; got != "no debe ser una dirección IP de un rango restringido" {
+		t.Fatalf("unexpected localized message %q", got)
+	}
+}
@@ -0,0 +1,229 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goflash/flash/v2/ctx"
+)
+
+// bindJSON decodes a JSON body into v. Struct targets are decoded to a map
+// with json.Number values first, so numbers can be checked against the field
+// types (no silent truncation or overflow) before c.BindMap assigns them.
+// Fields of type json.Number receive the number verbatim; with UseNumber,
+// untyped targets (any, map[string]any) do too instead of a float64.
+// Strings for time.Time fields are parsed with the field's `layout` tag (or
+// o.Coerce.TimeLayout, default time.RFC3339), strings for other types with a
+// parser (see RegisterParser) through it; failures are reported per field.
+// Malformed JSON is returned as a *SyntaxError positioned in body.
+func bindJSON(c ctx.Ctx, body []byte, v any, o BindOptions) error {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		var err error
+		if o.JSON != nil {
+			err = c.BindJSON(v, *o.JSON)
+		} else {
+			err = c.BindJSON(v)
+		}
+		return withSyntaxPosition(err, body)
+	}
+
+	var m map[string]any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&m); err != nil {
+		return withSyntaxPosition(err, body)
+	}
+	p := jsonPrep{res: FieldErrors{}, useNumber: o.UseNumber, timeLayout: o.Coerce.TimeLayout}
+	if p.timeLayout == "" {
+		p.timeLayout = time.RFC3339
+	}
+	p.object(m, t.Elem(), "")
+	if len(p.res) > 0 {
+		return p.res
+	}
+	if o.JSON != nil {
+		return c.BindMap(v, m, *o.JSON)
+	}
+	return c.BindMap(v, m)
+}
+
+// jsonPrep converts decoded JSON values in place to match a target type and
+// collects per-field conversion errors.
+type jsonPrep struct {
+	res        FieldErrors
+	useNumber  bool
+	timeLayout string
+}
+
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+// object prepares the values of m for struct or map type t.
+func (p *jsonPrep) object(m map[string]any, t reflect.Type, path string) {
+	t = derefType(t)
+	kind := reflect.Invalid
+	if t != nil {
+		kind = t.Kind()
+	}
+	for k, val := range m {
+		var ft reflect.Type
+		layout := p.timeLayout
+		switch kind {
+		case reflect.Struct:
+			if sf, ok := jsonField(t, k); ok {
+				ft = sf.Type
+				if l := sf.Tag.Get("layout"); l != "" {
+					layout = l
+				}
+			}
+		case reflect.Map:
+			ft = t.Elem()
+		}
+		m[k] = p.value(val, ft, layout, joinPath(path, k))
+	}
+}
+
+// value returns val converted for type t (nil if unknown).
+func (p *jsonPrep) value(val any, t reflect.Type, layout, path string) any {
+	t = derefType(t)
+	switch val := val.(type) {
+	case json.Number:
+		return p.number(val, t, path)
+	case string:
+		if t == timeType {
+			tm, err := time.Parse(layout, val)
+			if err != nil {
+				p.res[path] = "must be a time in format " + layout
+				return val
+			}
+			return tm
+		}
+		if tp, ok := parserFor(t); ok {
+			parsed, err := tp.parse(val)
+			if err != nil {
+				p.res[path] = tp.message
+				return val
+			}
+			return parsed
+		}
+	case map[string]any:
+		if t != nil && (t.Kind() == reflect.Struct || t.Kind() == reflect.Map) {
+			p.object(val, t, path)
+			return val
+		}
+		p.object(val, nil, path)
+	case []any:
+		var et reflect.Type
+		if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			et = t.Elem()
+		}
+		for i, item := range val {
+			val[i] = p.value(item, et, layout, joinPath(path, strconv.Itoa(i)))
+		}
+	}
+	return val
+}
+
+// number converts n to the numeric kind of t. Numbers for other or unknown
+// types become float64, as with a plain JSON decode, unless useNumber is set.
+func (p *jsonPrep) number(n json.Number, t reflect.Type, path string) any {
+	s := string(n)
+	kind := reflect.Invalid
+	switch {
+	case t == jsonNumberType:
+		return n
+	case t != nil:
+		kind = t.Kind()
+	}
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(s, 10, t.Bits()); err == nil {
+			return i
+		} else if errors.Is(err, strconv.ErrRange) {
+			p.res[path] = "value out of range"
+			return n
+		}
+		// Exponent or fraction, e.g. 1e3 or 1.5.
+		f, err := strconv.ParseFloat(s, 64)
+		switch {
+		case err == nil && f != math.Trunc(f):
+			p.res[path] = "must be a whole number"
+		case err != nil || f < -math.Ldexp(1, t.Bits()-1) || f >= math.Ldexp(1, t.Bits()-1):
+			p.res[path] = "value out of range"
+		default:
+			return int64(f)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if strings.HasPrefix(s, "-") {
+			p.res[path] = "must be a non-negative integer"
+			return n
+		}
+		if u, err := strconv.ParseUint(s, 10, t.Bits()); err == nil {
+			return u
+		} else if errors.Is(err, strconv.ErrRange) {
+			p.res[path] = "value out of range"
+			return n
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		switch {
+		case err == nil && f != math.Trunc(f):
+			p.res[path] = "must be a whole number"
+		case err != nil || f >= math.Ldexp(1, t.Bits()):
+			p.res[path] = "value out of range"
+		default:
+			return uint64(f)
+		}
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			p.res[path] = "value out of range"
+			return n
+		}
+		return f
+	case reflect.Invalid, reflect.Interface:
+		if p.useNumber {
+			return n
+		}
+		fallthrough
+	default:
+		f, err := n.Float64()
+		if err != nil {
+			return n
+		}
+		return f
+	}
+	return n
+}
+
+// jsonField finds the struct field of t with json name name, including
+// fields promoted from embedded structs.
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			if et := derefType(sf.Type); et.Kind() == reflect.Struct {
+				if f, ok := jsonField(et, name); ok {
+					return f, true
+				}
+			}
+			continue
+		}
+		if sf.IsExported() && jsonName(sf) == name {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
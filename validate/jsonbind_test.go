@@ -0,0 +1,130 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goflash/flash/v2"
+)
+
+type numbersItem struct {
+	Qty uint16 `json:"qty"`
+}
+
+type numbers struct {
+	Age   int            `json:"age"`
+	Small int8           `json:"small"`
+	ID    int64          `json:"id"`
+	Count *uint          `json:"count"`
+	Ratio float32        `json:"ratio"`
+	Items []numbersItem  `json:"items"`
+	Meta  map[string]int `json:"meta"`
+	Extra any            `json:"extra"`
+}
+
+func serveNumbers(t *testing.T, body string) (numbers, FieldErrors) {
+	t.Helper()
+	var (
+		in  numbers
+		res FieldErrors
+	)
+	app := flash.New()
+	app.POST("/", func(c flash.Ctx) error {
+		if err := BindAndValidate(c, &in); err != nil {
+			res = FieldErrors(ToFieldErrorsWithContext(c.Context(), err))
+		}
+		return nil
+	})
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	return in, res
+}
+
+func TestBindJSON_NumericErrors(t *testing.T) {
+	cases := map[string]FieldErrors{
+		`{"age":1.5}`:                         {"age": "must be a whole number"},
+		`{"age":1e30}`:                        {"age": "value out of range"},
+		`{"small":300}`:                       {"small": "value out of range"},
+		`{"small":-129}`:                      {"small": "value out of range"},
+		`{"count":-1}`:                        {"count": "must be a non-negative integer"},
+		`{"count":2.5}`:                       {"count": "must be a whole number"},
+		`{"ratio":1e300}`:                     {"ratio": "value out of range"},
+		`{"items":[{"qty":1},{"qty":70000}]}`: {"items.1.qty": "value out of range"},
+		`{"meta":{"a":0.5}}`:                  {"meta.a": "must be a whole number"},
+	}
+	for body, want := range cases {
+		_, fe := serveNumbers(t, body)
+		if len(fe) != len(want) {
+			t.Fatalf("%s: expected %v, got %v", body, want, fe)
+		}
+		for k, msg := range want {
+			if fe[k] != msg {
+				t.Fatalf("%s: expected %v, got %v", body, want, fe)
+			}
+		}
+	}
+}
+
+func TestBindJSON_NumericValues(t *testing.T) {
+	in, fe := serveNumbers(t, `{"age":1e3,"small":-128,"id":9007199254740993,"count":7,"ratio":0.5,"items":[{"qty":65535}],"meta":{"a":2},"extra":{"n":1.5}}`)
+	if fe != nil {
+		t.Fatalf("expected valid bind, got %v", fe)
+	}
+	if in.Age != 1000 || in.Small != -128 || in.ID != 9007199254740993 || *in.Count != 7 || in.Ratio != 0.5 || in.Items[0].Qty != 65535 || in.Meta["a"] != 2 {
+		t.Fatalf("unexpected values: %+v", in)
+	}
+	if extra, _ := in.Extra.(map[string]any); extra["n"] != 1.5 {
+		t.Fatalf("expected untyped numbers as float64, got %#v", in.Extra)
+	}
+}
+
+type schedule struct {
+	Start time.Time   `json:"start"`
+	Day   *time.Time  `json:"day" layout:"2006-01-02"`
+	Slots []time.Time `json:"slots" layout:"15:04"`
+}
+
+func TestBindJSON_Times(t *testing.T) {
+	bind := func(body string, opts ...BindOptions) (schedule, FieldErrors) {
+		var (
+			in  schedule
+			res FieldErrors
+		)
+		app := flash.New()
+		app.POST("/", func(c flash.Ctx) error {
+			if err := BindAndValidate(c, &in, opts...); err != nil {
+				res = FieldErrors(ToFieldErrorsWithContext(c.Context(), err))
+			}
+			return nil
+		})
+		app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		return in, res
+	}
+
+	in, fe := bind(`{"start":"2024-05-01T10:00:00.5Z","day":"2024-05-02","slots":["09:30"]}`)
+	if fe != nil || in.Start.Year() != 2024 || in.Day.Day() != 2 || in.Slots[0].Hour() != 9 {
+		t.Fatalf("expected times to bind, got %+v %v", in, fe)
+	}
+
+	_, fe = bind(`{"start":"","day":"02/05/2024","slots":["09:30","9am"]}`)
+	want := FieldErrors{
+		"start":   "must be a time in format 2006-01-02T15:04:05Z07:00",
+		"day":     "must be a time in format 2006-01-02",
+		"slots.1": "must be a time in format 15:04",
+	}
+	if len(fe) != len(want) {
+		t.Fatalf("expected %v, got %v", want, fe)
+	}
+	for k, msg := range want {
+		if fe[k] != msg {
+			t.Fatalf("field %q: expected %q, got %v", k, msg, fe)
+		}
+	}
+
+	_, fe = bind(`{"start":"2024-05-01"}`, BindOptions{Coerce: CoerceOptions{TimeLayout: time.DateOnly}})
+	if fe != nil {
+		t.Fatalf("expected default layout from options, got %v", fe)
+	}
+}
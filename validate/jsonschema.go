@@ -0,0 +1,316 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// jsonSchema is the supported subset of JSON Schema: type, enum, properties,
+// required, additionalProperties, items, min/maxLength, pattern,
+// minimum/maximum and min/maxItems.
+type jsonSchema struct {
+	Type                 schemaTypes            `json:"type"`
+	Enum                 []any                  `json:"enum"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *schemaOrBool          `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	Pattern              string                 `json:"pattern"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	MinItems             *int                   `json:"minItems"`
+	MaxItems             *int                   `json:"maxItems"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes accepts "type" as a string or a list of strings.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(t))
+}
+
+// schemaOrBool holds additionalProperties: false forbids extra properties and
+// a schema validates them.
+type schemaOrBool struct {
+	allowed bool
+	schema  *jsonSchema
+}
+
+func (s *schemaOrBool) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &s.allowed); err == nil {
+		return nil
+	}
+	s.allowed = true
+	return json.Unmarshal(b, &s.schema)
+}
+
+var (
+	jsonSchemasMu sync.RWMutex
+	jsonSchemas   = map[string]*jsonSchema{}
+)
+
+func init() {
+	// Violations are reported under sub-paths of the field (see
+	// schemaFieldErrors); this message covers documents that are not JSON.
+	mustRegister("json_schema", matchesJSONSchema, map[string]string{DefaultMessageLocale: "must be valid JSON"})
+}
+
+// RegisterJSONSchema registers a JSON Schema document under name for the
+// `json_schema=<name>` tag, which validates string (or []byte) fields carrying
+// JSON documents:
+//
+//	err := validate.RegisterJSONSchema("template_v1", schemaJSON)
+//
+//	type Webhook struct {
+//		Template string `json:"template" validate:"required,json_schema=template_v1"`
+//	}
+//
+// Violations are reported per location inside the document, e.g.
+// {"template.body": "is required"}. Only a subset of JSON Schema is
+// supported; unknown keywords are ignored. Registering a name again replaces
+// it.
+func RegisterJSONSchema(name string, schema []byte) error {
+	var s jsonSchema
+	if err := json.Unmarshal(schema, &s); err != nil {
+		return fmt.Errorf("validate: RegisterJSONSchema(%q): %w", name, err)
+	}
+	if err := s.compile(); err != nil {
+		return fmt.Errorf("validate: RegisterJSONSchema(%q): %w", name, err)
+	}
+	jsonSchemasMu.Lock()
+	jsonSchemas[name] = &s
+	jsonSchemasMu.Unlock()
+	return nil
+}
+
+// compile prepares the patterns of s and its sub-schemas.
+func (s *jsonSchema) compile() error {
+	if s == nil {
+		return nil
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.pattern = re
+	}
+	for _, p := range s.Properties {
+		if err := p.compile(); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil {
+		if err := s.AdditionalProperties.schema.compile(); err != nil {
+			return err
+		}
+	}
+	return s.Items.compile()
+}
+
+// matchesJSONSchema accepts documents valid against the schema named in the
+// parameter. Unknown schemas reject everything.
+func matchesJSONSchema(fl validator.FieldLevel) bool {
+	violations, ok := schemaViolations(fl.Field().Interface(), fl.Param())
+	return ok && len(violations) == 0
+}
+
+// schemaViolations decodes a string or []byte document and validates it,
+// returning messages keyed by path inside the document ("" for the root).
+// ok is false when the document is not JSON or the schema is unknown.
+func schemaViolations(value any, name string) (map[string]string, bool) {
+	var doc []byte
+	switch v := value.(type) {
+	case string:
+		doc = []byte(v)
+	case []byte:
+		doc = v
+	case json.RawMessage:
+		doc = v
+	default:
+		return nil, false
+	}
+	jsonSchemasMu.RLock()
+	s := jsonSchemas[name]
+	jsonSchemasMu.RUnlock()
+	if s == nil {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return nil, false
+	}
+	out := map[string]string{}
+	s.validate(v, "", out)
+	return out, true
+}
+
+// schemaFieldErrors expands a json_schema failure into messages keyed by the
+// field joined with paths inside the document, e.g. "template.body".
+func schemaFieldErrors(field string, fe validator.FieldError) (map[string]string, bool) {
+	violations, ok := schemaViolations(fe.Value(), fe.Param())
+	if !ok || len(violations) == 0 {
+		return nil, false
+	}
+	return prefixPaths(field, violations), true
+}
+
+// prefixPaths keys violations by field joined with their path; the root
+// path ("") maps to field itself.
+func prefixPaths(field string, violations map[string]string) map[string]string {
+	out := make(map[string]string, len(violations))
+	for path, msg := range violations {
+		if path == "" {
+			out[field] = msg
+			continue
+		}
+		out[field+"."+path] = msg
+	}
+	return out
+}
+
+func (s *jsonSchema) validate(v any, path string, out map[string]string) {
+	if _, done := out[path]; done {
+		return
+	}
+	if len(s.Type) > 0 && !s.Type.match(v) {
+		out[path] = "must be of type " + strings.Join(s.Type, " or ")
+		return
+	}
+	if len(s.Enum) > 0 && !s.inEnum(v) {
+		labels := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			labels[i] = fmt.Sprint(e)
+		}
+		out[path] = "must be one of " + strings.Join(labels, ", ")
+		return
+	}
+	switch v := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(v)
+		switch {
+		case s.MinLength != nil && n < *s.MinLength:
+			out[path] = fmt.Sprintf("must be at least %d characters", *s.MinLength)
+		case s.MaxLength != nil && n > *s.MaxLength:
+			out[path] = fmt.Sprintf("must be at most %d characters", *s.MaxLength)
+		case s.pattern != nil && !s.pattern.MatchString(v):
+			out[path] = "has an invalid format"
+		}
+	case json.Number:
+		f, _ := v.Float64()
+		switch {
+		case s.Minimum != nil && f < *s.Minimum:
+			out[path] = "must be greater than or equal to " + formatSchemaNumber(*s.Minimum)
+		case s.Maximum != nil && f > *s.Maximum:
+			out[path] = "must be less than or equal to " + formatSchemaNumber(*s.Maximum)
+		}
+	case []any:
+		switch {
+		case s.MinItems != nil && len(v) < *s.MinItems:
+			out[path] = fmt.Sprintf("must contain at least %d items", *s.MinItems)
+		case s.MaxItems != nil && len(v) > *s.MaxItems:
+			out[path] = fmt.Sprintf("must contain at most %d items", *s.MaxItems)
+		case s.Items != nil:
+			for i, item := range v {
+				s.Items.validate(item, joinPath(path, strconv.Itoa(i)), out)
+			}
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				out[joinPath(path, name)] = "is required"
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if p, ok := s.Properties[k]; ok {
+				p.validate(v[k], joinPath(path, k), out)
+				continue
+			}
+			switch ap := s.AdditionalProperties; {
+			case ap == nil:
+			case !ap.allowed:
+				out[joinPath(path, k)] = "unexpected field"
+			case ap.schema != nil:
+				ap.schema.validate(v[k], joinPath(path, k), out)
+			}
+		}
+	}
+}
+
+func (t schemaTypes) match(v any) bool {
+	for _, typ := range t {
+		switch v := v.(type) {
+		case nil:
+			if typ == "null" {
+				return true
+			}
+		case bool:
+			if typ == "boolean" {
+				return true
+			}
+		case string:
+			if typ == "string" {
+				return true
+			}
+		case json.Number:
+			if typ == "number" {
+				return true
+			}
+			if f, err := v.Float64(); typ == "integer" && err == nil && f == math.Trunc(f) {
+				return true
+			}
+		case []any:
+			if typ == "array" {
+				return true
+			}
+		case map[string]any:
+			if typ == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inEnum compares v with the enum values; numbers compare by value.
+func (s *jsonSchema) inEnum(v any) bool {
+	if n, ok := v.(json.Number); ok {
+		f, _ := n.Float64()
+		v = f
+	}
+	for _, e := range s.Enum {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatSchemaNumber(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
@@ -0,0 +1,76 @@
+package validate
+
+import "testing"
+
+const testTemplateSchema = `{
+	"type": "object",
+	"required": ["subject", "body"],
+	"additionalProperties": false,
+	"properties": {
+		"subject": {"type": "string", "minLength": 1, "maxLength": 10},
+		"body": {"type": "string"},
+		"priority": {"type": "integer", "minimum": 1, "maximum": 5},
+		"channel": {"enum": ["email", "sms"]},
+		"tags": {"type": "array", "maxItems": 3, "items": {"type": "string", "pattern": "^[a-z]+$"}}
+	}
+}`
+
+func TestJSONSchema_SubPathErrors(t *testing.T) {
+	if err := RegisterJSONSchema("test_template", []byte(testTemplateSchema)); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	defer func() {
+		jsonSchemasMu.Lock()
+		delete(jsonSchemas, "test_template")
+		jsonSchemasMu.Unlock()
+	}()
+	type webhook struct {
+		Template string `json:"template" validate:"required,json_schema=test_template"`
+	}
+
+	ok := webhook{Template: `{"subject":"hi","body":"x","priority":2,"channel":"sms","tags":["a"]}`}
+	if err := Struct(ok); err != nil {
+		t.Fatalf("expected valid template, got %v", err)
+	}
+
+	bad := webhook{Template: `{"subject":"much too long","priority":1.5,"channel":"fax","tags":["ok","No"],"extra":1}`}
+	fe := ToFieldErrors(Struct(bad))
+	want := map[string]string{
+		"template.body":     "is required",
+		"template.subject":  "must be at most 10 characters",
+		"template.priority": "must be of type integer",
+		"template.channel":  "must be one of email, sms",
+		"template.tags.1":   "has an invalid format",
+		"template.extra":    "unexpected field",
+	}
+	if len(fe) != len(want) {
+		t.Fatalf("expected %v, got %v", want, fe)
+	}
+	for k, msg := range want {
+		if fe[k] != msg {
+			t.Fatalf("%s: expected %q, got %q (all: %v)", k, msg, fe[k], fe)
+		}
+	}
+
+	if fe := ToFieldErrors(Struct(webhook{Template: `[]`})); fe["template"] != "must be of type object" {
+		t.Fatalf("expected root error under the field, got %v", fe)
+	}
+	if fe := ToFieldErrors(Struct(webhook{Template: `{"subject":`})); fe["template"] != "must be valid JSON" {
+		t.Fatalf("expected invalid JSON message, got %v", fe)
+	}
+	if Var("template", []byte(`{"subject":"a","body":"b"}`), "json_schema=test_template") != nil {
+		t.Fatalf("expected []byte documents to validate")
+	}
+	if Var("template", `{}`, "json_schema=missing") == nil {
+		t.Fatalf("expected unknown schema to fail")
+	}
+}
+
+func TestRegisterJSONSchema_Invalid(t *testing.T) {
+	if RegisterJSONSchema("test_bad", []byte(`{"type":`)) == nil {
+		t.Fatalf("expected malformed schema to fail")
+	}
+	if RegisterJSONSchema("test_bad", []byte(`{"properties":{"a":{"pattern":"("}}}`)) == nil {
+		t.Fatalf("expected bad pattern to fail")
+	}
+}
@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// jsonTagInfo is the parsed json tag of a field.
+type jsonTagInfo struct {
+	name string // tag name, "" when the tag has none
+	skip bool   // json:"-"
+}
+
+// jsonTagIndex caches parsed json tags by tag, shared by the tag-name func
+// (jsonTagName) and the converters and binders (jsonName), so each distinct
+// tag of the hot request types is parsed once. reflect.StructField is not
+// comparable, so the raw tag is the key.
+var jsonTagIndex sync.Map // reflect.StructTag -> jsonTagInfo
+
+// jsonTagOf returns the parsed json tag of sf.
+func jsonTagOf(sf reflect.StructField) jsonTagInfo {
+	if info, ok := jsonTagIndex.Load(sf.Tag); ok {
+		return info.(jsonTagInfo)
+	}
+	info := parseJSONTag(sf.Tag)
+	jsonTagIndex.Store(sf.Tag, info)
+	return info
+}
+
+func parseJSONTag(tag reflect.StructTag) jsonTagInfo {
+	name, _, _ := strings.Cut(tag.Get("json"), ",")
+	if name == "-" {
+		return jsonTagInfo{skip: true}
+	}
+	return jsonTagInfo{name: name}
+}
+
+// jsonTagName names fields by their json tag.
+func jsonTagName(sf reflect.StructField) string {
+	if info := jsonTagOf(sf); !info.skip {
+		return info.name
+	}
+	return ""
+}
+
+// jsonName returns the json tag name of a field, its Go name when untagged,
+// or "" when the field is excluded with json:"-".
+func jsonName(sf reflect.StructField) string {
+	info := jsonTagOf(sf)
+	switch {
+	case info.skip:
+		return ""
+	case info.name == "":
+		return sf.Name
+	}
+	return info.name
+}
@@ -0,0 +1,74 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+)
+
+type jsonTagsModel struct {
+	ID        string `json:"id" validate:"required,uuid4"`
+	Email     string `json:"email,omitempty" validate:"required,email"`
+	CreatedAt string `db:"created_at" json:"created_at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Secret    string `json:"-"`
+	Plain     string
+	Opts      string `json:",omitempty"`
+}
+
+func TestJSONTagIndex(t *testing.T) {
+	rt := reflect.TypeOf(jsonTagsModel{})
+	cases := []struct {
+		field, tagName, name string
+	}{
+		{"ID", "id", "id"},
+		{"Email", "email", "email"},
+		{"CreatedAt", "created_at", "created_at"},
+		{"Secret", "", ""},
+		{"Plain", "", "Plain"},
+		{"Opts", "", "Opts"},
+	}
+	for i := 0; i < 2; i++ { // second pass is served from the index
+		for _, c := range cases {
+			sf, _ := rt.FieldByName(c.field)
+			if got := jsonTagName(sf); got != c.tagName {
+				t.Fatalf("jsonTagName(%s) = %q, want %q", c.field, got, c.tagName)
+			}
+			if got := jsonName(sf); got != c.name {
+				t.Fatalf("jsonName(%s) = %q, want %q", c.field, got, c.name)
+			}
+		}
+	}
+	// Untagged fields share the empty tag; the Go name is not cached.
+	type other struct{ Count int }
+	if got := jsonName(reflect.TypeOf(other{}).Field(0)); got != "Count" {
+		t.Fatalf("expected Go name for untagged field, got %q", got)
+	}
+}
+
+// BenchmarkJSONTag compares parsing the json tags of a hot request type on
+// every lookup with the shared index used by the tag-name func and the
+// converters.
+func BenchmarkJSONTag(b *testing.B) {
+	rt := reflect.TypeOf(jsonTagsModel{})
+	fields := make([]reflect.StructField, rt.NumField())
+	for i := range fields {
+		fields[i] = rt.Field(i)
+	}
+	var sink jsonTagInfo
+	b.Run("parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, sf := range fields {
+				sink = parseJSONTag(sf.Tag)
+			}
+		}
+	})
+	b.Run("index", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, sf := range fields {
+				sink = jsonTagOf(sf)
+			}
+		}
+	})
+	_ = sink
+}
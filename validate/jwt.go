@@ -0,0 +1,84 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	mustRegister("jwt_claims", hasJWTClaims, map[string]string{DefaultMessageLocale: "must be a JWT with the expected claims"})
+}
+
+// hasJWTClaims decodes a JWT's payload, without verifying the signature, and
+// checks the ";"-separated claims of the parameter: `name=value` requires the
+// claim to equal value (or, for array claims such as "aud", to contain it) and
+// a bare `name` requires the claim to be present:
+//
+//	Token string `json:"token" validate:"required,jwt_claims=aud=api;iss=https://auth.example.com;sub"`
+//
+// It only checks the token's shape and intent; authenticate tokens with a
+// verifying library.
+func hasJWTClaims(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	claims, ok := jwtClaims(field.String())
+	if !ok {
+		return false
+	}
+	for _, want := range strings.Split(fl.Param(), ";") {
+		name, value, hasValue := strings.Cut(strings.TrimSpace(want), "=")
+		if name == "" {
+			continue
+		}
+		got, present := claims[name]
+		if !present || hasValue && !claimMatches(got, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// jwtClaims decodes the payload of a compact JWS with a JSON object payload.
+func jwtClaims(token string) (map[string]any, bool) {
+	if bakedIn.Var(token, "jwt") != nil {
+		return nil, false
+	}
+	parts := strings.Split(token, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var claims map[string]any
+	if err := dec.Decode(&claims); err != nil || claims == nil {
+		return nil, false
+	}
+	return claims, true
+}
+
+// claimMatches compares a claim with an expected value by its string form;
+// arrays match if any element does.
+func claimMatches(claim any, want string) bool {
+	if list, ok := claim.([]any); ok {
+		for _, item := range list {
+			if claimMatches(item, want) {
+				return true
+			}
+		}
+		return false
+	}
+	switch claim.(type) {
+	case string, json.Number, bool:
+		return fmt.Sprint(claim) == want
+	}
+	return false
+}
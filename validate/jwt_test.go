@@ -0,0 +1,39 @@
+package validate
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func testJWT(payload string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(payload)) + ".c2ln"
+}
+
+func TestJWTClaims(t *testing.T) {
+	const tag = "jwt_claims=aud=api;iss=https://auth.example.com;sub"
+	cases := []struct {
+		value any
+		tag   string
+		ok    bool
+	}{
+		{testJWT(`{"aud":"api","iss":"https://auth.example.com","sub":"u1"}`), tag, true},
+		{testJWT(`{"aud":["web","api"],"iss":"https://auth.example.com","sub":"u1"}`), tag, true},
+		{testJWT(`{"aud":"web","iss":"https://auth.example.com","sub":"u1"}`), tag, false},
+		{testJWT(`{"aud":"api","iss":"https://auth.example.com"}`), tag, false},
+		{testJWT(`{"aud":"api","iss":"https://evil.example.com","sub":"u1"}`), tag, false},
+		{testJWT(`{"ver":2,"admin":true}`), "jwt_claims=ver=2;admin=true", true},
+		{testJWT(`{"ver":{"major":2}}`), "jwt_claims=ver=2", false},
+		{testJWT(`["not","claims"]`), "jwt_claims=sub", false},
+		{"not-a-token", "jwt_claims=sub", false},
+		{1, "jwt_claims=sub", false},
+	}
+	for _, c := range cases {
+		if err := Var("token", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%v %s: expected ok=%v, got %v", c.value, c.tag, c.ok, err)
+		}
+	}
+	if got := ToFieldErrors(Var("token", "x", tag))["token"]; got != "must be a JWT with the expected claims" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
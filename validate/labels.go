@@ -0,0 +1,120 @@
+package validate
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+var (
+	labelsMu    sync.RWMutex
+	fieldLabels = map[string]map[string]string{}
+)
+
+// RegisterFieldLabel sets the display names of a field by locale, used when
+// messages refer to another field (e.g. "is required when payment method is
+// card" for required_if=PaymentMethod card). name is the Go field name as
+// written in tag parameters; nested fields use dotted names. Passing a nil or
+// empty map removes the labels:
+//
+//	validate.RegisterFieldLabel("PaymentMethod", map[string]string{
+//		"en": "payment method",
+//		"es": "método de pago",
+//	})
+//
+// Fields without a label are shown in snake_case ("payment_method"), which
+// matches the json names of most APIs.
+func RegisterFieldLabel(name string, labels map[string]string) {
+	labelsMu.Lock()
+	defer labelsMu.Unlock()
+	if len(labels) == 0 {
+		delete(fieldLabels, name)
+		return
+	}
+	m := make(map[string]string, len(labels))
+	for locale, label := range labels {
+		m[strings.ToLower(locale)] = label
+	}
+	fieldLabels[name] = m
+}
+
+// FieldLabel returns the display name of the Go field name in locale: its
+// registered label (falling back to DefaultMessageLocale), or name in
+// snake_case.
+func FieldLabel(name, locale string) string {
+	labelsMu.RLock()
+	m := fieldLabels[name]
+	label := m[strings.ToLower(locale)]
+	if label == "" {
+		label = m[DefaultMessageLocale]
+	}
+	labelsMu.RUnlock()
+	if label != "" {
+		return label
+	}
+	parts := strings.Split(name, ".")
+	for i, p := range parts {
+		parts[i] = snakeCase(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+// Context key for storing request-scoped field labels.
+type ctxKeyFieldLabels struct{}
+
+// WithFieldLabels attaches field labels to a non-nil context that override
+// the registered ones (see RegisterFieldLabel) in messages resolved with it,
+// e.g. for white-label products renaming "workspace" to "team":
+//
+//	ctx = validate.WithFieldLabels(ctx, map[string]string{"WorkspaceID": "team"})
+//	// required_without=WorkspaceID -> "is required when team is missing"
+//
+// Keys are Go field names as written in tag parameters; a label also
+// replaces the {field} placeholder of messages for that field. Labels apply
+// in every locale, so tenant middleware should pick them per locale.
+func WithFieldLabels(ctx context.Context, labels map[string]string) context.Context {
+	return context.WithValue(ctx, ctxKeyFieldLabels{}, labels)
+}
+
+// contextFieldLabels returns the labels attached with WithFieldLabels.
+func contextFieldLabels(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	labels, _ := ctx.Value(ctxKeyFieldLabels{}).(map[string]string)
+	return labels
+}
+
+// labelFunc returns the label of a Go field name in locale, preferring the
+// labels of ctx over the registered ones.
+func labelFunc(ctx context.Context, locale string) func(name string) string {
+	labels := contextFieldLabels(ctx)
+	return func(name string) string {
+		if label := labels[name]; label != "" {
+			return label
+		}
+		return FieldLabel(name, locale)
+	}
+}
+
+// defaultLabel names fields in the built-in (English) messages.
+func defaultLabel(name string) string { return FieldLabel(name, DefaultMessageLocale) }
+
+// snakeCase converts a Go identifier to snake_case, keeping initialisms
+// together: "TenantID" -> "tenant_id", "URLPath" -> "url_path".
+func snakeCase(s string) string {
+	r := []rune(s)
+	var b strings.Builder
+	for i, c := range r {
+		if unicode.IsUpper(c) {
+			if i > 0 && (unicode.IsLower(r[i-1]) || unicode.IsDigit(r[i-1]) ||
+				unicode.IsUpper(r[i-1]) && i+1 < len(r) && unicode.IsLower(r[i+1])) {
+				b.WriteByte('_')
+			}
+			c = unicode.ToLower(c)
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFieldLabel(t *testing.T) {
+	RegisterFieldLabel("Workspace", map[string]string{"en": "workspace", "ES": "espacio de trabajo"})
+	defer RegisterFieldLabel("Workspace", nil)
+	if l := FieldLabel("Workspace", "es"); l != "espacio de trabajo" {
+		t.Fatalf("expected localized label, got %q", l)
+	}
+	if l := FieldLabel("Workspace", "fr"); l != "workspace" {
+		t.Fatalf("expected default-locale label, got %q", l)
+	}
+	for in, want := range map[string]string{
+		"TenantID":          "tenant_id",
+		"URLPath":           "url_path",
+		"PaymentMethod":     "payment_method",
+		"Address.ZipCode":   "address.zip_code",
+		"already_snake":     "already_snake",
+		"Line2":             "line2",
+		"HTTPSProxyEnabled": "https_proxy_enabled",
+	} {
+		if got := FieldLabel(in, "en"); got != want {
+			t.Fatalf("FieldLabel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWithFieldLabels(t *testing.T) {
+	type invite struct {
+		WorkspaceID string `json:"workspace_id"`
+		Email       string `json:"email" validate:"required_without=WorkspaceID"`
+		Confirm     string `json:"confirm" validate:"eqfield=Email"`
+	}
+	err := Struct(invite{Confirm: "x"})
+	ctx := WithFieldLabels(context.Background(), map[string]string{"WorkspaceID": "team", "Email": "contact address"})
+	got := ToFieldErrorsWithContext(ctx, err)
+	if got["email"] != "is required when team is missing" {
+		t.Fatalf("unexpected email message: %q", got["email"])
+	}
+	if got["confirm"] != "must match contact address" {
+		t.Fatalf("unexpected confirm message: %q", got["confirm"])
+	}
+	// Without overrides the registered or snake_case names are used.
+	if got := ToFieldErrors(err); got["email"] != "is required when workspace_id is missing" {
+		t.Fatalf("unexpected default message: %q", got["email"])
+	}
+
+	e := NewEngine()
+	e.RegisterMessages("eqfield", map[string]string{"en": "{field} must match {label}"})
+	err = e.Struct(invite{Email: "a@b.co", Confirm: "x"})
+	got = ToFieldErrorsWithContext(WithEngine(WithFieldLabels(context.Background(),
+		map[string]string{"Email": "contact address", "Confirm": "confirmation"}), e), err)
+	if got["confirm"] != "confirmation must match contact address" {
+		t.Fatalf("unexpected registered message: %q", got["confirm"])
+	}
+}
@@ -0,0 +1,93 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func init() {
+	registerBuiltin(func(e *DefaultEngine) {
+		e.RegisterMessages("latitude", map[string]string{DefaultMessageLocale: "must be a valid latitude"})
+		e.RegisterMessages("longitude", map[string]string{DefaultMessageLocale: "must be a valid longitude"})
+		e.RegisterMessages("latlng", map[string]string{DefaultMessageLocale: "coordinates are required together"})
+	})
+}
+
+// RegisterLatLng registers a struct-level rule for T's coordinate pair, named
+// by Go field names. Errors are reported under the json names: latitudes
+// outside [-90, 90] and longitudes outside [-180, 180] as "latitude" and
+// "longitude" failures, and a pair with only one side set as a "latlng"
+// failure ("coordinates are required together") on the missing side:
+//
+//	type Place struct {
+//		Lat *float64 `json:"lat"`
+//		Lng *float64 `json:"lng"`
+//	}
+//
+//	validate.RegisterLatLng[Place]("Lat", "Lng")
+//
+// Fields may be floats, integers or pointers to them. Pointers are set when
+// non-nil, other fields when non-zero, so use pointers if 0,0 is a valid
+// location. Add `required` to the fields to require the pair itself. It
+// panics if T has no such numeric fields.
+func RegisterLatLng[T any](lat, lng string) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	latField := coordinateField(t, lat)
+	lngField := coordinateField(t, lng)
+	RegisterStructRule(func(v T, rep *StructReporter) {
+		rv := reflect.ValueOf(v)
+		la, laSet := coordinate(rv, latField.Index)
+		ln, lnSet := coordinate(rv, lngField.Index)
+		latName, lngName := jsonName(latField), jsonName(lngField)
+		switch {
+		case laSet && !lnSet:
+			rep.AddFieldTag(lngName, "latlng", "")
+		case lnSet && !laSet:
+			rep.AddFieldTag(latName, "latlng", "")
+		}
+		if laSet && !(la >= -90 && la <= 90) {
+			rep.AddFieldTag(latName, "latitude", "")
+		}
+		if lnSet && !(ln >= -180 && ln <= 180) {
+			rep.AddFieldTag(lngName, "longitude", "")
+		}
+	})
+}
+
+// coordinateField looks up a numeric struct field of t by Go name.
+func coordinateField(t reflect.Type, name string) reflect.StructField {
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: RegisterLatLng: %v is not a struct", t))
+	}
+	sf, ok := t.FieldByName(name)
+	if !ok {
+		panic(fmt.Sprintf("validate: RegisterLatLng: %v has no field %s", t, name))
+	}
+	switch derefType(sf.Type).Kind() {
+	case reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return sf
+	}
+	panic(fmt.Sprintf("validate: RegisterLatLng: %v.%s is not numeric", t, name))
+}
+
+// coordinate returns the value of a coordinate field and whether it is set.
+// Fields behind nil embedded pointers are unset.
+func coordinate(rv reflect.Value, index []int) (float64, bool) {
+	v, err := rv.FieldByIndexErr(index)
+	if err != nil {
+		return 0, false
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	} else if v.IsZero() {
+		return 0, false
+	}
+	if v.CanFloat() {
+		return v.Float(), true
+	}
+	return float64(v.Int()), true
+}
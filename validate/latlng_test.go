@@ -0,0 +1,69 @@
+package validate
+
+import "testing"
+
+type testPlace struct {
+	Name string   `json:"name"`
+	Lat  *float64 `json:"lat"`
+	Lng  *float64 `json:"lng"`
+}
+
+type testStop struct {
+	Latitude  float32 `json:"latitude"`
+	Longitude int     `json:"longitude"`
+}
+
+func init() {
+	RegisterLatLng[testPlace]("Lat", "Lng")
+	RegisterLatLng[testStop]("Latitude", "Longitude")
+}
+
+func TestRegisterLatLng(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	cases := []struct {
+		place testPlace
+		want  map[string]string
+	}{
+		{testPlace{}, map[string]string{}},
+		{testPlace{Lat: f(0), Lng: f(0)}, map[string]string{}},
+		{testPlace{Lat: f(52.5), Lng: f(13.4)}, map[string]string{}},
+		{testPlace{Lat: f(91), Lng: f(-181)}, map[string]string{"lat": "must be a valid latitude", "lng": "must be a valid longitude"}},
+		{testPlace{Lat: f(10)}, map[string]string{"lng": "coordinates are required together"}},
+		{testPlace{Lng: f(200)}, map[string]string{"lat": "coordinates are required together", "lng": "must be a valid longitude"}},
+	}
+	for _, c := range cases {
+		got := ToFieldErrors(Struct(c.place))
+		if len(got) != len(c.want) {
+			t.Fatalf("%+v: expected %v, got %v", c.place, c.want, got)
+		}
+		for k, msg := range c.want {
+			if got[k] != msg {
+				t.Fatalf("%+v: expected %v, got %v", c.place, c.want, got)
+			}
+		}
+	}
+
+	if got := ToFieldErrors(Struct(testStop{Latitude: -95, Longitude: 10})); got["latitude"] != "must be a valid latitude" || len(got) != 1 {
+		t.Fatalf("expected latitude error for value fields, got %v", got)
+	}
+	if got := ToFieldErrors(Struct(testStop{Longitude: 10})); got["latitude"] != "coordinates are required together" {
+		t.Fatalf("expected zero value field to count as missing, got %v", got)
+	}
+}
+
+func TestRegisterLatLng_PanicsOnBadFields(t *testing.T) {
+	for _, fn := range []func(){
+		func() { RegisterLatLng[testPlace]("Lat", "Missing") },
+		func() { RegisterLatLng[testPlace]("Name", "Lng") },
+		func() { RegisterLatLng[string]("Lat", "Lng") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected panic")
+				}
+			}()
+			fn()
+		}()
+	}
+}
@@ -0,0 +1,135 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/goflash/flash/v2/ctx"
+)
+
+// readLimitedBody buffers the request body, enforcing max bytes (0 = no
+// limit), and replaces it so later binders can read it again.
+func readLimitedBody(c ctx.Ctx, max int64) ([]byte, FieldErrors) {
+	r := c.Request()
+	if max > 0 && r.ContentLength > max {
+		return nil, bodyTooLarge(max)
+	}
+	if r.Body == nil {
+		return nil, nil
+	}
+	var src io.Reader = r.Body
+	if max > 0 {
+		src = io.LimitReader(r.Body, max+1)
+	}
+	data, err := io.ReadAll(src)
+	_ = r.Body.Close()
+	if err != nil {
+		return nil, FieldErrors{"_error": err.Error()}
+	}
+	if max > 0 && int64(len(data)) > max {
+		return nil, bodyTooLarge(max)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func bodyTooLarge(max int64) FieldErrors {
+	return FieldErrors{"_error": fmt.Sprintf("request body must not exceed %d bytes", max)}
+}
+
+func tooManyFields(max int) FieldErrors {
+	return FieldErrors{"_error": fmt.Sprintf("request must not contain more than %d fields", max)}
+}
+
+func tooManyItems(key string, max int) FieldErrors {
+	if key == "" {
+		key = "_error"
+	}
+	return FieldErrors{key: fmt.Sprintf("must contain at most %d items", max)}
+}
+
+// checkValueLimits applies MaxFields (distinct keys) and MaxArrayLength
+// (values per key) to query or form values.
+func checkValueLimits(values url.Values, maxFields, maxItems int) FieldErrors {
+	if maxFields > 0 && len(values) > maxFields {
+		return tooManyFields(maxFields)
+	}
+	if maxItems > 0 {
+		for k, vs := range values {
+			if len(vs) > maxItems {
+				return tooManyItems(k, maxItems)
+			}
+		}
+	}
+	return nil
+}
+
+// checkJSONLimits walks the JSON tokens of body without decoding it into Go
+// values. maxFields bounds the total number of object keys and maxItems the
+// length of every array, reported under its dotted path. Malformed JSON is
+// left for the binder to report.
+func checkJSONLimits(body []byte, maxFields, maxItems int) FieldErrors {
+	s := jsonScan{dec: json.NewDecoder(bytes.NewReader(body)), maxFields: maxFields, maxItems: maxItems}
+	s.walk("")
+	return s.res
+}
+
+type jsonScan struct {
+	dec                 *json.Decoder
+	maxFields, maxItems int
+	fields              int
+	res                 FieldErrors
+	stop                bool
+}
+
+func (s *jsonScan) walk(path string) {
+	tok, err := s.dec.Token()
+	if err != nil {
+		s.stop = true
+		return
+	}
+	d, ok := tok.(json.Delim)
+	if !ok {
+		return
+	}
+	switch d {
+	case '{':
+		for !s.stop && s.dec.More() {
+			tok, err := s.dec.Token()
+			key, ok := tok.(string)
+			if err != nil || !ok {
+				s.stop = true
+				return
+			}
+			if s.fields++; s.maxFields > 0 && s.fields > s.maxFields {
+				s.res, s.stop = tooManyFields(s.maxFields), true
+				return
+			}
+			s.walk(joinPath(path, key))
+		}
+	case '[':
+		for n := 0; !s.stop && s.dec.More(); n++ {
+			if s.maxItems > 0 && n >= s.maxItems {
+				s.res, s.stop = tooManyItems(path, s.maxItems), true
+				return
+			}
+			s.walk(joinPath(path, strconv.Itoa(n)))
+		}
+	}
+	if !s.stop {
+		if _, err := s.dec.Token(); err != nil {
+			s.stop = true
+		}
+	}
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,78 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/goflash/flash/v2"
+)
+
+func TestBindAndValidate_MaxBodyBytes(t *testing.T) {
+	opts := BindOptions{MaxBodyBytes: 24}
+	_, fe := serveBind(t, http.MethodPost, `{"name":"Ann","age":30,"pad":"xxxxxxxx"}`, "application/json", opts)
+	if fe["_error"] != "request body must not exceed 24 bytes" {
+		t.Fatalf("expected body size error, got %v", fe)
+	}
+	in, fe := serveBind(t, http.MethodPost, `{"name":"A","age":30}`, "application/json", opts)
+	if fe != nil || in.Name != "A" {
+		t.Fatalf("expected small body to bind, got %+v %v", in, fe)
+	}
+	_, fe = serveBind(t, http.MethodPost, "name=Ann&age=30&pad=xxxxxxxxxxxx", "application/x-www-form-urlencoded", opts)
+	if fe["_error"] != "request body must not exceed 24 bytes" {
+		t.Fatalf("expected form body size error, got %v", fe)
+	}
+}
+
+func TestBindAndValidate_MaxFieldsAndArrayLength(t *testing.T) {
+	_, fe := serveBind(t, http.MethodPost, `{"name":"Ann","age":30,"x":{"a":1}}`, "application/json", BindOptions{MaxFields: 3})
+	if fe["_error"] != "request must not contain more than 3 fields" {
+		t.Fatalf("expected field count error, got %v", fe)
+	}
+	_, fe = serveBind(t, http.MethodPost, `{"name":"Ann","age":30,"x":{"tags":[1,2,3]}}`, "application/json", BindOptions{MaxArrayLength: 2})
+	if len(fe) != 1 || fe["x.tags"] != "must contain at most 2 items" {
+		t.Fatalf("expected array length error, got %v", fe)
+	}
+	_, fe = serveBind(t, http.MethodGet, "name=Ann&age=30&age=31&age=32", "", BindOptions{MaxArrayLength: 2})
+	if fe["age"] != "must contain at most 2 items" {
+		t.Fatalf("expected repeated value error, got %v", fe)
+	}
+	_, fe = serveBind(t, http.MethodGet, "name=Ann&age=30&x=1", "", BindOptions{MaxFields: 2})
+	if fe["_error"] != "request must not contain more than 2 fields" {
+		t.Fatalf("expected query field count error, got %v", fe)
+	}
+	in, fe := serveBind(t, http.MethodPost, `{"name":"Ann","age":30}`, "application/json", BindOptions{MaxFields: 2, MaxArrayLength: 1})
+	if fe != nil || in.Age != 30 {
+		t.Fatalf("expected payload within limits to bind, got %+v %v", in, fe)
+	}
+	_, fe = serveBind(t, http.MethodPost, `{"name" 1}`, "application/json", BindOptions{MaxFields: 2})
+	if _, ok := fe["_syntax"]; !ok || len(fe) != 1 {
+		t.Fatalf("expected malformed JSON to be reported by the binder, got %v", fe)
+	}
+}
+
+func TestValidated(t *testing.T) {
+	var (
+		got bindUser
+		fe  FieldErrors
+	)
+	app := flash.New()
+	app.POST("/users", func(c flash.Ctx) error {
+		var err error
+		got, err = Validated[bindUser](c)
+		if _, ok := err.(validator.ValidationErrors); ok {
+			fe = FieldErrors(ToFieldErrorsWithContext(c.Context(), err))
+		}
+		return nil
+	})
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Ann","age":30}`)))
+	if fe != nil || got.Name != "Ann" {
+		t.Fatalf("expected bound value, got %+v %v", got, fe)
+	}
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"age":3}`)))
+	if fe["name"] != "is required" {
+		t.Fatalf("expected validation errors, got %v", fe)
+	}
+}
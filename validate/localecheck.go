@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	localeCheckLogger atomic.Pointer[slog.Logger]
+	localeCheckRoutes sync.Map // route -> struct{}
+)
+
+// SetLocaleCheck makes ToFieldErrorsWithContext warn on logger when it
+// converts an error for an HTTP request that carries no locale, message
+// function or translator, which means messages silently fall back to English.
+// It usually means the i18n middleware (validator.ValidatorI18n) is missing on
+// the route or runs after the code converting errors, e.g. after Enforce.
+//
+// Only contexts of requests handled by validator.Enforce or Require, which
+// record the route (see WithRoute), are checked, and each route is reported
+// once. Other contexts (gRPC and queue adapters, background jobs, ...) are
+// never reported. The check is off by default; validator.ValidatorI18n turns
+// it on with OrderingCheck. Passing nil disables it.
+func SetLocaleCheck(logger *slog.Logger) { localeCheckLogger.Store(logger) }
+
+// checkLocale reports c if it lacks a locale and the check is enabled.
+func checkLocale(c context.Context) {
+	logger := localeCheckLogger.Load()
+	if logger == nil {
+		return
+	}
+	route := RouteFromContext(c)
+	if route == "" {
+		return
+	}
+	if LocaleFromContext(c) != "" || MessageFuncFromContext(c) != nil || TranslatorFromContext(c) != nil {
+		return
+	}
+	if _, seen := localeCheckRoutes.LoadOrStore(route, struct{}{}); seen {
+		return
+	}
+	logger.WarnContext(c, "validation messages fall back to English: no locale in request context; "+
+		"install validator.ValidatorI18n on this route, before Enforce and any middleware converting errors",
+		"route", route)
+}
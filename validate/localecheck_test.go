@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type ctxTestKey struct{}
+
+func TestSetLocaleCheck(t *testing.T) {
+	var logs bytes.Buffer
+	SetLocaleCheck(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer SetLocaleCheck(nil)
+
+	type in struct {
+		Name string `validate:"required"`
+	}
+	err := Struct(in{})
+	users := WithRoute(context.Background(), "POST /locale-check/users")
+	orders := WithRoute(context.Background(), "POST /locale-check/orders")
+	for i := 0; i < 2; i++ {
+		ToFieldErrorsWithContext(users, err)
+		ToFieldErrorsWithContext(orders, err)
+	}
+	if n := strings.Count(logs.String(), "no locale in request context"); n != 2 ||
+		!strings.Contains(logs.String(), `route="POST /locale-check/users"`) ||
+		!strings.Contains(logs.String(), `route="POST /locale-check/orders"`) {
+		t.Fatalf("expected one warning per route, got %q", logs.String())
+	}
+
+	logs.Reset()
+	other := WithRoute(context.Background(), "POST /locale-check/other")
+	ToFieldErrorsWithContext(WithLocale(other, "de"), err)
+	ToFieldErrorsWithContext(context.WithValue(context.Background(), ctxTestKey{}, "grpc"), err)
+	ToFieldErrorsWithContext(context.Background(), err)
+	ToFieldErrorsWithContext(other, nil)
+	if logs.Len() != 0 {
+		t.Fatalf("expected no warnings with a locale, outside routes or without errors, got %q", logs.String())
+	}
+
+	SetLocaleCheck(nil)
+	ToFieldErrorsWithContext(other, err)
+	if logs.Len() != 0 {
+		t.Fatalf("expected no warnings when disabled, got %q", logs.String())
+	}
+}
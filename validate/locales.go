@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// supportedLocales holds the locales set with SetSupportedLocales, normalized
+// to lower case with "-" separators.
+var supportedLocales atomic.Pointer[[]string]
+
+func init() {
+	mustRegister("bcp47", isBCP47, map[string]string{DefaultMessageLocale: "must be a valid language tag, e.g. en-US"})
+	mustRegister("bcp47_supported", isSupportedLocale, map[string]string{DefaultMessageLocale: "must be a supported language: {locales}"})
+}
+
+// SetSupportedLocales sets the locales accepted by `bcp47_supported`, usually
+// the locales the application has translations for. The i18n middleware sets
+// them from ValidatorI18nConfig.SupportedLocales.
+func SetSupportedLocales(locales ...string) {
+	out := make([]string, 0, len(locales))
+	for _, l := range locales {
+		if l = normalizeLocale(l); l != "" {
+			out = append(out, l)
+		}
+	}
+	supportedLocales.Store(&out)
+}
+
+// SupportedLocales returns the locales set with SetSupportedLocales.
+func SupportedLocales() []string {
+	if l := supportedLocales.Load(); l != nil {
+		return append([]string(nil), *l...)
+	}
+	return nil
+}
+
+// isBCP47 accepts well-formed BCP 47 language tags such as "en", "pt-BR" or
+// "zh-Hant-TW".
+func isBCP47(fl validator.FieldLevel) bool {
+	return fl.Field().Kind() == reflect.String && bakedIn.Var(fl.Field().String(), "bcp47_language_tag") == nil
+}
+
+// isSupportedLocale accepts language tags matching a supported locale, either
+// exactly or by their language ("es-MX" when "es" is supported). Without
+// supported locales it accepts any valid tag.
+func isSupportedLocale(fl validator.FieldLevel) bool {
+	if !isBCP47(fl) {
+		return false
+	}
+	supported := SupportedLocales()
+	if len(supported) == 0 {
+		return true
+	}
+	tag := normalizeLocale(fl.Field().String())
+	base, _, _ := strings.Cut(tag, "-")
+	for _, s := range supported {
+		if s == tag || s == base {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeLocale(l string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(l), "_", "-"))
+}
@@ -0,0 +1,45 @@
+package validate
+
+import "testing"
+
+func TestBCP47(t *testing.T) {
+	for _, tag := range []string{"en", "pt-BR", "zh-Hant-TW", "es_MX"} {
+		if err := Var("lang", tag, "bcp47"); err != nil {
+			t.Fatalf("%q: expected valid, got %v", tag, err)
+		}
+	}
+	for _, tag := range []any{"", "english!", "e", 1} {
+		if Var("lang", tag, "bcp47") == nil {
+			t.Fatalf("%v: expected invalid", tag)
+		}
+	}
+	if got := ToFieldErrors(Var("lang", "??", "bcp47"))["lang"]; got != "must be a valid language tag, e.g. en-US" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestBCP47Supported(t *testing.T) {
+	defer SetSupportedLocales()
+	SetSupportedLocales()
+	if Var("lang", "fr-CA", "bcp47_supported") != nil {
+		t.Fatalf("expected any valid tag without supported locales")
+	}
+
+	SetSupportedLocales("en", "pt_BR", " ")
+	if got := SupportedLocales(); len(got) != 2 || got[1] != "pt-br" {
+		t.Fatalf("expected normalized locales, got %v", got)
+	}
+	for _, tag := range []string{"en", "EN-gb", "pt-BR", "pt_br"} {
+		if err := Var("lang", tag, "bcp47_supported"); err != nil {
+			t.Fatalf("%q: expected supported, got %v", tag, err)
+		}
+	}
+	for _, tag := range []string{"pt", "pt-PT", "de", "not a tag"} {
+		if Var("lang", tag, "bcp47_supported") == nil {
+			t.Fatalf("%q: expected unsupported", tag)
+		}
+	}
+	if got := ToFieldErrors(Var("lang", "de", "bcp47_supported"))["lang"]; got != "must be a supported language: en, pt-br" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
@@ -0,0 +1,62 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Map validates schemaless data (e.g. JSON decoded into map[string]any)
+// against rules keyed by field, e.g.
+//
+//	validate.Map(body, map[string]string{
+//		"email":        "required,email",
+//		"address.city": "required",
+//	})
+//
+// Dotted keys reach into nested maps. A missing key validates as nil, so
+// "required" fails and "omitempty" skips. Failures are returned as
+// FieldErrors keyed like rules, with messages resolved like ToFieldErrors.
+func Map(data map[string]any, rules map[string]string) error {
+	return MapCtx(context.Background(), data, rules)
+}
+
+// MapCtx is like Map but resolves messages for the request in ctx (locale and
+// message function) and passes ctx to context-aware validations.
+func MapCtx(ctx context.Context, data map[string]any, rules map[string]string) error {
+	keys := make([]string, 0, len(rules))
+	for k := range rules {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	checks := make([]NamedVar, 0, len(keys))
+	for _, key := range keys {
+		checks = append(checks, NamedVar{Name: key, Value: lookupPath(data, key), Tag: rules[key]})
+	}
+	err := VarsCtx(ctx, checks...)
+	var vErrs validator.ValidationErrors
+	if !errors.As(err, &vErrs) {
+		return err
+	}
+	return FieldErrors(ToFieldErrorsWithContext(ctx, vErrs))
+}
+
+// lookupPath resolves a dotted key in nested maps, returning nil when any
+// segment is missing.
+func lookupPath(data map[string]any, key string) any {
+	var cur any = data
+	for _, part := range strings.Split(key, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if cur, ok = m[part]; !ok {
+			return nil
+		}
+	}
+	return cur
+}
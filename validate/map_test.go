@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	data := map[string]any{
+		"email": "nope",
+		"age":   float64(12),
+		"address": map[string]any{
+			"city": "",
+		},
+	}
+	rules := map[string]string{
+		"email":        "required,email",
+		"age":          "gte=18",
+		"name":         "required",
+		"nickname":     "omitempty,min=2",
+		"address.city": "required",
+		"address.zip":  "omitempty,len=5",
+	}
+	fe, ok := Map(data, rules).(FieldErrors)
+	if !ok {
+		t.Fatalf("expected FieldErrors")
+	}
+	want := map[string]string{
+		"email":        "must be a valid email",
+		"age":          "must be greater than or equal to 18",
+		"name":         "is required",
+		"address.city": "is required",
+	}
+	if len(fe) != len(want) {
+		t.Fatalf("unexpected errors: %v", fe)
+	}
+	for k, msg := range want {
+		if fe[k] != msg {
+			t.Fatalf("field %q: expected %q, got %q", k, msg, fe[k])
+		}
+	}
+
+	data = map[string]any{"email": "a@b.co", "age": 30, "name": "Ann", "address": map[string]any{"city": "Oslo"}}
+	if err := Map(data, rules); err != nil {
+		t.Fatalf("expected valid map, got %v", err)
+	}
+}
+
+func TestMapCtx_LocalizedMessages(t *testing.T) {
+	RegisterMessages("required", map[string]string{"de": "{field} ist erforderlich"})
+	defer RegisterMessages("required", nil)
+
+	ctx := WithLocale(context.Background(), "de")
+	fe, _ := MapCtx(ctx, map[string]any{}, map[string]string{"user.name": "required"}).(FieldErrors)
+	if fe["user.name"] != "user.name ist erforderlich" {
+		t.Fatalf("expected localized message with field name, got %v", fe)
+	}
+}
@@ -0,0 +1,230 @@
+package validate
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultMessageLocale is the key under which the default (English) message of
+// a tag is registered. It is used for every locale without its own entry.
+const DefaultMessageLocale = "en"
+
+// RegisterValidationWithMessage registers a custom validation on the global
+// Validator together with its messages in one call. messages is keyed by
+// locale; the DefaultMessageLocale ("en") entry is the default message used for
+// every locale without a dedicated translation.
+//
+// Messages may reference the field name and tag parameter with {field} and
+// {param}:
+//
+//	validate.RegisterValidationWithMessage("sku", isSKU, map[string]string{
+//		"en": "must be a valid SKU",
+//		"es": "debe ser un SKU válido",
+//	})
+//
+// Registered messages take precedence over message functions (translators
+// usually know nothing about custom tags). The locale is read from the context
+// (see WithLocale), which ValidatorI18n sets per request.
+func RegisterValidationWithMessage(tag string, fn validator.Func, messages map[string]string, callValidationEvenIfNull ...bool) error {
+	return Default().RegisterValidationWithMessage(tag, fn, messages, callValidationEvenIfNull...)
+}
+
+// RegisterMessages sets (or replaces) the messages of a tag without registering
+// a validation, e.g. to override wording of a built-in tag for some locales.
+// tag may include a parameter ("national_id=BR") to word one parameter
+// differently; such messages take precedence over those of the bare tag.
+// Passing a nil or empty map removes the tag's messages.
+func RegisterMessages(tag string, messages map[string]string) {
+	Default().RegisterMessages(tag, messages)
+}
+
+// RegisterValidationWithMessage registers a custom validation together with
+// its messages on e. See the package-level RegisterValidationWithMessage.
+func (e *DefaultEngine) RegisterValidationWithMessage(tag string, fn validator.Func, messages map[string]string, callValidationEvenIfNull ...bool) error {
+	if err := e.RegisterValidation(tag, fn, callValidationEvenIfNull...); err != nil {
+		return err
+	}
+	e.RegisterMessages(tag, messages)
+	return nil
+}
+
+// RegisterMessages sets (or replaces) the messages of a tag on e. Passing a
+// nil or empty map removes the tag's messages.
+func (e *DefaultEngine) RegisterMessages(tag string, messages map[string]string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(messages) == 0 {
+		delete(e.messages, tag)
+	} else {
+		m := make(map[string]string, len(messages))
+		for locale, msg := range messages {
+			m[strings.ToLower(locale)] = msg
+		}
+		e.messages[tag] = m
+	}
+	if e.EnglishOnly() {
+		e.english.Store(e.buildEnglish())
+	}
+}
+
+// SetMessageFunc sets e's fallback message function, used for errors
+// resolved with e in context (see WithEngine) when neither a registered
+// message nor a request-scoped function applies. Unlike the package-level
+// SetMessageFunc it does not affect other engines, so apps with different
+// default locales can share a process. Nil removes it.
+func (e *DefaultEngine) SetMessageFunc(fn func(validator.FieldError) string) {
+	e.mu.Lock()
+	e.messageFunc = fn
+	e.mu.Unlock()
+}
+
+// fallbackMessage renders fe with e's fallback message function, if any.
+func (e *DefaultEngine) fallbackMessage(fe validator.FieldError) string {
+	e.mu.RLock()
+	fn := e.messageFunc
+	e.mu.RUnlock()
+	if fn == nil {
+		return ""
+	}
+	return fn(fe)
+}
+
+// registeredMessage returns the message registered for fe on the engine of ctx
+// in the locale of ctx, falling back to the DefaultMessageLocale entry, with
+// placeholders expanded. {label} is the label (see FieldLabel and
+// WithFieldLabels) of the field named by the parameter, in that locale.
+func registeredMessage(ctx context.Context, fe validator.FieldError) (string, bool) {
+	locale := LocaleFromContext(ctx)
+	return messageEngine(ctx).registeredMessage(fe, locale, labelFunc(ctx, locale), contextFieldLabels(ctx))
+}
+
+func (e *DefaultEngine) registeredMessage(fe validator.FieldError, locale string, label func(string) string, fieldLabels map[string]string) (string, bool) {
+	msg, ok := e.rawMessage(fe.Tag(), fe.Param(), locale)
+	if !ok {
+		return "", false
+	}
+	if strings.Contains(msg, "{label}") {
+		other, _, _ := strings.Cut(fe.Param(), " ")
+		msg = strings.ReplaceAll(msg, "{label}", label(other))
+	}
+	if l := fieldLabels[fe.StructField()]; l != "" {
+		msg = strings.ReplaceAll(msg, "{field}", l)
+	}
+	return expandMessage(msg, fe), true
+}
+
+// rawMessage returns the unexpanded message registered for tag (preferring
+// "tag=param") in locale, falling back to the DefaultMessageLocale entry.
+func (e *DefaultEngine) rawMessage(tag, param, locale string) (string, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	m, ok := e.messages[tag+"="+param]
+	if !ok {
+		m, ok = e.messages[tag]
+	}
+	if !ok {
+		return "", false
+	}
+	msg, ok := m[strings.ToLower(locale)]
+	if !ok || msg == "" {
+		msg, ok = m[DefaultMessageLocale]
+	}
+	return msg, ok && msg != ""
+}
+
+// warningMessage returns the message of tag for a warning, where no
+// FieldError is available: the registered message in the locale of ctx, or
+// the default message.
+func warningMessage(ctx context.Context, tag string) string {
+	if msg, ok := messageEngine(ctx).rawMessage(tag, "", LocaleFromContext(ctx)); ok {
+		return msg
+	}
+	return defaultMessageFor(tag, "")
+}
+
+// expandMessage replaces {field} and {param} placeholders, {from} and {to}
+// with the bounds of range parameters such as "1024-65535", {values} with the
+// allowed values of the enum named by the parameter (see RegisterEnum),
+// {locales} with the supported locales (see SetSupportedLocales) and
+// {invalid} with the offending members of subset_of and bitmask values.
+func expandMessage(msg string, fe validator.FieldError) string {
+	if !strings.Contains(msg, "{") {
+		return msg
+	}
+	if strings.Contains(msg, "{values}") {
+		msg = strings.ReplaceAll(msg, "{values}", enumLabels(fe.Param()))
+	}
+	if strings.Contains(msg, "{locales}") {
+		msg = strings.ReplaceAll(msg, "{locales}", strings.Join(SupportedLocales(), ", "))
+	}
+	if strings.Contains(msg, "{invalid}") {
+		msg = strings.ReplaceAll(msg, "{invalid}", invalidMembers(fe))
+	}
+	from, to, _ := strings.Cut(fe.Param(), "-")
+	return strings.NewReplacer("{field}", fe.Field(), "{param}", fe.Param(), "{from}", from, "{to}", to).Replace(msg)
+}
+
+// Context key for storing the request locale.
+type ctxKeyLocale struct{}
+
+// WithLocale attaches the request locale to a non-nil context. It selects
+// messages registered with RegisterValidationWithMessage.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, ctxKeyLocale{}, strings.ToLower(locale))
+}
+
+// LocaleFromContext returns the locale set with WithLocale, or "".
+func LocaleFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if v, ok := ctx.Value(ctxKeyLocale{}).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// WithLocaleMessageFunc puts locale (lowercased, or defaultLocale when empty)
+// on ctx with WithLocale, unless ctx already carries one, and attaches the
+// message function messageFuncFor returns for it (see WithMessageFunc),
+// falling back to defaultLocale's when locale has none. It is the locale
+// resolution of the ValidatorI18n middleware for adapters that pick the
+// locale themselves, such as the gRPC and Connect interceptors. No message
+// function is attached when messageFuncFor is nil, ctx already carries one,
+// or neither locale has one.
+func WithLocaleMessageFunc(ctx context.Context, locale, defaultLocale string, messageFuncFor func(locale string) func(validator.FieldError) string) context.Context {
+	if locale == "" {
+		locale = defaultLocale
+	} else {
+		locale = strings.ToLower(locale)
+	}
+	if locale != "" && LocaleFromContext(ctx) == "" {
+		ctx = WithLocale(ctx, locale)
+	}
+	if messageFuncFor == nil || MessageFuncFromContext(ctx) != nil {
+		return ctx
+	}
+	mf := messageFuncFor(locale)
+	if mf == nil && locale != defaultLocale {
+		mf = messageFuncFor(defaultLocale)
+	}
+	if mf == nil {
+		return ctx
+	}
+	return WithMessageFunc(ctx, mf)
+}
+
+// PrimaryLanguage returns the primary language of the first entry of an
+// Accept-Language value, e.g. "es-ES,es;q=0.9" -> "es", or "".
+func PrimaryLanguage(acceptLanguage string) string {
+	l := acceptLanguage
+	if idx := strings.IndexAny(l, ",;"); idx >= 0 {
+		l = l[:idx]
+	}
+	if idx := strings.IndexAny(l, "-_"); idx >= 0 {
+		l = l[:idx]
+	}
+	return strings.TrimSpace(l)
+}
@@ -0,0 +1,149 @@
+package validate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	globalValidator "github.com/go-playground/validator/v10"
+)
+
+type product struct {
+	SKU  string `json:"sku" validate:"sku"`
+	Code string `json:"code" validate:"prefixed=AB"`
+}
+
+func init() {
+	isSKU := func(fl globalValidator.FieldLevel) bool { return strings.HasPrefix(fl.Field().String(), "SKU-") }
+	if err := RegisterValidationWithMessage("sku", isSKU, map[string]string{
+		"en": "must be a valid SKU",
+		"ES": "debe ser un SKU válido",
+	}); err != nil {
+		panic(err)
+	}
+	hasPrefix := func(fl globalValidator.FieldLevel) bool { return strings.HasPrefix(fl.Field().String(), fl.Param()) }
+	if err := RegisterValidationWithMessage("prefixed", hasPrefix, map[string]string{
+		"en": "{field} must start with {param}",
+	}); err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisterValidationWithMessage_DefaultEnglish(t *testing.T) {
+	m := ToFieldErrors(Struct(product{SKU: "x", Code: "x"}))
+	if m["sku"] != "must be a valid SKU" {
+		t.Fatalf("expected registered message, got %v", m)
+	}
+	if m["code"] != "code must start with AB" {
+		t.Fatalf("expected placeholders expanded, got %v", m)
+	}
+}
+
+func TestRegisterValidationWithMessage_LocaleFromContext(t *testing.T) {
+	ctx := WithLocale(context.Background(), "es")
+	m := ToFieldErrorsWithContext(ctx, Struct(product{SKU: "x", Code: "AB1"}))
+	if m["sku"] != "debe ser un SKU válido" {
+		t.Fatalf("expected spanish message, got %v", m)
+	}
+	// Unknown locale falls back to the default message.
+	m = ToFieldErrorsWithContext(WithLocale(context.Background(), "fr"), Struct(product{SKU: "x", Code: "AB1"}))
+	if m["sku"] != "must be a valid SKU" {
+		t.Fatalf("expected default message, got %v", m)
+	}
+}
+
+func TestRegisterValidationWithMessage_WinsOverMessageFunc(t *testing.T) {
+	ctx := WithMessageFunc(context.Background(), func(globalValidator.FieldError) string { return "TRANSLATOR" })
+	m := ToFieldErrorsWithContext(ctx, Struct(product{SKU: "x", Code: "AB1"}))
+	if m["sku"] != "must be a valid SKU" {
+		t.Fatalf("expected registered message to win, got %v", m)
+	}
+}
+
+func TestRegisterValidationWithMessage_InvalidTag(t *testing.T) {
+	if err := RegisterValidationWithMessage("", nil, nil); err == nil {
+		t.Fatalf("expected error for empty tag")
+	}
+}
+
+func TestRegisterMessages_OverrideAndRemove(t *testing.T) {
+	type s struct {
+		Name string `json:"name" validate:"required"`
+	}
+	RegisterMessages("required", map[string]string{"en": "can't be blank"})
+	if m := ToFieldErrors(Struct(s{})); m["name"] != "can't be blank" {
+		RegisterMessages("required", nil)
+		t.Fatalf("expected overridden message, got %v", m)
+	}
+	RegisterMessages("required", nil)
+	if m := ToFieldErrors(Struct(s{})); m["name"] != "is required" {
+		t.Fatalf("expected built-in message after removal, got %v", m)
+	}
+	// Locale-only registrations without a default fall through.
+	RegisterMessages("required", map[string]string{"de": "ist erforderlich"})
+	defer RegisterMessages("required", nil)
+	if m := ToFieldErrors(Struct(s{})); m["name"] != "is required" {
+		t.Fatalf("expected built-in message without default entry, got %v", m)
+	}
+}
+
+func TestLocaleFromContext(t *testing.T) {
+	if LocaleFromContext(context.Background()) != "" {
+		t.Fatalf("expected empty locale")
+	}
+	if LocaleFromContext(WithLocale(context.Background(), "PT")) != "pt" {
+		t.Fatalf("expected lowercased locale")
+	}
+}
+
+func TestWithLocaleMessageFunc(t *testing.T) {
+	funcs := map[string]func(globalValidator.FieldError) string{
+		"en": func(globalValidator.FieldError) string { return "EN" },
+		"es": func(globalValidator.FieldError) string { return "ES" },
+	}
+	messageFuncFor := func(l string) func(globalValidator.FieldError) string { return funcs[l] }
+	err := Var("name", "", "required")
+	ctx := context.Background()
+	for locale, want := range map[string]string{"ES": "ES", "de": "EN", "": "EN"} {
+		got := ToFieldErrorsWithContext(WithLocaleMessageFunc(ctx, locale, "en", messageFuncFor), err)
+		if got["name"] != want {
+			t.Fatalf("locale %q: expected %q, got %v", locale, want, got)
+		}
+	}
+	pinned := WithMessageFunc(ctx, func(globalValidator.FieldError) string { return "PINNED" })
+	if got := ToFieldErrorsWithContext(WithLocaleMessageFunc(pinned, "es", "en", messageFuncFor), err); got["name"] != "PINNED" {
+		t.Fatalf("expected the context message func to win, got %v", got)
+	}
+	if MessageFuncFromContext(WithLocaleMessageFunc(ctx, "es", "en", nil)) != nil ||
+		MessageFuncFromContext(WithLocaleMessageFunc(ctx, "de", "fr", messageFuncFor)) != nil {
+		t.Fatalf("expected no message func without messageFuncFor or without a match")
+	}
+}
+
+func TestWithLocaleMessageFunc_Locale(t *testing.T) {
+	e := NewEngine()
+	e.RegisterMessages("required", map[string]string{"es": "es obligatorio"})
+	ctx := WithEngine(context.Background(), e)
+	for locale, want := range map[string]string{"ES": "es", "": "en"} {
+		if got := LocaleFromContext(WithLocaleMessageFunc(ctx, locale, "en", nil)); got != want {
+			t.Fatalf("locale %q: expected %q on ctx, got %q", locale, want, got)
+		}
+	}
+	if got := LocaleFromContext(WithLocaleMessageFunc(WithLocale(ctx, "fr"), "es", "en", nil)); got != "fr" {
+		t.Fatalf("expected the locale of ctx to be kept, got %q", got)
+	}
+	lctx := WithLocaleMessageFunc(ctx, "es", "en", nil)
+	if got := ToFieldErrorsWithContext(lctx, e.StructCtx(lctx, struct {
+		Name string `json:"name" validate:"required"`
+	}{})); got["name"] != "es obligatorio" {
+		t.Fatalf("expected the registered es message, got %v", got)
+	}
+}
+
+func TestPrimaryLanguage(t *testing.T) {
+	for in, want := range map[string]string{"es-ES,es;q=0.9": "es", "fr": "fr", " de_AT ": "de", "": ""} {
+		if got := PrimaryLanguage(in); got != want {
+			t.Fatalf("PrimaryLanguage(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
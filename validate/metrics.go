@@ -0,0 +1,61 @@
+package validate
+
+import "sync/atomic"
+
+// Metric names reported to the hook installed with SetMetrics. All metrics
+// are counters incremented by one per event.
+const (
+	// MetricDeprecatedField counts requests that set a field tagged
+	// `deprecated`. Labels: "type", "field".
+	MetricDeprecatedField = "validator_deprecated_field_total"
+	// MetricShadowFailure counts failures of shadow (log-only) rules.
+	// Labels: "route", "field", "tag".
+	MetricShadowFailure = "validator_shadow_failure_total"
+	// MetricCanaryDivergence counts validations where a Canary's candidate
+	// rules disagree with the current ones. Labels: "kind", "type".
+	MetricCanaryDivergence = "validator_canary_divergence_total"
+	// MetricRemoteError counts remote checks (see RegisterRemote) that failed
+	// or timed out. Labels: "tag".
+	MetricRemoteError = "validator_remote_error_total"
+	// MetricLocaleNotReady counts message lookups for a lazily loaded locale
+	// (see validator.LazyLocales) that fell back because it was not loaded
+	// yet. Labels: "locale".
+	MetricLocaleNotReady = "validator_locale_not_ready_total"
+	// MetricLocaleServed counts requests by the locale they asked for and
+	// the locale whose messages served them (see the i18n middleware).
+	// Labels: "requested" (its base language for unsupported locales, or
+	// "invalid"), "served", and "result": "requested", "default" when the
+	// default locale stood in, or "builtin" when no locale had messages.
+	MetricLocaleServed = "validator_locale_served_total"
+)
+
+// MetricsFunc receives a counter increment for metric with its labels. It must
+// be safe for concurrent use and should not retain labels.
+type MetricsFunc func(metric string, labels map[string]string)
+
+var metricsHook atomic.Pointer[MetricsFunc]
+
+// SetMetrics installs the metrics hook, e.g. adapting to Prometheus counter
+// vectors keyed by metric name. Passing nil disables metrics.
+func SetMetrics(fn MetricsFunc) {
+	if fn == nil {
+		metricsHook.Store(nil)
+		return
+	}
+	metricsHook.Store(&fn)
+}
+
+// ReportMetric reports one event to the metrics hook, if any. Integrations use
+// it to report their own metrics through the same hook.
+func ReportMetric(metric string, labels map[string]string) { incMetric(metric, labels) }
+
+// MetricsEnabled reports whether a metrics hook is installed, so hot paths
+// can skip building labels.
+func MetricsEnabled() bool { return metricsHook.Load() != nil }
+
+// incMetric reports one event to the metrics hook, if any.
+func incMetric(metric string, labels map[string]string) {
+	if fn := metricsHook.Load(); fn != nil {
+		(*fn)(metric, labels)
+	}
+}
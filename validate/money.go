@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	// Messages for RegisterMoney, per currency where the minor unit differs
+	// from 2 decimal places ("money_currency=JPY").
+	registerBuiltin(func(e *DefaultEngine) {
+		e.RegisterMessages("money_currency", map[string]string{DefaultMessageLocale: "{param} amounts can have at most 2 decimal places"})
+		for code, units := range currencyMinorUnits {
+			msg := "{param} amounts can have at most " + strconv.Itoa(int(units)) + " decimal places"
+			if units == 0 {
+				msg = "{param} amounts cannot have decimals"
+			}
+			e.RegisterMessages("money_currency="+code, map[string]string{DefaultMessageLocale: msg})
+		}
+	})
+}
+
+// RegisterMoney registers a struct-level rule for T that checks the decimal
+// places of an amount against the minor unit of the currency in another field
+// (see CurrencyMinorUnits), both named by Go field name:
+//
+//	type Charge struct {
+//		Amount   decimal.Decimal `json:"amount"`
+//		Currency string          `json:"currency" validate:"required,iso4217"`
+//	}
+//
+//	validate.RegisterMoney[Charge]("Amount", "Currency")
+//	// {"amount": "JPY amounts cannot have decimals"}
+//
+// Failures are reported on the amount as "money_currency" with the currency
+// as parameter; translations can be registered per currency under
+// "money_currency=<code>". The amount may be a decimal, string, float or
+// integer field. Empty currencies and unparseable amounts are left to other
+// tags. It panics if T has no such fields.
+func RegisterMoney[T any](amount, currency string) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: RegisterMoney: %v is not a struct", t))
+	}
+	amountField, ok := t.FieldByName(amount)
+	if !ok {
+		panic(fmt.Sprintf("validate: RegisterMoney: %v has no field %s", t, amount))
+	}
+	currencyField, ok := t.FieldByName(currency)
+	if !ok || derefType(currencyField.Type).Kind() != reflect.String {
+		panic(fmt.Sprintf("validate: RegisterMoney: %v has no string field %s", t, currency))
+	}
+	name := jsonName(amountField)
+	RegisterStructRule(func(v T, rep *StructReporter) {
+		rv := reflect.ValueOf(v)
+		cf, err := rv.FieldByIndexErr(currencyField.Index)
+		if err != nil {
+			return
+		}
+		code := reflect.Indirect(cf)
+		if !code.IsValid() || code.String() == "" {
+			return
+		}
+		af, err := rv.FieldByIndexErr(amountField.Index)
+		if err != nil {
+			return
+		}
+		if af.Kind() == reflect.Pointer {
+			if af.IsNil() {
+				return
+			}
+			af = af.Elem()
+		}
+		d, ok := decimalFromField(af)
+		if ok && d.scale > CurrencyMinorUnits(code.String()) {
+			rep.AddFieldTag(name, "money_currency", strings.ToUpper(code.String()))
+		}
+	})
+}
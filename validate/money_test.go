@@ -0,0 +1,72 @@
+package validate
+
+import "testing"
+
+type testCharge struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type testInvoice struct {
+	Total    *string `json:"total"`
+	Currency *string `json:"currency"`
+}
+
+func init() {
+	RegisterMoney[testCharge]("Amount", "Currency")
+	RegisterMoney[testInvoice]("Total", "Currency")
+}
+
+func TestRegisterMoney(t *testing.T) {
+	cases := []struct {
+		amount, currency string
+		want             string
+	}{
+		{"1000", "JPY", ""},
+		{"1000.5", "JPY", "JPY amounts cannot have decimals"},
+		{"10.50", "EUR", ""},
+		{"10.505", "EUR", "EUR amounts can have at most 2 decimal places"},
+		{"1.125", "BHD", ""},
+		{"1.1255", "bhd", "BHD amounts can have at most 3 decimal places"},
+		{"1.5", "", ""},
+	}
+	for _, c := range cases {
+		got := ToFieldErrors(Struct(testCharge{Amount: c.amount, Currency: c.currency}))
+		if got["amount"] != c.want || c.want == "" && len(got) != 0 {
+			t.Fatalf("%s %s: expected %q, got %v", c.amount, c.currency, c.want, got)
+		}
+	}
+
+	s := func(v string) *string { return &v }
+	if got := ToFieldErrors(Struct(testInvoice{Total: s("12.3"), Currency: s("KWD")})); len(got) != 0 {
+		t.Fatalf("expected valid KWD total, got %v", got)
+	}
+	if got := ToFieldErrors(Struct(testInvoice{Total: s("12.3"), Currency: s("ISK")})); got["total"] != "ISK amounts cannot have decimals" {
+		t.Fatalf("expected pointer fields to be checked, got %v", got)
+	}
+	if err := Struct(testInvoice{Currency: s("JPY")}); err != nil {
+		t.Fatalf("expected nil amount to be skipped, got %v", err)
+	}
+}
+
+func TestRegisterMoney_PanicsOnBadFields(t *testing.T) {
+	for _, fn := range []func(){
+		func() { RegisterMoney[testCharge]("Missing", "Currency") },
+		func() {
+			RegisterMoney[struct {
+				Amount   string
+				Currency int
+			}]("Amount", "Currency")
+		},
+		func() { RegisterMoney[int]("Amount", "Currency") },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected panic")
+				}
+			}()
+			fn()
+		}()
+	}
+}
@@ -0,0 +1,75 @@
+package validate
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+)
+
+// MXResolver resolves mail exchangers. *net.Resolver implements it.
+type MXResolver interface {
+	DNSResolver
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// MXChecker is a RemoteValidator reporting whether the domain of an email
+// address accepts mail: it has MX records (other than a null MX), or no MX
+// but an address record (the implicit MX of RFC 5321).
+type MXChecker struct {
+	// Resolver performs lookups. Default: net.DefaultResolver.
+	Resolver MXResolver
+}
+
+// NewMXChecker returns an MXChecker using r (nil for net.DefaultResolver).
+func NewMXChecker(r MXResolver) *MXChecker { return &MXChecker{Resolver: r} }
+
+// Check resolves the domain of email. Addresses without a domain are invalid;
+// unknown domains are invalid; other lookup errors are returned.
+func (m *MXChecker) Check(ctx context.Context, email string) (bool, error) {
+	i := strings.LastIndexByte(email, '@')
+	if i < 0 || i == len(email)-1 {
+		return false, nil
+	}
+	domain := strings.ToLower(email[i+1:])
+	var r MXResolver = net.DefaultResolver
+	if m.Resolver != nil {
+		r = m.Resolver
+	}
+	mxs, err := r.LookupMX(ctx, domain)
+	if err == nil && len(mxs) > 0 {
+		// A null MX (RFC 7505) declares that the domain accepts no mail.
+		return !(len(mxs) == 1 && strings.Trim(mxs[0].Host, ".") == ""), nil
+	}
+	if err != nil {
+		if err = notFoundAsInvalid(err); err != nil {
+			return false, err
+		}
+	}
+	addrs, err := r.LookupHost(ctx, domain)
+	if err != nil {
+		return false, notFoundAsInvalid(err)
+	}
+	return len(addrs) > 0, nil
+}
+
+// RegisterEmailMX registers the `email_mx` tag on the global Validator,
+// backed by an MXChecker using net.DefaultResolver. Unset options default to
+// a 3s timeout, a 1h cache and the message "must be an email address that
+// can receive mail". Lookups use the validation context (see StructCtx).
+func RegisterEmailMX(opts RemoteOptions) error {
+	return Default().RegisterRemote("email_mx", NewMXChecker(nil), emailMXOptions(opts))
+}
+
+func emailMXOptions(opts RemoteOptions) RemoteOptions {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 3 * time.Second
+	}
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = time.Hour
+	}
+	if len(opts.Messages) == 0 {
+		opts.Messages = map[string]string{DefaultMessageLocale: "must be an email address that can receive mail"}
+	}
+	return opts
+}
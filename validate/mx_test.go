@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+type fakeMXResolver struct {
+	fakeResolver
+	mx    map[string][]*net.MX
+	mxErr error
+}
+
+func (r *fakeMXResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	if r.mxErr != nil {
+		return nil, r.mxErr
+	}
+	if mx, ok := r.mx[name]; ok {
+		return mx, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func TestMXChecker(t *testing.T) {
+	r := &fakeMXResolver{
+		fakeResolver: fakeResolver{hosts: map[string][]string{"implicit.io": {"10.0.0.1"}}},
+		mx: map[string][]*net.MX{
+			"example.com": {{Host: "mx1.example.com.", Pref: 10}},
+			"nomail.io":   {{Host: ".", Pref: 0}},
+		},
+	}
+	m := NewMXChecker(r)
+	cases := map[string]bool{
+		"ann@Example.com": true,
+		"ann@implicit.io": true,
+		"ann@nomail.io":   false,
+		"ann@missing.io":  false,
+		"ann@":            false,
+		"ann":             false,
+	}
+	for email, want := range cases {
+		if ok, err := m.Check(context.Background(), email); err != nil || ok != want {
+			t.Fatalf("%q: expected %v, got %v %v", email, want, ok, err)
+		}
+	}
+	r.mxErr = errors.New("servfail")
+	if _, err := m.Check(context.Background(), "ann@example.com"); err == nil {
+		t.Fatalf("expected lookup errors to be returned")
+	}
+}
+
+func TestEmailMXTag(t *testing.T) {
+	r := &fakeMXResolver{mx: map[string][]*net.MX{"example.com": {{Host: "mx.example.com."}}}}
+	e := NewEngine()
+	if err := e.RegisterRemote("email_mx", NewMXChecker(r), emailMXOptions(RemoteOptions{})); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	ctx := WithEngine(context.Background(), e)
+	if err := VarCtx(ctx, "email", "a@example.com", "email_mx"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m := ToFieldErrorsWithContext(ctx, VarCtx(ctx, "email", "a@typo-example.com", "email_mx")); m["email"] != "must be an email address that can receive mail" {
+		t.Fatalf("expected MX failure, got %v", m)
+	}
+	if o := emailMXOptions(RemoteOptions{Messages: map[string]string{"en": "x"}}); o.Messages["en"] != "x" || o.Timeout == 0 || o.CacheTTL == 0 {
+		t.Fatalf("unexpected defaults: %+v", o)
+	}
+}
@@ -0,0 +1,216 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	nationalIDsMu sync.RWMutex
+	nationalIDs   = map[string]func(string) bool{}
+)
+
+func init() {
+	mustRegister("national_id", isNationalID, map[string]string{DefaultMessageLocale: "must be a valid national ID"})
+	registerNationalID("BR", isBRTaxID, map[string]string{DefaultMessageLocale: "must be a valid CPF or CNPJ", "pt": "deve ser um CPF ou CNPJ válido"})
+	registerNationalID("ES", isESNIF, map[string]string{DefaultMessageLocale: "must be a valid NIF or NIE", "es": "debe ser un NIF o NIE válido"})
+	registerNationalID("PT", isPTNIF, map[string]string{DefaultMessageLocale: "must be a valid NIF", "pt": "deve ser um NIF válido"})
+	registerNationalID("US", isUSSSN, map[string]string{DefaultMessageLocale: "must be a valid SSN"})
+	registerNationalID("IN", isAadhaar, map[string]string{DefaultMessageLocale: "must be a valid Aadhaar number"})
+}
+
+// RegisterNationalID adds (or replaces) the validator behind
+// `national_id=<country>` for an ISO 3166-1 alpha-2 country code, with its
+// messages by locale on the global Validator:
+//
+//	validate.RegisterNationalID("CL", isRUT, map[string]string{
+//		"en": "must be a valid RUT",
+//		"es": "debe ser un RUT válido",
+//	})
+//
+// fn receives the value with spaces, dots, dashes and slashes removed and
+// letters upper-cased. Built in: BR (CPF/CNPJ), ES (NIF/NIE), PT (NIF), US
+// (SSN format) and IN (Aadhaar checksum).
+func RegisterNationalID(country string, fn func(id string) bool, messages map[string]string) {
+	country = strings.ToUpper(country)
+	nationalIDsMu.Lock()
+	nationalIDs[country] = fn
+	nationalIDsMu.Unlock()
+	Default().RegisterMessages("national_id="+country, messages)
+}
+
+// registerNationalID registers a built-in country on every engine.
+func registerNationalID(country string, fn func(string) bool, messages map[string]string) {
+	nationalIDs[country] = fn
+	registerBuiltin(func(e *DefaultEngine) {
+		e.RegisterMessages("national_id="+country, messages)
+	})
+}
+
+func isNationalID(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	nationalIDsMu.RLock()
+	fn, ok := nationalIDs[strings.ToUpper(fl.Param())]
+	nationalIDsMu.RUnlock()
+	if !ok {
+		return false
+	}
+	id := strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "", "/", "").Replace(field.String()))
+	return id != "" && fn(id)
+}
+
+// digitsOf returns the digits of s, or nil if s has any other character.
+func digitsOf(s string) []int {
+	d := make([]int, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return nil
+		}
+		d[i] = int(s[i] - '0')
+	}
+	return d
+}
+
+// allSame reports whether all digits are equal (e.g. 111.111.111-11).
+func allSame(d []int) bool {
+	for _, x := range d {
+		if x != d[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// isBRTaxID validates a Brazilian CPF (11 digits) or CNPJ (14 digits).
+func isBRTaxID(id string) bool {
+	d := digitsOf(id)
+	if d == nil || allSame(d) {
+		return false
+	}
+	switch len(d) {
+	case 11:
+		for n := 9; n <= 10; n++ {
+			sum := 0
+			for i := 0; i < n; i++ {
+				sum += d[i] * (n + 1 - i)
+			}
+			if sum*10%11%10 != d[n] {
+				return false
+			}
+		}
+		return true
+	case 14:
+		weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+		for n := 12; n <= 13; n++ {
+			sum := 0
+			for i := 0; i < n; i++ {
+				sum += d[i] * weights[i+13-n]
+			}
+			check := 11 - sum%11
+			if check >= 10 {
+				check = 0
+			}
+			if check != d[n] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isESNIF validates a Spanish DNI-based NIF (8 digits and a letter) or NIE
+// (X, Y or Z, 7 digits and a letter).
+func isESNIF(id string) bool {
+	if len(id) != 9 {
+		return false
+	}
+	if i := strings.IndexByte("XYZ", id[0]); i >= 0 {
+		id = string(rune('0'+i)) + id[1:]
+	}
+	d := digitsOf(id[:8])
+	if d == nil {
+		return false
+	}
+	n := 0
+	for _, x := range d {
+		n = n*10 + x
+	}
+	return "TRWAGMYFPDXBNJZSQVHLCKE"[n%23] == id[8]
+}
+
+// isPTNIF validates a Portuguese NIF (9 digits, mod 11 check digit).
+func isPTNIF(id string) bool {
+	d := digitsOf(id)
+	if len(d) != 9 || !strings.ContainsRune("1235689", rune(id[0])) && !isPTPrefix(id[:2]) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		sum += d[i] * (9 - i)
+	}
+	check := 11 - sum%11
+	if check >= 10 {
+		check = 0
+	}
+	return check == d[8]
+}
+
+func isPTPrefix(p string) bool {
+	switch p {
+	case "45", "70", "71", "72", "74", "75", "77", "79":
+		return true
+	}
+	return false
+}
+
+// isUSSSN validates the format of a US Social Security number: 9 digits with
+// no all-zero group and no area 000, 666 or 900-999.
+func isUSSSN(id string) bool {
+	d := digitsOf(id)
+	if len(d) != 9 {
+		return false
+	}
+	area, group, serial := id[:3], id[3:5], id[5:]
+	return area != "000" && area != "666" && id[0] != '9' && group != "00" && serial != "0000"
+}
+
+// verhoeff tables for the Aadhaar checksum.
+var (
+	verhoeffD = [10][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, {1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6}, {3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+		{4, 0, 1, 2, 3, 9, 5, 6, 7, 8}, {5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+		{6, 5, 9, 8, 7, 1, 0, 4, 3, 2}, {7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+		{8, 7, 6, 5, 9, 3, 2, 1, 0, 4}, {9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	verhoeffP = [8][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, {1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2}, {8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+		{9, 4, 5, 3, 1, 2, 6, 8, 7, 0}, {4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5}, {7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+	}
+)
+
+// isAadhaar validates an Indian Aadhaar number: 12 digits, not starting with
+// 0 or 1, with a valid Verhoeff check digit.
+func isAadhaar(id string) bool {
+	d := digitsOf(id)
+	return len(d) == 12 && d[0] >= 2 && verhoeffValid(d)
+}
+
+// verhoeffValid reports whether the last digit of d is its Verhoeff check
+// digit.
+func verhoeffValid(d []int) bool {
+	c := 0
+	for i := range d {
+		c = verhoeffD[c][verhoeffP[i%8][d[len(d)-1-i]]]
+	}
+	return c == 0
+}
@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNationalID_BuiltIns(t *testing.T) {
+	valid := map[string][]string{
+		"BR": {"529.982.247-25", "11.222.333/0001-81"},
+		"es": {"12345678Z", "x1234567l"},
+		"PT": {"123456789", "451234561"},
+		"US": {"123-45-6789"},
+		"IN": {"2341 2341 2346"},
+	}
+	for country, ids := range valid {
+		for _, id := range ids {
+			if err := Var("id", id, "national_id="+country); err != nil {
+				t.Fatalf("%s %q: unexpected error %v", country, id, err)
+			}
+		}
+	}
+	invalid := map[string][]string{
+		"BR": {"529.982.247-24", "111.111.111-11", "11.222.333/0001-80", "123"},
+		"ES": {"12345678A", "Q1234567L", "1234567"},
+		"PT": {"123456780", "423456789"},
+		"US": {"666-12-3456", "000-12-3456", "900-12-3456", "123-00-6789", "123-45-0000", "12-345-678"},
+		"IN": {"234123412345", "123412341234"},
+	}
+	for country, ids := range invalid {
+		for _, id := range ids {
+			if Var("id", id, "national_id="+country) == nil {
+				t.Fatalf("%s %q: expected error", country, id)
+			}
+		}
+	}
+	if Var("id", "123", "national_id=ZZ") == nil || Var("id", 123, "national_id=US") == nil || Var("id", "", "national_id=US") == nil {
+		t.Fatalf("expected unknown countries and non-strings to fail")
+	}
+}
+
+func TestNationalID_Messages(t *testing.T) {
+	err := Var("cpf", "123", "national_id=BR")
+	if m := ToFieldErrors(err); m["cpf"] != "must be a valid CPF or CNPJ" {
+		t.Fatalf("expected country message, got %v", m)
+	}
+	if m := ToFieldErrorsWithContext(WithLocale(context.Background(), "pt"), err); m["cpf"] != "deve ser um CPF ou CNPJ válido" {
+		t.Fatalf("expected localized message, got %v", m)
+	}
+	if m := ToFieldErrors(Var("id", "1", "national_id=ZZ")); m["id"] != "must be a valid national ID" {
+		t.Fatalf("expected generic message, got %v", m)
+	}
+
+	RegisterNationalID("zz", func(id string) bool { return id == "OK1" }, map[string]string{"en": "must be a valid ZZ ID"})
+	defer func() {
+		nationalIDsMu.Lock()
+		delete(nationalIDs, "ZZ")
+		nationalIDsMu.Unlock()
+		RegisterMessages("national_id=ZZ", nil)
+	}()
+	if err := Var("id", "ok-1", "national_id=ZZ"); err != nil {
+		t.Fatalf("expected custom country to validate normalized value, got %v", err)
+	}
+	if m := ToFieldErrors(Var("id", "no", "national_id=ZZ")); m["id"] != "must be a valid ZZ ID" {
+		t.Fatalf("expected custom message, got %v", m)
+	}
+	if m := ToFieldErrors(NewEngine().Var("x", "national_id=ES")); len(m) != 1 {
+		t.Fatalf("expected built-in countries on new engines, got %v", m)
+	}
+}
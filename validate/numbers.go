@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Precision tags for numbers carried as strings or json.Number, e.g. 64-bit
+	// IDs from JavaScript clients that would not survive a float64.
+	mustRegister("intstring", intString, map[string]string{DefaultMessageLocale: "must be an integer"})
+	mustRegister("max_digits", maxDigits, map[string]string{DefaultMessageLocale: "must have at most {param} digits"})
+}
+
+// intString accepts strings (including json.Number) holding a base-10
+// integer with an optional sign.
+func intString(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	s := strings.TrimPrefix(strings.TrimPrefix(field.String(), "-"), "+")
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// maxDigits limits the number of digits of an integer, or of the mantissa of
+// a numeric string (json.Number).
+func maxDigits(fl validator.FieldLevel) bool {
+	limit, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		return false
+	}
+	field := fl.Field()
+	var s string
+	switch field.Kind() {
+	case reflect.String:
+		s = field.String()
+		if i := strings.IndexAny(s, "eE"); i >= 0 {
+			s = s[:i]
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = strconv.FormatInt(field.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s = strconv.FormatUint(field.Uint(), 10)
+	default:
+		return false
+	}
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			n++
+		}
+	}
+	return n <= limit
+}
@@ -0,0 +1,74 @@
+package validate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+)
+
+type bigIDs struct {
+	ID    json.Number    `json:"id" validate:"intstring,max_digits=19"`
+	Ref   string         `json:"ref" validate:"omitempty,intstring"`
+	Count int64          `json:"count" validate:"max_digits=3"`
+	Attrs map[string]any `json:"attrs"`
+}
+
+func TestNumberTags(t *testing.T) {
+	if err := Struct(bigIDs{ID: "-9223372036854775807", Ref: "+42", Count: -999}); err != nil {
+		t.Fatalf("unexpected error: %v", ToFieldErrors(err))
+	}
+	m := ToFieldErrors(Struct(bigIDs{ID: "12345678901234567890", Ref: "1.5", Count: 1000}))
+	want := map[string]string{
+		"id":    "must have at most 19 digits",
+		"ref":   "must be an integer",
+		"count": "must have at most 3 digits",
+	}
+	for k, msg := range want {
+		if m[k] != msg {
+			t.Fatalf("field %q: expected %q, got %q (all: %v)", k, msg, m[k], m)
+		}
+	}
+	if m := ToFieldErrors(Var("id", json.Number("1e3"), "intstring")); m["id"] != "must be an integer" {
+		t.Fatalf("expected exponent to be rejected, got %v", m)
+	}
+	if err := Var("n", json.Number("1.25e10"), "max_digits=3"); err != nil {
+		t.Fatalf("expected mantissa digits only, got %v", err)
+	}
+	if Var("n", 1.5, "max_digits=3") == nil || Var("n", "", "intstring") == nil || Var("n", 1, "intstring") == nil {
+		t.Fatalf("expected unsupported values to fail")
+	}
+}
+
+func TestBindJSON_UseNumber(t *testing.T) {
+	bind := func(opts ...BindOptions) (bigIDs, FieldErrors) {
+		var (
+			in  bigIDs
+			res FieldErrors
+		)
+		app := flash.New()
+		app.POST("/", func(c flash.Ctx) error {
+			if err := BindAndValidate(c, &in, opts...); err != nil {
+				res = FieldErrors(ToFieldErrorsWithContext(c.Context(), err))
+			}
+			return nil
+		})
+		body := `{"id":9007199254740993,"count":5,"attrs":{"big":9007199254740993}}`
+		app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		return in, res
+	}
+	in, fe := bind()
+	if fe != nil || in.ID != "9007199254740993" {
+		t.Fatalf("expected json.Number field to keep precision, got %+v %v", in, fe)
+	}
+	if _, ok := in.Attrs["big"].(float64); !ok {
+		t.Fatalf("expected float64 for untyped values by default, got %T", in.Attrs["big"])
+	}
+	in, _ = bind(BindOptions{UseNumber: true})
+	if n, ok := in.Attrs["big"].(json.Number); !ok || n != "9007199254740993" {
+		t.Fatalf("expected json.Number for untyped values, got %#v", in.Attrs["big"])
+	}
+}
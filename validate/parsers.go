@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// textParser parses a string into a value of a registered type.
+type textParser struct {
+	parse   func(string) (any, error)
+	message string
+}
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = map[reflect.Type]textParser{}
+
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// invalidFormatMessage is reported for types implementing
+// encoding.TextUnmarshaler without a registered message.
+const invalidFormatMessage = "has an invalid format"
+
+// RegisterParser registers how strings from JSON bodies, query strings and
+// forms are parsed into fields of type T, and the message reported when
+// parsing fails. A nil parse uses T's encoding.TextUnmarshaler:
+//
+//	validate.RegisterParser[ulid.ULID]("must be a valid ULID", nil)
+//	validate.RegisterParser("must be a valid SKU", ParseSKU)
+//
+// Types implementing encoding.TextUnmarshaler are parsed without
+// registration and report "has an invalid format"; typesvalidate registers
+// uuid.UUID with "must be a valid UUID". Call it at startup.
+func RegisterParser[T any](message string, parse func(string) (T, error)) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	p := textParser{message: message}
+	if parse != nil {
+		p.parse = func(s string) (any, error) { return parse(s) }
+	} else if tp, ok := unmarshalTextParser(t); ok {
+		p.parse = tp
+	} else {
+		panic(fmt.Sprintf("validate: RegisterParser: %s needs a parse func or encoding.TextUnmarshaler", t))
+	}
+	parsersMu.Lock()
+	parsers[t] = p
+	parsersMu.Unlock()
+}
+
+// parserFor returns the parser for t, falling back to encoding.TextUnmarshaler.
+func parserFor(t reflect.Type) (textParser, bool) {
+	parsersMu.RLock()
+	p, ok := parsers[t]
+	parsersMu.RUnlock()
+	if ok {
+		return p, true
+	}
+	if tp, ok := unmarshalTextParser(t); ok {
+		return textParser{parse: tp, message: invalidFormatMessage}, true
+	}
+	return textParser{}, false
+}
+
+func unmarshalTextParser(t reflect.Type) (func(string) (any, error), bool) {
+	if t == nil || t.Kind() == reflect.Pointer || t.Kind() == reflect.Interface || !reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return nil, false
+	}
+	return func(s string) (any, error) {
+		v := reflect.New(t)
+		if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return nil, err
+		}
+		return v.Elem().Interface(), nil
+	}, true
+}
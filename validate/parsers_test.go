@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+)
+
+type sku string
+
+func parseSKU(s string) (sku, error) {
+	if !strings.HasPrefix(s, "SKU-") {
+		return "", errors.New("bad sku")
+	}
+	return sku(s), nil
+}
+
+type hexID [2]byte
+
+func (h *hexID) UnmarshalText(b []byte) error {
+	if len(b) != 2 {
+		return errors.New("bad id")
+	}
+	copy(h[:], b)
+	return nil
+}
+
+type parsed struct {
+	SKU   sku        `json:"sku"`
+	Owner *sku       `json:"owner"`
+	Addr  netip.Addr `json:"addr"`
+	Hex   hexID      `json:"hex"`
+}
+
+func TestRegisterParser_JSON(t *testing.T) {
+	RegisterParser("must be a valid SKU", parseSKU)
+	RegisterParser[hexID]("must be a valid hex ID", nil)
+	defer func() {
+		parsersMu.Lock()
+		delete(parsers, reflectTypeOf[sku]())
+		delete(parsers, reflectTypeOf[hexID]())
+		parsersMu.Unlock()
+	}()
+
+	bind := func(body string) (parsed, FieldErrors) {
+		var (
+			in  parsed
+			res FieldErrors
+		)
+		app := flash.New()
+		app.POST("/", func(c flash.Ctx) error {
+			if err := BindAndValidate(c, &in); err != nil {
+				res = FieldErrors(ToFieldErrorsWithContext(c.Context(), err))
+			}
+			return nil
+		})
+		app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		return in, res
+	}
+
+	in, fe := bind(`{"owner":"SKU-2","sku":"SKU-1","addr":"10.0.0.1","hex":"ab"}`)
+	if fe != nil || *in.Owner != "SKU-2" || in.SKU != "SKU-1" || in.Addr.String() != "10.0.0.1" || in.Hex != (hexID{'a', 'b'}) {
+		t.Fatalf("expected values to bind, got %+v %v", in, fe)
+	}
+
+	_, fe = bind(`{"owner":"","sku":"X","addr":"::zz","hex":"abc"}`)
+	want := FieldErrors{
+		"owner": "must be a valid SKU",
+		"sku":   "must be a valid SKU",
+		"addr":  "has an invalid format",
+		"hex":   "must be a valid hex ID",
+	}
+	if len(fe) != len(want) {
+		t.Fatalf("expected %v, got %v", want, fe)
+	}
+	for k, msg := range want {
+		if fe[k] != msg {
+			t.Fatalf("field %q: expected %q, got %v", k, msg, fe)
+		}
+	}
+}
+
+func TestRegisterParser_Coerce(t *testing.T) {
+	var dst parsed
+	err := Coerce(url.Values{"hex": {"abc"}, "addr": {"10.0.0.1"}}, &dst)
+	if fe, _ := err.(FieldErrors); fe["hex"] != invalidFormatMessage || len(fe) != 1 || dst.Addr.String() != "10.0.0.1" {
+		t.Fatalf("unexpected result: %v %+v", err, dst)
+	}
+}
+
+func TestRegisterParser_PanicsWithoutParser(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	RegisterParser[int]("x", nil)
+}
+
+func reflectTypeOf[T any]() reflect.Type { return reflect.TypeOf((*T)(nil)).Elem() }
@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// port replaces the library's tag, which only supports unsigned fields,
+	// so it also works on int and string fields.
+	mustRegister("port", isPortNumber, map[string]string{DefaultMessageLocale: "must be a valid port"})
+	mustRegister("port_range", inPortRange, map[string]string{DefaultMessageLocale: "must be a port between {from} and {to}"})
+	mustRegister("hostport", isHostPort, map[string]string{DefaultMessageLocale: "must be a host:port address"})
+}
+
+// isPortNumber accepts 1-65535 in integer or numeric string fields.
+func isPortNumber(fl validator.FieldLevel) bool {
+	p, ok := portOf(fl.Field())
+	return ok && p >= 1 && p <= 65535
+}
+
+// inPortRange accepts ports within the inclusive parameter range, e.g.
+// `port_range=1024-65535`.
+func inPortRange(fl validator.FieldLevel) bool {
+	from, to, ok := strings.Cut(fl.Param(), "-")
+	if !ok {
+		return false
+	}
+	lo, err1 := strconv.ParseInt(strings.TrimSpace(from), 10, 64)
+	hi, err2 := strconv.ParseInt(strings.TrimSpace(to), 10, 64)
+	p, ok := portOf(fl.Field())
+	return ok && err1 == nil && err2 == nil && p >= 1 && p <= 65535 && p >= lo && p <= hi
+}
+
+// isHostPort accepts listener and peer addresses: a host name, IPv4 address
+// or bracketed IPv6 address, or an empty host (":8080"), followed by a port.
+func isHostPort(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	host, port, err := net.SplitHostPort(fl.Field().String())
+	if err != nil {
+		return false
+	}
+	if p, err := strconv.ParseInt(port, 10, 64); err != nil || p < 1 || p > 65535 {
+		return false
+	}
+	if host == "" {
+		return true
+	}
+	if _, err := netip.ParseAddr(host); err == nil {
+		return true
+	}
+	return bakedIn.Var(host, "hostname_rfc1123") == nil
+}
+
+// portOf reads a port number from an integer or numeric string field.
+func portOf(field reflect.Value) (int64, bool) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if field.Uint() > 65535 {
+			return 0, false
+		}
+		return int64(field.Uint()), true
+	case reflect.String:
+		p, err := strconv.ParseInt(field.String(), 10, 64)
+		return p, err == nil
+	}
+	return 0, false
+}
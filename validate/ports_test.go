@@ -0,0 +1,50 @@
+package validate
+
+import "testing"
+
+func TestPortTags(t *testing.T) {
+	cases := []struct {
+		value any
+		tag   string
+		ok    bool
+	}{
+		{8080, "port", true},
+		{uint16(443), "port", true},
+		{"22", "port", true},
+		{0, "port", false},
+		{70000, "port", false},
+		{"http", "port", false},
+		{8080, "port_range=1024-65535", true},
+		{"1024", "port_range=1024-65535", true},
+		{80, "port_range=1024-65535", false},
+		{80, "port_range=1024", false},
+		{"localhost:8080", "hostport", true},
+		{":8080", "hostport", true},
+		{"10.0.0.1:5432", "hostport", true},
+		{"[::1]:443", "hostport", true},
+		{"db.internal.example.com:5432", "hostport", true},
+		{"::1:443", "hostport", false},
+		{"host:0", "hostport", false},
+		{"host", "hostport", false},
+		{"bad_host!:80", "hostport", false},
+		{8080, "hostport", false},
+	}
+	for _, c := range cases {
+		if err := Var("addr", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%v %s: expected ok=%v, got %v", c.value, c.tag, c.ok, err)
+		}
+	}
+}
+
+func TestPortMessages(t *testing.T) {
+	want := map[string]string{
+		"port":                  "must be a valid port",
+		"port_range=1024-65535": "must be a port between 1024 and 65535",
+		"hostport":              "must be a host:port address",
+	}
+	for tag, msg := range want {
+		if got := ToFieldErrors(Var("addr", "x", tag))["addr"]; got != msg {
+			t.Fatalf("%s: expected %q, got %q", tag, msg, got)
+		}
+	}
+}
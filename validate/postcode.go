@@ -0,0 +1,39 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// bakedIn validates single values with the validator's built-in tags, free of
+// application registrations.
+var bakedIn = validator.New()
+
+func init() {
+	mustRegister("postcode_for", postcodeFor, map[string]string{DefaultMessageLocale: "must be a valid postal code"})
+}
+
+// postcodeFor checks a postal code against the format of the ISO 3166-1
+// alpha-2 country held by the sibling field named in the parameter, e.g.
+// `postcode_for=Country`. The country is matched case-insensitively; an empty
+// country is left to that field's own rules, an unknown one fails.
+func postcodeFor(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	country, kind, _, found := fl.GetStructFieldOKAdvanced2(fl.Parent(), fl.Param())
+	if !found || kind != reflect.String {
+		return false
+	}
+	code := strings.ToUpper(strings.TrimSpace(country.String()))
+	if code == "" {
+		return true
+	}
+	if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return false
+	}
+	return bakedIn.Var(strings.TrimSpace(field.String()), "postcode_iso3166_alpha2="+code) == nil
+}
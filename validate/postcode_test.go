@@ -0,0 +1,38 @@
+package validate
+
+import "testing"
+
+type postAddress struct {
+	Country  string  `json:"country"`
+	Postcode string  `json:"postcode" validate:"postcode_for=Country"`
+	Ptr      *string `json:"ptr"`
+	Alt      string  `json:"alt" validate:"omitempty,postcode_for=Ptr"`
+}
+
+func TestPostcodeFor(t *testing.T) {
+	gb := "gb"
+	for _, a := range []postAddress{
+		{Country: "US", Postcode: "94105"},
+		{Country: "gb", Postcode: " SW1A 1AA "},
+		{Country: "NL", Postcode: "1012 AB"},
+		{Country: "", Postcode: "anything"},
+		{Country: "US", Postcode: "94105", Ptr: &gb, Alt: "EC1A 1BB"},
+	} {
+		if err := Struct(a); err != nil {
+			t.Fatalf("%+v: unexpected error: %v", a, ToFieldErrors(err))
+		}
+	}
+	for _, a := range []postAddress{
+		{Country: "US", Postcode: "SW1A 1AA"},
+		{Country: "GB", Postcode: "94105-12"},
+		{Country: "XX", Postcode: "12345"},
+		{Country: "US,required", Postcode: "12345"},
+	} {
+		if m := ToFieldErrors(Struct(a)); m["postcode"] != "must be a valid postal code" {
+			t.Fatalf("%+v: expected postcode error, got %v", a, m)
+		}
+	}
+	if m := ToFieldErrors(Struct(postAddress{Country: "US", Postcode: "94105", Alt: "x"})); m["alt"] != "must be a valid postal code" {
+		t.Fatalf("expected nil country pointer to fail, got %v", m)
+	}
+}
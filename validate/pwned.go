@@ -0,0 +1,126 @@
+package validate
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PwnedPasswordTag is the tag registered by RegisterPwnedPasswords.
+const PwnedPasswordTag = "pwned_password"
+
+func init() {
+	registerBuiltin(func(e *DefaultEngine) {
+		e.RegisterMessages(PwnedPasswordTag, map[string]string{DefaultMessageLocale: "has appeared in a data breach, choose another password"})
+	})
+}
+
+// DefaultPwnedPasswordsEndpoint is the public Pwned Passwords range API.
+const DefaultPwnedPasswordsEndpoint = "https://api.pwnedpasswords.com/range/"
+
+// PwnedPasswords checks passwords against the Have I Been Pwned Pwned
+// Passwords range API. Only the first five hex characters of the password's
+// SHA-1 hash leave the process (k-anonymity); the match happens locally.
+type PwnedPasswords struct {
+	Client *http.Client
+	// Endpoint is the range API URL, to which the hash prefix is appended.
+	Endpoint string
+	// MinCount is the number of breaches from which a password is rejected.
+	// Default: 1.
+	MinCount int
+}
+
+// NewPwnedPasswords returns a checker using client (nil for
+// http.DefaultClient).
+func NewPwnedPasswords(client *http.Client) *PwnedPasswords {
+	return &PwnedPasswords{Client: client, Endpoint: DefaultPwnedPasswordsEndpoint}
+}
+
+// Check reports whether password does not appear in the breach corpus (at
+// least MinCount times). Empty passwords are reported valid without a
+// request; leave them to `required`.
+func (p *PwnedPasswords) Check(ctx context.Context, password string) (bool, error) {
+	if password == "" {
+		return true, nil
+	}
+	hash := sha1Hex(password)
+	prefix, suffix := hash[:5], hash[5:]
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Endpoint+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	// Padding hides the size of the response from observers.
+	req.Header.Set("Add-Padding", "true")
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("pwned passwords: unexpected status %d", resp.StatusCode)
+	}
+	minCount := p.MinCount
+	if minCount <= 0 {
+		minCount = 1
+	}
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		s, n, ok := strings.Cut(strings.TrimSpace(sc.Text()), ":")
+		if !ok || !strings.EqualFold(s, suffix) {
+			continue
+		}
+		count, _ := strconv.Atoi(n)
+		return count < minCount, nil
+	}
+	if err := sc.Err(); err != nil {
+		return false, fmt.Errorf("pwned passwords: %w", err)
+	}
+	return true, nil
+}
+
+// RegisterPwnedPasswords registers the pwned_password tag on the global
+// Validator, backed by p (nil for NewPwnedPasswords(nil)). Set opts.SoftFail
+// to warn about breached passwords instead of rejecting them:
+//
+//	validate.RegisterPwnedPasswords(nil, validate.RemoteOptions{CacheTTL: time.Hour, SoftFail: true})
+//
+//	type Signup struct {
+//		Password string `json:"password" validate:"required,min=12,pwned_password"`
+//	}
+//
+// Answers are cached by SHA-1 hash rather than plaintext unless
+// opts.CacheKey is set. Service errors let passwords pass unless
+// opts.FailClosed is set.
+func RegisterPwnedPasswords(p *PwnedPasswords, opts RemoteOptions) error {
+	return Default().RegisterPwnedPasswords(p, opts)
+}
+
+// RegisterPwnedPasswords registers the pwned_password tag on e. See the
+// package-level RegisterPwnedPasswords.
+func (e *DefaultEngine) RegisterPwnedPasswords(p *PwnedPasswords, opts RemoteOptions) error {
+	if p == nil {
+		p = NewPwnedPasswords(nil)
+	}
+	if opts.CacheKey == nil {
+		opts.CacheKey = sha1Hex
+	}
+	if len(opts.Messages) == 0 {
+		opts.Messages = map[string]string{DefaultMessageLocale: "has appeared in a data breach, choose another password"}
+	}
+	return e.RegisterRemote(PwnedPasswordTag, p, opts)
+}
+
+// sha1Hex returns the upper-case hex SHA-1 of s, as used by the range API.
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,87 @@
+package validate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type pwnedSignup struct {
+	Password string `json:"password" validate:"pwned_password"`
+}
+
+// pwnedServer answers the range request for "password" (SHA-1 prefix 5BAA6)
+// with a padded response listing it 3 times.
+func pwnedServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Add-Padding") != "true" {
+			t.Errorf("expected padding to be requested")
+		}
+		if r.URL.Path != "/5BAA6" {
+			_, _ = w.Write([]byte("0018A45C4D1DEF81644B54AB7F969B88D65:0\r\n"))
+			return
+		}
+		_, _ = w.Write([]byte("003D68EB55068C33ACE09247EE4C639306B:3\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:3\r\n"))
+	}))
+}
+
+func TestPwnedPasswords(t *testing.T) {
+	var calls atomic.Int32
+	srv := pwnedServer(t, &calls)
+	defer srv.Close()
+
+	p := NewPwnedPasswords(srv.Client())
+	p.Endpoint = srv.URL + "/"
+	if ok, err := p.Check(context.Background(), "password"); err != nil || ok {
+		t.Fatalf("expected breached password, got %v %v", ok, err)
+	}
+	if ok, err := p.Check(context.Background(), "correct horse battery staple 42"); err != nil || !ok {
+		t.Fatalf("expected unknown password to pass, got %v %v", ok, err)
+	}
+	p.MinCount = 4
+	if ok, _ := p.Check(context.Background(), "password"); !ok {
+		t.Fatalf("expected MinCount to allow rarely breached passwords")
+	}
+	if ok, _ := p.Check(context.Background(), ""); !ok || calls.Load() != 3 {
+		t.Fatalf("expected empty password to pass without a request")
+	}
+}
+
+func TestRegisterPwnedPasswords(t *testing.T) {
+	var calls atomic.Int32
+	srv := pwnedServer(t, &calls)
+	defer srv.Close()
+	p := NewPwnedPasswords(srv.Client())
+	p.Endpoint = srv.URL + "/"
+
+	e := NewEngine()
+	if err := e.RegisterPwnedPasswords(p, RemoteOptions{CacheTTL: time.Minute}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	ctx := WithEngine(context.Background(), e)
+	for i := 0; i < 2; i++ {
+		m := ToFieldErrorsWithContext(ctx, StructCtx(ctx, pwnedSignup{Password: "password"}))
+		if !strings.HasPrefix(m["password"], "has appeared in a data breach") {
+			t.Fatalf("expected breach message, got %v", m)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected cached answer, got %d calls", calls.Load())
+	}
+
+	soft := NewEngine()
+	_ = soft.RegisterPwnedPasswords(p, RemoteOptions{SoftFail: true})
+	ctx = CollectWarnings(WithEngine(context.Background(), soft))
+	if err := StructCtx(ctx, pwnedSignup{Password: "password"}); err != nil {
+		t.Fatalf("expected soft failure to pass, got %v", err)
+	}
+	if w := ContextWarnings(ctx); !strings.HasPrefix(w["password"], "has appeared in a data breach") {
+		t.Fatalf("expected breach warning, got %v", w)
+	}
+}
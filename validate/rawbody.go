@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"context"
+
+	"github.com/goflash/flash/v2/ctx"
+)
+
+// rawBodyKey stores the body retained by BindAndValidate.
+type rawBodyKey struct{}
+
+type rawBody struct {
+	data      []byte
+	truncated bool
+}
+
+// RawBody returns the request body retained by BindAndValidate with
+// BindOptions.RetainBody, for enriching errors (positions, snippets) or
+// auditing. truncated reports whether the body was cut at the cap. body is
+// nil when nothing was retained. It is a copy: callers may keep it, but must
+// redact it themselves before logging or echoing it.
+func RawBody(c context.Context) (body []byte, truncated bool) {
+	if c == nil {
+		return nil, false
+	}
+	rb, _ := c.Value(rawBodyKey{}).(rawBody)
+	return rb.data, rb.truncated
+}
+
+// retainBody stores a copy of at most max bytes of body in the request
+// context. The copy keeps the retained data independent of the (possibly
+// much larger) read buffer.
+func retainBody(c ctx.Ctx, body []byte, max int64) {
+	if max <= 0 || body == nil {
+		return
+	}
+	rb := rawBody{}
+	if int64(len(body)) > max {
+		body, rb.truncated = body[:max], true
+	}
+	rb.data = append([]byte(nil), body...)
+	c.Set(rawBodyKey{}, rb)
+}
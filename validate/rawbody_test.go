@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+)
+
+func TestBindAndValidate_RetainBody(t *testing.T) {
+	serve := func(body, contentType string, opts BindOptions) (raw []byte, truncated bool, in bindUser) {
+		t.Helper()
+		app := flash.New()
+		app.POST("/users", func(c flash.Ctx) error {
+			_ = BindAndValidate(c, &in, opts)
+			raw, truncated = RawBody(c.Context())
+			return c.String(http.StatusOK, "ok")
+		})
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		app.ServeHTTP(httptest.NewRecorder(), req)
+		return raw, truncated, in
+	}
+
+	body := `{"name":"Ann","age":30}`
+	raw, truncated, in := serve(body, "application/json", BindOptions{RetainBody: 64})
+	if string(raw) != body || truncated || in.Age != 30 {
+		t.Fatalf("expected whole body retained and bound, got %q %v %+v", raw, truncated, in)
+	}
+	raw, truncated, in = serve(body, "application/json", BindOptions{RetainBody: 8})
+	if string(raw) != `{"name":` || !truncated || in.Name != "Ann" {
+		t.Fatalf("expected body cut at 8 bytes, got %q %v %+v", raw, truncated, in)
+	}
+	raw, _, in = serve("name=Ann&age=30", "application/x-www-form-urlencoded", BindOptions{RetainBody: 64})
+	if string(raw) != "name=Ann&age=30" || in.Age != 30 {
+		t.Fatalf("expected form body retained and bound, got %q %+v", raw, in)
+	}
+	if raw, _, _ = serve(body, "application/json", BindOptions{}); raw != nil {
+		t.Fatalf("expected nothing retained by default, got %q", raw)
+	}
+	if raw, _, _ = serve(body, "application/json", BindOptions{RetainBody: 64, MaxBodyBytes: 8}); raw != nil {
+		t.Fatalf("expected oversized body not retained, got %q", raw)
+	}
+	if raw, truncated = RawBody(context.Background()); raw != nil || truncated {
+		t.Fatalf("expected no body outside requests")
+	}
+}
@@ -0,0 +1,189 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// RemoteValidator checks a value against an external service (a tax
+// registry, DNS, a breach database, ...). It reports whether the value is
+// valid; a non-nil error means the service could not answer.
+type RemoteValidator interface {
+	Check(ctx context.Context, value string) (bool, error)
+}
+
+// RemoteFunc adapts a function to RemoteValidator.
+type RemoteFunc func(ctx context.Context, value string) (bool, error)
+
+// Check calls f.
+func (f RemoteFunc) Check(ctx context.Context, value string) (bool, error) { return f(ctx, value) }
+
+// RemoteOptions configures a tag registered with RegisterRemote.
+type RemoteOptions struct {
+	// Timeout bounds each check, in addition to any deadline of the
+	// validation context. Default: 2s.
+	Timeout time.Duration
+	// CacheTTL keeps answers per value for this long. 0 disables caching.
+	CacheTTL time.Duration
+	// CacheSize bounds the number of cached values. Default: 10000.
+	CacheSize int
+	// CacheKey derives the cache key of a value, e.g. a hash so secrets are
+	// not kept in memory. Default: the value itself.
+	CacheKey func(value string) string
+	// FailClosed rejects values when the service errors or times out. By
+	// default such values pass, so an outage does not block users.
+	FailClosed bool
+	// SoftFail reports failing values as warnings (see CollectWarnings),
+	// keyed by field name, instead of rejecting them.
+	SoftFail bool
+	// Messages are the tag's messages by locale, as in
+	// RegisterValidationWithMessage.
+	Messages map[string]string
+}
+
+// RegisterRemote registers tag on the global Validator backed by rv. Checks
+// run with the validation context (see StructCtx), so request cancellation
+// and deadlines apply; answers are cached and errors counted as
+// MetricRemoteError:
+//
+//	validate.RegisterRemote("vat_vies", validate.NewVIES(nil), validate.RemoteOptions{
+//		Timeout:  3 * time.Second,
+//		CacheTTL: 24 * time.Hour,
+//		Messages: map[string]string{"en": "is not a registered VAT number"},
+//	})
+func RegisterRemote(tag string, rv RemoteValidator, opts RemoteOptions) error {
+	return Default().RegisterRemote(tag, rv, opts)
+}
+
+// RegisterRemote registers a remote-backed tag on e. See the package-level
+// RegisterRemote.
+func (e *DefaultEngine) RegisterRemote(tag string, rv RemoteValidator, opts RemoteOptions) error {
+	r := newRemoteCheck(tag, rv, opts)
+	if err := e.RegisterValidationCtx(tag, r.validate); err != nil {
+		return err
+	}
+	e.RegisterMessages(tag, opts.Messages)
+	return nil
+}
+
+// remoteCheck runs a RemoteValidator with timeout and cache.
+type remoteCheck struct {
+	tag   string
+	rv    RemoteValidator
+	opts  RemoteOptions
+	cache *ttlCache
+}
+
+func newRemoteCheck(tag string, rv RemoteValidator, opts RemoteOptions) *remoteCheck {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Second
+	}
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = 10000
+	}
+	r := &remoteCheck{tag: tag, rv: rv, opts: opts}
+	if opts.CacheTTL > 0 {
+		r.cache = newTTLCache(opts.CacheTTL, opts.CacheSize)
+	}
+	return r
+}
+
+func (r *remoteCheck) validate(ctx context.Context, fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	if r.check(ctx, field.String()) {
+		return true
+	}
+	if r.opts.SoftFail {
+		addWarning(ctx, fl.FieldName(), warningMessage(ctx, r.tag))
+		return true
+	}
+	return false
+}
+
+// check answers from the cache or asks the remote validator.
+func (r *remoteCheck) check(ctx context.Context, value string) bool {
+	key := value
+	if r.opts.CacheKey != nil {
+		key = r.opts.CacheKey(value)
+	}
+	if r.cache != nil {
+		if ok, hit := r.cache.get(key); hit {
+			return ok
+		}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Timeout)
+	defer cancel()
+	ok, err := r.rv.Check(ctx, value)
+	if err != nil {
+		incMetric(MetricRemoteError, map[string]string{"tag": r.tag})
+		return !r.opts.FailClosed
+	}
+	if r.cache != nil {
+		r.cache.set(key, ok)
+	}
+	return ok
+}
+
+// ttlCache is a small bounded cache of boolean answers.
+type ttlCache struct {
+	ttl time.Duration
+	max int
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]ttlEntry
+}
+
+type ttlEntry struct {
+	ok      bool
+	expires time.Time
+}
+
+func newTTLCache(ttl time.Duration, max int) *ttlCache {
+	return &ttlCache{ttl: ttl, max: max, now: time.Now, entries: map[string]ttlEntry{}}
+}
+
+func (c *ttlCache) get(key string) (ok, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, found := c.entries[key]
+	if !found {
+		return false, false
+	}
+	if c.now().After(e.expires) {
+		delete(c.entries, key)
+		return false, false
+	}
+	return e.ok, true
+}
+
+func (c *ttlCache) set(key string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= c.max {
+		// Drop expired entries first, then an arbitrary one.
+		now := c.now()
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < c.max {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = ttlEntry{ok: ok, expires: c.now().Add(c.ttl)}
+}
@@ -0,0 +1,105 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type remoteSignup struct {
+	Code string `json:"code" validate:"remote_code"`
+}
+
+func TestRegisterRemote(t *testing.T) {
+	var calls atomic.Int32
+	rv := RemoteFunc(func(ctx context.Context, v string) (bool, error) {
+		calls.Add(1)
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatalf("expected a deadline on remote checks")
+		}
+		return v == "ok", nil
+	})
+	e := NewEngine()
+	if err := e.RegisterRemote("remote_code", rv, RemoteOptions{CacheTTL: time.Minute, Messages: map[string]string{"en": "is not known"}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	ctx := WithEngine(context.Background(), e)
+	for i := 0; i < 2; i++ {
+		if err := StructCtx(ctx, remoteSignup{Code: "ok"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if m := ToFieldErrorsWithContext(ctx, StructCtx(ctx, remoteSignup{Code: "bad"})); m["code"] != "is not known" {
+		t.Fatalf("expected remote failure message, got %v", m)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected cached answers, got %d calls", n)
+	}
+}
+
+func TestRegisterRemote_FailOpenAndClosed(t *testing.T) {
+	var metrics atomic.Int32
+	SetMetrics(func(metric string, labels map[string]string) {
+		if metric == MetricRemoteError && labels["tag"] == "remote_code" {
+			metrics.Add(1)
+		}
+	})
+	defer SetMetrics(nil)
+
+	slow := RemoteFunc(func(ctx context.Context, _ string) (bool, error) {
+		<-ctx.Done()
+		return false, ctx.Err()
+	})
+	for _, closed := range []bool{false, true} {
+		e := NewEngine()
+		_ = e.RegisterRemote("remote_code", slow, RemoteOptions{Timeout: 10 * time.Millisecond, FailClosed: closed})
+		err := e.Struct(remoteSignup{Code: "x"})
+		if (err != nil) != closed {
+			t.Fatalf("FailClosed=%v: unexpected result %v", closed, err)
+		}
+	}
+	if metrics.Load() != 2 {
+		t.Fatalf("expected remote errors to be counted, got %d", metrics.Load())
+	}
+
+	e := NewEngine()
+	_ = e.RegisterRemote("remote_code", RemoteFunc(func(context.Context, string) (bool, error) {
+		return false, errors.New("unreachable")
+	}), RemoteOptions{FailClosed: true})
+	if err := e.Var(1, "remote_code"); err == nil {
+		t.Fatalf("expected non-string values to fail")
+	}
+}
+
+func TestTTLCache(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := newTTLCache(time.Minute, 2)
+	c.now = func() time.Time { return now }
+	c.set("a", true)
+	c.set("b", false)
+	if ok, hit := c.get("a"); !ok || !hit {
+		t.Fatalf("expected cached true")
+	}
+	c.set("c", true)
+	if len(c.entries) != 2 {
+		t.Fatalf("expected size bound, got %d entries", len(c.entries))
+	}
+	now = now.Add(2 * time.Minute)
+	if _, hit := c.get("c"); hit {
+		t.Fatalf("expected entry to expire")
+	}
+}
+
+func TestRemoteCheck_CacheKey(t *testing.T) {
+	r := newRemoteCheck("x", RemoteFunc(func(context.Context, string) (bool, error) { return true, nil }),
+		RemoteOptions{CacheTTL: time.Minute, CacheKey: sha1Hex})
+	r.check(context.Background(), "secret")
+	if _, hit := r.cache.get("secret"); hit {
+		t.Fatalf("expected plaintext not to be used as cache key")
+	}
+	if _, hit := r.cache.get(sha1Hex("secret")); !hit {
+		t.Fatalf("expected hashed cache key")
+	}
+}
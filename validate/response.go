@@ -0,0 +1,54 @@
+package validate
+
+import (
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/goflash/flash/v2/ctx"
+)
+
+var (
+	strictResponses atomic.Bool
+	responseLogger  atomic.Pointer[slog.Logger]
+)
+
+// SetStrictResponses controls how Response handles invalid response values.
+// When strict, the violation is logged and the client receives a 500 instead
+// of the invalid body; otherwise (the default) it is logged and the body is
+// sent anyway.
+func SetStrictResponses(strict bool) { strictResponses.Store(strict) }
+
+// SetResponseLogger sets the logger used for response violations.
+// Passing nil restores slog.Default().
+func SetResponseLogger(l *slog.Logger) { responseLogger.Store(l) }
+
+// Response validates an outgoing response value with the current engine and
+// writes it as JSON. It enforces contract guarantees such as "never return an
+// empty id" on egress:
+//
+//	type userResp struct {
+//		ID string `json:"id" validate:"required"`
+//	}
+//	return validate.Response(c, userResp{ID: u.ID})
+//
+// Violations are logged with the route and per-field messages. See
+// SetStrictResponses for whether they also turn into a 500.
+func Response(c ctx.Ctx, v any) error {
+	if err := StructCtx(c.Context(), v); err != nil {
+		fields := ToFieldErrorsWithContext(c.Context(), err)
+		logger := responseLogger.Load()
+		if logger == nil {
+			logger = slog.Default()
+		}
+		strict := strictResponses.Load()
+		logger.WarnContext(c.Context(), "response validation failed",
+			"method", c.Method(), "route", c.Route(), "fields", fields, "strict", strict)
+		if strict {
+			return c.Status(http.StatusInternalServerError).JSON(map[string]any{
+				"message": "internal server error",
+			})
+		}
+	}
+	return c.JSON(v)
+}
@@ -0,0 +1,51 @@
+package validate
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+)
+
+type userResp struct {
+	ID   string `json:"id" validate:"required"`
+	Name string `json:"name"`
+}
+
+func serveResponse(v userResp) *httptest.ResponseRecorder {
+	app := flash.New()
+	app.GET("/users/:id", func(c flash.Ctx) error { return Response(c, v) })
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	return rec
+}
+
+func TestResponse(t *testing.T) {
+	var logs bytes.Buffer
+	SetResponseLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer SetResponseLogger(nil)
+
+	rec := serveResponse(userResp{ID: "1", Name: "Ann"})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"id":"1"`) || logs.Len() != 0 {
+		t.Fatalf("expected valid response, got %d %s (logs %q)", rec.Code, rec.Body.String(), logs.String())
+	}
+
+	rec = serveResponse(userResp{Name: "Ann"})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"name":"Ann"`) {
+		t.Fatalf("expected lenient mode to send body, got %d %s", rec.Code, rec.Body.String())
+	}
+	if out := logs.String(); !strings.Contains(out, "response validation failed") || !strings.Contains(out, "route=/users/:id") || !strings.Contains(out, "id:is required") {
+		t.Fatalf("unexpected log output: %q", out)
+	}
+
+	SetStrictResponses(true)
+	defer SetStrictResponses(false)
+	rec = serveResponse(userResp{Name: "Ann"})
+	if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "Ann") {
+		t.Fatalf("expected strict mode to return 500 without body, got %d %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// Set membership for slices of values and for integer flag fields. The
+	// messages name the offending members through {invalid}.
+	mustRegister("subset_of", subsetOf, map[string]string{DefaultMessageLocale: "contains invalid values: {invalid}"})
+	mustRegister("bitmask", bitmask, map[string]string{DefaultMessageLocale: "contains invalid flags: {invalid}"})
+}
+
+// subsetOf accepts slices and arrays whose items are all listed in the
+// space-separated parameter, e.g. `subset_of=read write admin`.
+func subsetOf(fl validator.FieldLevel) bool {
+	_, ok := notInSet(fl.Field(), fl.Param())
+	return ok
+}
+
+// notInSet returns the items of v missing from the space-separated set.
+func notInSet(v reflect.Value, param string) ([]string, bool) {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	allowed := map[string]bool{}
+	for _, s := range strings.Fields(param) {
+		allowed[s] = true
+	}
+	var invalid []string
+	for i := 0; i < v.Len(); i++ {
+		s := fmt.Sprint(reflect.Indirect(v.Index(i)).Interface())
+		if !allowed[s] {
+			invalid = append(invalid, s)
+		}
+	}
+	return invalid, len(invalid) == 0
+}
+
+// bitmask accepts non-negative integers with no bits outside the mask given
+// as parameter (decimal, 0x hex or 0b binary), e.g. `bitmask=0b0111`.
+func bitmask(fl validator.FieldLevel) bool {
+	_, ok := bitsOutside(fl.Field(), fl.Param())
+	return ok
+}
+
+// bitsOutside returns the flags of v outside mask, as hex values.
+func bitsOutside(v reflect.Value, param string) ([]string, bool) {
+	mask, err := strconv.ParseUint(param, 0, 64)
+	if err != nil {
+		return nil, false
+	}
+	var u uint64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return []string{strconv.FormatInt(v.Int(), 10)}, false
+		}
+		u = uint64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u = v.Uint()
+	default:
+		return nil, false
+	}
+	var invalid []string
+	for extra := u &^ mask; extra != 0; extra &= extra - 1 {
+		invalid = append(invalid, fmt.Sprintf("0x%x", uint64(1)<<bits.TrailingZeros64(extra)))
+	}
+	return invalid, len(invalid) == 0
+}
+
+// invalidMembers lists the offending members of a subset_of or bitmask
+// failure, or the offending construct of a safe_html, safe_filename or
+// safe_relpath failure, for the {invalid} message placeholder.
+func invalidMembers(fe validator.FieldError) string {
+	var invalid []string
+	switch fe.Tag() {
+	case "subset_of":
+		invalid, _ = notInSet(reflect.ValueOf(fe.Value()), fe.Param())
+	case "bitmask":
+		invalid, _ = bitsOutside(reflect.ValueOf(fe.Value()), fe.Param())
+	case "safe_html":
+		if s, ok := fe.Value().(string); ok {
+			return unsafeHTML(s, fe.Param())
+		}
+	case "safe_filename":
+		if s, ok := fe.Value().(string); ok {
+			return unsafeFilename(s)
+		}
+	case "safe_relpath":
+		if s, ok := fe.Value().(string); ok {
+			return unsafeRelPath(s)
+		}
+	}
+	return strings.Join(invalid, ", ")
+}
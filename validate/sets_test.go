@@ -0,0 +1,31 @@
+package validate
+
+import "testing"
+
+type setPerms struct {
+	Roles []string  `json:"roles" validate:"subset_of=read write admin"`
+	Tags  [2]string `json:"tags" validate:"omitempty,subset_of=a b"`
+	Flags uint8     `json:"flags" validate:"bitmask=0b0111"`
+	Mode  int       `json:"mode" validate:"bitmask=0x10"`
+}
+
+func TestSetTags(t *testing.T) {
+	if err := Struct(setPerms{Roles: []string{"read", "admin"}, Flags: 5, Mode: 16}); err != nil {
+		t.Fatalf("unexpected error: %v", ToFieldErrors(err))
+	}
+	m := ToFieldErrors(Struct(setPerms{Roles: []string{"read", "superroot", "owner"}, Tags: [2]string{"a", "z"}, Flags: 0b11101, Mode: -1}))
+	want := map[string]string{
+		"roles": "contains invalid values: superroot, owner",
+		"tags":  "contains invalid values: z",
+		"flags": "contains invalid flags: 0x8, 0x10",
+		"mode":  "contains invalid flags: -1",
+	}
+	for k, msg := range want {
+		if m[k] != msg {
+			t.Fatalf("field %q: expected %q, got %q (all: %v)", k, msg, m[k], m)
+		}
+	}
+	if Var("x", "read", "subset_of=read") == nil || Var("x", 1, "bitmask=nope") == nil || Var("x", "1", "bitmask=1") == nil {
+		t.Fatalf("expected unsupported values and params to fail")
+	}
+}
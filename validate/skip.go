@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Context key for storing per-request tag skip predicates.
+type ctxKeySkip struct{}
+
+// SkipTagIf returns a context in which tag is not enforced while pred reports
+// true, so role- or feature-flag-dependent rules can be toggled per request
+// without duplicating structs:
+//
+//	ctx := validate.SkipTagIf(c.Context(), "required_if_admin", func() bool { return !isAdmin(c) })
+//	err := validate.StructCtx(ctx, in)
+//
+// Tags registered through a DefaultEngine (including the package-level
+// Register* helpers) are skipped during validation, so later tags on the same
+// field still run. Other tags (the validator built-ins) are skipped by
+// dropping their errors after validation; because a failing tag stops the
+// remaining tags of that field, list such a tag last. Skipping applies to the
+// context-aware helpers (StructCtx, VarCtx, Map, the binding helpers, ...).
+func SkipTagIf(ctx context.Context, tag string, pred func() bool) context.Context {
+	prev, _ := ctx.Value(ctxKeySkip{}).(map[string]func() bool)
+	next := make(map[string]func() bool, len(prev)+1)
+	for k, v := range prev {
+		next[k] = v
+	}
+	next[tag] = pred
+	return context.WithValue(ctx, ctxKeySkip{}, next)
+}
+
+// tagSkipped reports whether tag is skipped in ctx.
+func tagSkipped(ctx context.Context, tag string) bool {
+	if ctx == nil {
+		return false
+	}
+	preds, _ := ctx.Value(ctxKeySkip{}).(map[string]func() bool)
+	pred, ok := preds[tag]
+	return ok && pred != nil && pred()
+}
+
+// filterSkipped drops errors for tags skipped in ctx, returning nil when none
+// remain.
+func filterSkipped(ctx context.Context, err error) error {
+	if err == nil || ctx.Value(ctxKeySkip{}) == nil {
+		return err
+	}
+	var vErrs validator.ValidationErrors
+	if !errors.As(err, &vErrs) {
+		return err
+	}
+	kept := vErrs[:0:0]
+	for _, fe := range vErrs {
+		if !tagSkipped(ctx, fe.Tag()) {
+			kept = append(kept, fe)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+// skippable wraps a validation so it passes while its tag is skipped in the
+// validation context.
+func skippable(tag string, fn validator.FuncCtx) validator.FuncCtx {
+	return func(ctx context.Context, fl validator.FieldLevel) bool {
+		if tagSkipped(ctx, tag) {
+			return true
+		}
+		return fn(ctx, fl)
+	}
+}
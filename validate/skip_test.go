@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type adminReq struct {
+	Reason string `json:"reason" validate:"skip_reason,max=5"`
+	Email  string `json:"email" validate:"required"`
+}
+
+func init() {
+	_ = RegisterValidationWithMessage("skip_reason", func(fl validator.FieldLevel) bool {
+		return fl.Field().String() != ""
+	}, map[string]string{DefaultMessageLocale: "is required for admins"})
+}
+
+func TestSkipTagIf(t *testing.T) {
+	isAdmin := false
+	ctx := SkipTagIf(context.Background(), "skip_reason", func() bool { return !isAdmin })
+	ctx = SkipTagIf(ctx, "required", func() bool { return true })
+
+	// Registered tag is skipped during validation: max still runs.
+	fe := ToFieldErrors(StructCtx(ctx, adminReq{Reason: "", Email: ""}))
+	if len(fe) != 0 {
+		t.Fatalf("expected skipped tags, got %v", fe)
+	}
+	fe = ToFieldErrors(StructCtx(ctx, adminReq{Reason: "toolong"}))
+	if len(fe) != 1 || fe["reason"] != "must be at most 5" {
+		t.Fatalf("expected later tags to run, got %v", fe)
+	}
+
+	isAdmin = true
+	fe = ToFieldErrors(StructCtx(ctx, adminReq{}))
+	if len(fe) != 1 || fe["reason"] != "is required for admins" {
+		t.Fatalf("expected rule to apply for admins, got %v", fe)
+	}
+	if VarCtx(ctx, "email", "", "required") != nil {
+		t.Fatalf("expected skipped built-in tag on VarCtx")
+	}
+
+	// Without the predicate everything is enforced.
+	if fe := ToFieldErrors(StructCtx(context.Background(), adminReq{})); len(fe) != 2 {
+		t.Fatalf("expected both errors, got %v", fe)
+	}
+}
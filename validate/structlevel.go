@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// StructRuleTag is the tag reported for errors added with
+// StructReporter.AddFieldError. Its message is the literal text passed to
+// AddFieldError and is never routed through message functions.
+const StructRuleTag = "struct_rule"
+
+// StructReporter is passed to struct-level rules registered with
+// RegisterStructRule and attributes errors to individual fields, so they flow
+// into ToFieldErrors like any tag failure.
+type StructReporter struct {
+	ctx context.Context
+	sl  validator.StructLevel
+}
+
+// AddFieldError reports a literal message for field. Use the name clients see
+// (the json name), e.g. rep.AddFieldError("end_date", "must be after start_date").
+func (r *StructReporter) AddFieldError(field, message string) {
+	r.sl.ReportError(nil, field, field, StructRuleTag, message)
+}
+
+// AddFieldTag reports a failure of tag (with optional param) for field. Unlike
+// AddFieldError, the message is resolved like any other tag, so translators and
+// SetMessageFunc apply, e.g. rep.AddFieldTag("end_date", "gtfield", "start_date").
+func (r *StructReporter) AddFieldTag(field, tag, param string) {
+	r.sl.ReportError(nil, field, field, tag, param)
+}
+
+// AddWarning records a non-fatal message for field in the warnings collector
+// of the validation context (see CollectWarnings). Validation still passes.
+func (r *StructReporter) AddWarning(field, message string) {
+	addWarning(r.ctx, field, message)
+}
+
+// Context returns the validation context (see StructCtx), or
+// context.Background() when validating without one.
+func (r *StructReporter) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
+// StructLevel exposes the underlying validator.StructLevel for advanced use.
+func (r *StructReporter) StructLevel() validator.StructLevel { return r.sl }
+
+// RegisterStructRule registers a struct-level rule for T on the global Validator.
+// The rule receives the struct value and a reporter that attributes errors to
+// specific fields:
+//
+//	validate.RegisterStructRule(func(b Booking, rep *validate.StructReporter) {
+//		if !b.EndDate.After(b.StartDate) {
+//			rep.AddFieldError("end_date", "must be after start_date")
+//		}
+//	})
+func RegisterStructRule[T any](fn func(v T, rep *StructReporter)) {
+	var zero T
+	Default().RegisterStructValidationCtx(func(ctx context.Context, sl validator.StructLevel) {
+		v, ok := sl.Current().Interface().(T)
+		if !ok {
+			return
+		}
+		fn(v, &StructReporter{ctx: ctx, sl: sl})
+	}, zero)
+}
@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	globalValidator "github.com/go-playground/validator/v10"
+)
+
+type booking struct {
+	StartDate time.Time `json:"start_date" validate:"required"`
+	EndDate   time.Time `json:"end_date" validate:"required"`
+	Guests    int       `json:"guests"`
+}
+
+func init() {
+	RegisterStructRule(func(b booking, rep *StructReporter) {
+		if !b.EndDate.After(b.StartDate) {
+			rep.AddFieldError("end_date", "must be after start_date")
+		}
+		if b.Guests > 10 {
+			rep.AddFieldTag("guests", "max", "10")
+		}
+	})
+}
+
+func TestRegisterStructRule_AttributesToField(t *testing.T) {
+	now := time.Now()
+	err := Struct(booking{StartDate: now, EndDate: now.Add(-time.Hour), Guests: 12})
+	m := ToFieldErrors(err)
+	if m["end_date"] != "must be after start_date" {
+		t.Fatalf("expected struct rule error on end_date, got %v", m)
+	}
+	if m["guests"] != "must be at most 10" {
+		t.Fatalf("expected tag-based struct rule error on guests, got %v", m)
+	}
+}
+
+func TestRegisterStructRule_LiteralMessageBypassesMessageFunc(t *testing.T) {
+	defer SetMessageFunc(nil)
+	SetMessageFunc(func(globalValidator.FieldError) string { return "TRANSLATED" })
+	now := time.Now()
+	m := ToFieldErrors(Struct(booking{StartDate: now, EndDate: now, Guests: 11}))
+	if m["end_date"] != "must be after start_date" {
+		t.Fatalf("expected literal message, got %v", m)
+	}
+	if m["guests"] != "TRANSLATED" {
+		t.Fatalf("expected tag error to use message func, got %v", m)
+	}
+}
+
+func TestRegisterStructRule_Valid(t *testing.T) {
+	now := time.Now()
+	if err := Struct(booking{StartDate: now, EndDate: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// unexpectedMessage is the message binders report for unknown input fields.
+const unexpectedMessage = "unexpected"
+
+// SuggestField returns the json field name of v's struct type that most
+// closely matches name, or "" if none is close enough. Dotted names such as
+// "address.zpi" are resolved through nested structs; only the last segment is
+// matched and the suggestion is returned without the prefix.
+func SuggestField(v any, name string) string {
+	t := reflect.TypeOf(v)
+	parts := strings.Split(name, ".")
+	for _, p := range parts[:len(parts)-1] {
+		if _, err := strconv.Atoi(p); err == nil {
+			continue // slice index, e.g. "items.0.name"
+		}
+		t = fieldType(t, p)
+		if t == nil {
+			return ""
+		}
+	}
+	return closestName(jsonFieldNames(t), parts[len(parts)-1])
+}
+
+// suggestUnexpected rewrites "unexpected" messages in res with a did-you-mean
+// hint against v's json field names.
+func suggestUnexpected(res map[string]string, v any) {
+	for k, msg := range res {
+		if msg != unexpectedMessage {
+			continue
+		}
+		if s := SuggestField(v, k); s != "" {
+			res[k] = fmt.Sprintf("unexpected field; did you mean '%s'?", s)
+		}
+	}
+}
+
+// fieldType returns the type of the field with json name name in t, following
+// pointers, slices and maps down to a struct.
+func fieldType(t reflect.Type, name string) reflect.Type {
+	t = structType(t)
+	if t == nil {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			if ft := fieldType(sf.Type, name); ft != nil {
+				return ft
+			}
+			continue
+		}
+		if sf.IsExported() && jsonName(sf) == name {
+			return structType(sf.Type)
+		}
+	}
+	return nil
+}
+
+func structType(t reflect.Type) reflect.Type {
+	for t != nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			return t
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func jsonFieldNames(t reflect.Type) []string {
+	t = structType(t)
+	if t == nil {
+		return nil
+	}
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			names = append(names, jsonFieldNames(sf.Type)...)
+			continue
+		}
+		if n := jsonName(sf); n != "" && sf.IsExported() {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// closestName picks the candidate with the smallest edit distance to name,
+// ignoring case, "_" and "-". Distances above a third of the name length (at
+// least 1, at most 3) are not suggested.
+func closestName(candidates []string, name string) string {
+	target := normalizeName(name)
+	limit := len(target) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	if limit > 3 {
+		limit = 3
+	}
+	best, bestDist := "", limit+1
+	for _, c := range candidates {
+		if d := editDistance(target, normalizeName(c)); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+func normalizeName(s string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(s))
+}
+
+// editDistance is the Levenshtein distance between a and b, counting an
+// adjacent transposition as one edit.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
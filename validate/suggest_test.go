@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"net/http"
+	"testing"
+)
+
+type suggestAddress struct {
+	PostalCode string `json:"postal_code"`
+}
+
+type suggestBase struct {
+	ID string `json:"id"`
+}
+
+type suggestUser struct {
+	suggestBase
+	Email     string           `json:"email"`
+	FirstName string           `json:"firstName"`
+	Address   suggestAddress   `json:"address"`
+	Others    []suggestAddress `json:"others"`
+	Secret    string           `json:"-"`
+}
+
+func TestSuggestField(t *testing.T) {
+	cases := map[string]string{
+		"emial":                "email",
+		"Email":                "email",
+		"first_name":           "firstName",
+		"address.postcode":     "postal_code",
+		"address.postalcod":    "postal_code",
+		"others.0.postal":      "",
+		"others.0.postal-code": "postal_code",
+		"zzzzz":                "",
+		"Secret":               "",
+		"nope.email":           "",
+	}
+	for in, want := range cases {
+		if got := SuggestField(&suggestUser{}, in); got != want {
+			t.Fatalf("SuggestField(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := SuggestField(suggestUser{}, "idd"); got != "id" {
+		t.Fatalf("expected promoted field from embedded struct, got %q", got)
+	}
+	if SuggestField(42, "x") != "" {
+		t.Fatalf("expected no suggestion for non-struct")
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, c := range []struct {
+		a, b string
+		d    int
+	}{{"", "abc", 3}, {"email", "emial", 1}, {"kitten", "sitting", 3}, {"same", "same", 0}} {
+		if got := editDistance(c.a, c.b); got != c.d {
+			t.Fatalf("editDistance(%q, %q) = %d, want %d", c.a, c.b, got, c.d)
+		}
+	}
+}
+
+func TestBindAndValidate_UnknownFieldSuggestion(t *testing.T) {
+	_, fe := serveBind(t, http.MethodPost, `{"name":"Ann","age":30,"agee":1,"zzz":1}`, "application/json")
+	if fe["agee"] != "unexpected field; did you mean 'age'?" || fe["zzz"] != "unexpected" {
+		t.Fatalf("unexpected errors: %v", fe)
+	}
+}
@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// SyntaxError is a malformed JSON body with the position of the error, as
+// reported by the JSON binder of BindAndValidate. ToFieldErrors reports it under the "_syntax"
+// key: {"_syntax": "line 3, column 7: invalid character '}' looking for
+// beginning of value"}.
+type SyntaxError struct {
+	// Line and Column are 1-based; Column counts characters, not bytes.
+	Line, Column int
+	// Offset is the byte offset into the body.
+	Offset int64
+	// Err is the decoder's error: a *json.SyntaxError or
+	// io.ErrUnexpectedEOF for a truncated body.
+	Err error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.message())
+}
+
+// Unwrap returns the decoder's error.
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+func (e *SyntaxError) message() string {
+	if errors.Is(e.Err, io.ErrUnexpectedEOF) {
+		return "unexpected end of JSON input"
+	}
+	return e.Err.Error()
+}
+
+// withSyntaxPosition returns err as a *SyntaxError positioned in body when it
+// is a JSON syntax error or a truncated body, or err unchanged.
+func withSyntaxPosition(err error, body []byte) error {
+	var se *json.SyntaxError
+	var offset int64
+	switch {
+	case errors.As(err, &se):
+		// Offset counts the bytes read, including the offending one.
+		offset = se.Offset - 1
+		if offset < 0 {
+			offset = 0
+		}
+		err = se
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		offset = int64(len(body))
+	default:
+		return err
+	}
+	if offset > int64(len(body)) {
+		offset = int64(len(body))
+	}
+	line, col := lineColumn(body, int(offset))
+	return &SyntaxError{Line: line, Column: col, Offset: offset, Err: err}
+}
+
+// lineColumn returns the 1-based line and column of byte offset in body.
+func lineColumn(body []byte, offset int) (line, col int) {
+	before := body[:offset]
+	line = 1 + bytes.Count(before, []byte{'\n'})
+	start := bytes.LastIndexByte(before, '\n') + 1
+	return line, utf8.RuneCount(before[start:]) + 1
+}
@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestBindAndValidate_SyntaxPosition(t *testing.T) {
+	_, fe := serveBind(t, http.MethodPost, "{\n  \"name\": \"Ann\",\n  \"age\": }\n", "application/json")
+	want := "line 3, column 10: invalid character '}' looking for beginning of value"
+	if fe["_syntax"] != want || len(fe) != 1 {
+		t.Fatalf("expected %q, got %v", want, fe)
+	}
+	_, fe = serveBind(t, http.MethodPost, "{\"name\": \"Zoë\",\n \"age\": 3", "application/json")
+	if fe["_syntax"] != "line 2, column 10: unexpected end of JSON input" {
+		t.Fatalf("expected truncated body at its end, got %v", fe)
+	}
+}
+
+func TestSyntaxError(t *testing.T) {
+	body := []byte("[1,\n\"é\" x]")
+	var v []any
+	err := withSyntaxPosition(json.Unmarshal(body, &v), body)
+	var se *SyntaxError
+	if !errors.As(err, &se) || se.Line != 2 || se.Column != 5 || se.Offset != 9 {
+		t.Fatalf("expected line 2, column 5 at offset 9, got %#v", err)
+	}
+	var jse *json.SyntaxError
+	if !errors.As(err, &jse) {
+		t.Fatalf("expected wrapped *json.SyntaxError")
+	}
+	if g := ToErrorGroups(context.Background(), err); g.Binding["_syntax"] == "" {
+		t.Fatalf("expected _syntax in binding group, got %+v", g)
+	}
+
+	other := errors.New("boom")
+	if withSyntaxPosition(other, body) != other || withSyntaxPosition(nil, body) != nil {
+		t.Fatalf("expected other errors unchanged")
+	}
+}
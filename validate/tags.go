@@ -0,0 +1,50 @@
+package validate
+
+import "sort"
+
+// RegisteredTag describes a custom tag registered through this package.
+type RegisteredTag struct {
+	// Tag is the tag name used in `validate` struct tags.
+	Tag string `json:"tag"`
+	// Alias is the expansion of an alias registered with RegisterAlias, e.g.
+	// "numeric,len=5"; empty for tags backed by a validation function.
+	Alias string `json:"alias,omitempty"`
+	// Message is the default message template, or empty when the tag has
+	// none and failures fall back to "failed <tag>".
+	Message string `json:"message,omitempty"`
+}
+
+// RegisteredTags returns the custom tags and aliases of the global Validator
+// registered through this package (its built-in tags and the application's
+// RegisterValidation, RegisterValidationWithMessage, RegisterAlias, ...
+// calls), sorted by tag. A startup check can compare them with the tags its
+// structs use to catch a missing registration before the first request:
+//
+//	known := map[string]bool{}
+//	for _, t := range validate.RegisteredTags() {
+//		known[t.Tag] = true
+//	}
+//
+// Tags registered directly on the underlying *validator.Validate and the
+// validator's baked-in tags are not included.
+func RegisteredTags() []RegisteredTag { return Default().RegisteredTags() }
+
+// RegisteredTags returns the custom tags and aliases registered on e. See the
+// package-level RegisteredTags.
+func (e *DefaultEngine) RegisteredTags() []RegisteredTag {
+	e.mu.RLock()
+	out := make([]RegisteredTag, 0, len(e.tags))
+	for tag, alias := range e.tags {
+		out = append(out, RegisteredTag{Tag: tag, Alias: alias})
+	}
+	e.mu.RUnlock()
+	for i := range out {
+		if msg, ok := e.rawMessage(out[i].Tag, "", DefaultMessageLocale); ok {
+			out[i].Message = msg
+		} else {
+			out[i].Message = defaultMessages[out[i].Tag]
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Tag < out[j].Tag })
+	return out
+}
@@ -0,0 +1,54 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestRegisteredTags(t *testing.T) {
+	e := NewEngine()
+	if err := e.RegisterValidationWithMessage("sku_tag", func(validator.FieldLevel) bool { return true },
+		map[string]string{"en": "must be a valid SKU", "es": "debe ser un SKU válido"}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if err := e.RegisterValidation("bare_tag", func(validator.FieldLevel) bool { return true }); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	e.RegisterAlias("zip5", "numeric,len=5", "must be a 5-digit ZIP code")
+
+	tags := map[string]RegisteredTag{}
+	list := e.RegisteredTags()
+	for i, rt := range list {
+		if i > 0 && list[i-1].Tag >= rt.Tag {
+			t.Fatalf("expected tags sorted, got %s before %s", list[i-1].Tag, rt.Tag)
+		}
+		tags[rt.Tag] = rt
+	}
+	if got := tags["sku_tag"]; got.Message != "must be a valid SKU" || got.Alias != "" {
+		t.Fatalf("unexpected sku_tag: %+v", got)
+	}
+	if got, ok := tags["bare_tag"]; !ok || got.Message != "" {
+		t.Fatalf("unexpected bare_tag: %+v", got)
+	}
+	if got := tags["zip5"]; got.Alias != "numeric,len=5" || got.Message != "must be a 5-digit ZIP code" {
+		t.Fatalf("unexpected alias: %+v", got)
+	}
+	// Built-in tags of this package are included, baked-in ones are not.
+	if _, ok := tags["decimal_places"]; !ok {
+		t.Fatalf("expected built-in decimal_places tag")
+	}
+	if _, ok := tags["required"]; ok {
+		t.Fatalf("did not expect baked-in required tag")
+	}
+
+	// Clones keep the tags; the global engine does not see them.
+	if len(e.Clone().RegisteredTags()) != len(list) {
+		t.Fatalf("expected clone to keep registered tags")
+	}
+	for _, rt := range RegisteredTags() {
+		if rt.Tag == "sku_tag" {
+			t.Fatalf("engine tags leaked into the global engine")
+		}
+	}
+}
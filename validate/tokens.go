@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// TokenFormat describes the part of a prefixed token (API key) after its
+// prefix.
+type TokenFormat struct {
+	// MinLength and MaxLength bound the length after the prefix. Zero
+	// values mean at least 1 and no maximum.
+	MinLength, MaxLength int
+	// Charset lists the allowed characters after the prefix. Default:
+	// ASCII letters and digits.
+	Charset string
+}
+
+var (
+	tokenFormatsMu sync.RWMutex
+	tokenFormats   = map[string]TokenFormat{}
+)
+
+func init() {
+	mustRegister("token_prefix", hasTokenPrefix, map[string]string{DefaultMessageLocale: "must be a token starting with {param}"})
+}
+
+// RegisterTokenPrefix sets the format of tokens with prefix, used by
+// `token_prefix=<prefix>`, so provider keys can be shape-checked without
+// calling the provider:
+//
+//	validate.RegisterTokenPrefix("sk_live_", validate.TokenFormat{MinLength: 24, MaxLength: 99})
+//
+//	type Integration struct {
+//		SecretKey string `json:"secret_key" validate:"required,token_prefix=sk_live_ sk_test_"`
+//	}
+//
+// Prefixes without a registered format accept one or more ASCII letters and
+// digits. Registering a prefix again replaces its format.
+func RegisterTokenPrefix(prefix string, f TokenFormat) {
+	tokenFormatsMu.Lock()
+	tokenFormats[prefix] = f
+	tokenFormatsMu.Unlock()
+}
+
+// hasTokenPrefix accepts strings that start with one of the space-separated
+// prefixes of the parameter and whose remainder matches that prefix's format.
+func hasTokenPrefix(fl validator.FieldLevel) bool {
+	if fl.Field().Kind() != reflect.String {
+		return false
+	}
+	s := fl.Field().String()
+	for _, prefix := range strings.Fields(fl.Param()) {
+		if rest, ok := strings.CutPrefix(s, prefix); ok && tokenFormatFor(prefix).match(rest) {
+			return true
+		}
+	}
+	return false
+}
+
+func tokenFormatFor(prefix string) TokenFormat {
+	tokenFormatsMu.RLock()
+	defer tokenFormatsMu.RUnlock()
+	return tokenFormats[prefix]
+}
+
+func (f TokenFormat) match(s string) bool {
+	minLen := f.MinLength
+	if minLen < 1 {
+		minLen = 1
+	}
+	if len(s) < minLen || f.MaxLength > 0 && len(s) > f.MaxLength {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if f.Charset == "" {
+			if !isASCIILetter(c) && (c < '0' || c > '9') {
+				return false
+			}
+		} else if strings.IndexByte(f.Charset, c) < 0 {
+			return false
+		}
+	}
+	return true
+}
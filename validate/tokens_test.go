@@ -0,0 +1,38 @@
+package validate
+
+import "testing"
+
+func TestTokenPrefix(t *testing.T) {
+	RegisterTokenPrefix("test_sk_", TokenFormat{MinLength: 8, MaxLength: 12})
+	RegisterTokenPrefix("test_hex_", TokenFormat{MinLength: 4, MaxLength: 4, Charset: "0123456789abcdef"})
+	defer func() {
+		tokenFormatsMu.Lock()
+		delete(tokenFormats, "test_sk_")
+		delete(tokenFormats, "test_hex_")
+		tokenFormatsMu.Unlock()
+	}()
+	cases := []struct {
+		value any
+		tag   string
+		ok    bool
+	}{
+		{"test_sk_abcDEF12", "token_prefix=test_sk_", true},
+		{"test_sk_abc", "token_prefix=test_sk_", false},
+		{"test_sk_abcdefghijklm", "token_prefix=test_sk_", false},
+		{"test_sk_abc-def-12", "token_prefix=test_sk_", false},
+		{"test_hex_0f9a", "token_prefix=test_sk_ test_hex_", true},
+		{"test_hex_0F9A", "token_prefix=test_sk_ test_hex_", false},
+		{"ghp_x1", "token_prefix=ghp_", true},
+		{"ghp_", "token_prefix=ghp_", false},
+		{"pk_live_abc", "token_prefix=sk_live_", false},
+		{42, "token_prefix=ghp_", false},
+	}
+	for _, c := range cases {
+		if err := Var("key", c.value, c.tag); (err == nil) != c.ok {
+			t.Fatalf("%v %s: expected ok=%v, got %v", c.value, c.tag, c.ok, err)
+		}
+	}
+	if got := ToFieldErrors(Var("key", "pk_live_abc", "token_prefix=sk_live_"))["key"]; got != "must be a token starting with sk_live_" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
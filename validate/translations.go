@@ -0,0 +1,85 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MissingTranslation is a message a locale has no translation for.
+type MissingTranslation struct {
+	// Locale is the checked locale.
+	Locale string `json:"locale"`
+	// Tag and Param identify the message, as in CatalogEntry.
+	Tag   string `json:"tag"`
+	Param string `json:"param,omitempty"`
+	// Fallback is the default (English) template served instead.
+	Fallback string `json:"fallback"`
+}
+
+// CheckTranslations reports, for each locale, the messages of the error
+// catalog (see Catalog) without a message registered for that locale, which
+// clients of that locale get in English. Run it in CI to block releases with
+// incomplete catalogs:
+//
+//	if missing := validate.CheckTranslations("es", "de"); len(missing) > 0 {
+//		t.Fatalf("untranslated messages: %v", missing)
+//	}
+//
+// Use CheckTranslationsWith when messages come from a message function, such
+// as translators. DefaultMessageLocale is not checked.
+func CheckTranslations(locales ...string) []MissingTranslation {
+	return Default().CheckTranslationsWith(nil, locales...)
+}
+
+// CheckTranslationsWith is CheckTranslations for apps translating with message
+// functions, typically the MessageFuncFor of the i18n middleware. Each
+// catalog message without a registered translation is run through the
+// locale's function with a sample FieldError; it is missing when the function
+// returns "" or the same message as for DefaultMessageLocale. The sample's
+// Translate method looks the tag up in the translator it is given, so
+// translator-backed functions (see TranslatorMessageFunc) are checked against
+// the translations loaded for each locale.
+func CheckTranslationsWith(messageFuncFor func(locale string) func(validator.FieldError) string, locales ...string) []MissingTranslation {
+	return Default().CheckTranslationsWith(messageFuncFor, locales...)
+}
+
+// CheckTranslations runs CheckTranslations on e.
+func (e *DefaultEngine) CheckTranslations(locales ...string) []MissingTranslation {
+	return e.CheckTranslationsWith(nil, locales...)
+}
+
+// CheckTranslationsWith runs CheckTranslationsWith on e.
+func (e *DefaultEngine) CheckTranslationsWith(messageFuncFor func(locale string) func(validator.FieldError) string, locales ...string) []MissingTranslation {
+	var defaultFunc func(validator.FieldError) string
+	if messageFuncFor != nil {
+		defaultFunc = messageFuncFor(DefaultMessageLocale)
+	}
+	var missing []MissingTranslation
+	catalog := e.Catalog()
+	for _, l := range locales {
+		locale := strings.ToLower(l)
+		if locale == DefaultMessageLocale {
+			continue
+		}
+		var fn func(validator.FieldError) string
+		if messageFuncFor != nil {
+			fn = messageFuncFor(locale)
+		}
+		for _, ce := range catalog {
+			if ce.Translations[locale] != "" {
+				continue
+			}
+			if fn != nil {
+				fe := &updateFieldError{tag: ce.Tag, param: ce.Param, field: "field", typ: reflect.TypeOf("")}
+				msg := fn(fe)
+				if msg != "" && msg != ce.Template && (defaultFunc == nil || msg != defaultFunc(fe)) {
+					continue
+				}
+			}
+			missing = append(missing, MissingTranslation{Locale: locale, Tag: ce.Tag, Param: ce.Param, Fallback: ce.Template})
+		}
+	}
+	return missing
+}
@@ -0,0 +1,98 @@
+package validate
+
+import (
+	"testing"
+
+	enLocale "github.com/go-playground/locales/en"
+	esLocale "github.com/go-playground/locales/es"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	esTranslations "github.com/go-playground/validator/v10/translations/es"
+)
+
+func TestCheckTranslations(t *testing.T) {
+	e := NewEngine()
+	_ = e.RegisterValidationWithMessage("sku_check", func(validator.FieldLevel) bool { return true },
+		map[string]string{"en": "must be a valid SKU", "es": "debe ser un SKU válido"})
+
+	missing := map[string]MissingTranslation{}
+	for _, m := range e.CheckTranslations("en", "ES", "de") {
+		missing[m.Locale+" "+m.Tag] = m
+	}
+	if _, ok := missing["es sku_check"]; ok {
+		t.Fatalf("es has a translation for sku_check")
+	}
+	if m, ok := missing["de sku_check"]; !ok || m.Fallback != "must be a valid SKU" {
+		t.Fatalf("expected de sku_check to be missing, got %+v", m)
+	}
+	if _, ok := missing["es required"]; !ok {
+		t.Fatalf("expected built-in messages to be missing for es")
+	}
+	for key := range missing {
+		if key[:3] == "en " {
+			t.Fatalf("default locale should not be checked: %s", key)
+		}
+	}
+}
+
+func TestCheckTranslationsWith(t *testing.T) {
+	e := NewEngine()
+	fr := map[string]string{"required": "est obligatoire", "min": "doit contenir au moins {param}"}
+	funcFor := func(locale string) func(validator.FieldError) string {
+		return func(fe validator.FieldError) string {
+			switch locale {
+			case "fr":
+				return fr[fe.Tag()]
+			case "de":
+				return "failed " + fe.Tag() // same as en: not translated
+			}
+			return "failed " + fe.Tag()
+		}
+	}
+	missing := map[string]bool{}
+	for _, m := range e.CheckTranslationsWith(funcFor, "fr", "de") {
+		missing[m.Locale+" "+m.Tag] = true
+	}
+	if missing["fr required"] || missing["fr min"] {
+		t.Fatalf("expected fr required and min to be translated, got %v", missing)
+	}
+	if !missing["fr email"] || !missing["de required"] {
+		t.Fatalf("expected untranslated messages to be reported, got %v", missing)
+	}
+}
+
+func TestCheckTranslationsWith_Translator(t *testing.T) {
+	e := NewEngine()
+	uni := ut.New(enLocale.New(), enLocale.New(), esLocale.New())
+	en, _ := uni.GetTranslator("en")
+	es, _ := uni.GetTranslator("es")
+	if err := enTranslations.RegisterDefaultTranslations(e.Validate(), en); err != nil {
+		t.Fatal(err)
+	}
+	if err := esTranslations.RegisterDefaultTranslations(e.Validate(), es); err != nil {
+		t.Fatal(err)
+	}
+	funcFor := func(locale string) func(validator.FieldError) string {
+		trans, _ := uni.GetTranslator(locale)
+		return TranslatorMessageFunc(trans)
+	}
+
+	missing := e.CheckTranslationsWith(funcFor, "es")
+	added := map[string]bool{}
+	for _, m := range missing {
+		if m.Tag == "required" || m.Tag == "min" || m.Tag == "email" {
+			t.Fatalf("expected %s to be translated by the es translator, got %v", m.Tag, missing)
+		}
+		if added[m.Tag] {
+			continue
+		}
+		added[m.Tag] = true
+		if err := es.Add(m.Tag, "{0} falla "+m.Tag, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if missing := e.CheckTranslationsWith(funcFor, "es"); len(missing) != 0 {
+		t.Fatalf("expected a translator covering every tag to report nothing, got %v", missing)
+	}
+}
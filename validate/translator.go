@@ -0,0 +1,98 @@
+package validate
+
+import (
+	"context"
+
+	"github.com/go-playground/locales"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// Context key for storing a per-request translator.
+type ctxKeyTranslator struct{}
+
+// WithTranslator attaches a universal-translator to a non-nil context, so
+// downstream code (custom validators, response renderers) can translate
+// arbitrary keys for the request:
+//
+//	if trans := validate.TranslatorFromContext(c.Context()); trans != nil {
+//		msg, _ := trans.T("order_limit", limit)
+//	}
+//
+// When the context has no message function (see WithMessageFunc), field
+// errors are translated with it as well; tags without a translation fall
+// through to the remaining message sources.
+func WithTranslator(ctx context.Context, trans ut.Translator) context.Context {
+	return context.WithValue(ctx, ctxKeyTranslator{}, trans)
+}
+
+// TranslatorFromContext returns the translator attached with WithTranslator,
+// or nil.
+func TranslatorFromContext(ctx context.Context) ut.Translator {
+	if ctx == nil {
+		return nil
+	}
+	trans, _ := ctx.Value(ctxKeyTranslator{}).(ut.Translator)
+	return trans
+}
+
+// TranslatorMessageFunc returns a message function translating field errors
+// with trans. Tags without a registered translation yield "", so callers fall
+// back to other messages instead of the raw validator error text.
+func TranslatorMessageFunc(trans ut.Translator) func(validator.FieldError) string {
+	return func(fe validator.FieldError) string {
+		if msg := fe.Translate(trans); msg != fe.Error() {
+			return msg
+		}
+		return ""
+	}
+}
+
+// contextMessageFunc returns the message function of ctx: the one attached
+// with WithMessageFunc, else one backed by the translator of ctx, else nil.
+func contextMessageFunc(ctx context.Context) func(validator.FieldError) string {
+	if fn := MessageFuncFromContext(ctx); fn != nil {
+		return fn
+	}
+	if trans := TranslatorFromContext(ctx); trans != nil {
+		return TranslatorMessageFunc(trans)
+	}
+	return nil
+}
+
+// RegisterTranslations registers the translations of trans on the validator
+// of e with fn, typically a go-playground/validator translations package, and
+// returns the translator to translate with:
+//
+//	trans, err = validate.Default().RegisterTranslations(trans, es_translations.RegisterDefaultTranslations)
+//
+// Unlike calling fn on Validate() directly, the registration is recorded, so
+// clones of e (see Clone) translate as well. The returned translator wraps
+// trans so that the replay can add its messages again; messages added
+// through it replace existing ones.
+func (e *DefaultEngine) RegisterTranslations(trans ut.Translator, fn func(*validator.Validate, ut.Translator) error) (ut.Translator, error) {
+	if _, ok := trans.(replayTranslator); !ok {
+		trans = replayTranslator{trans}
+	}
+	return trans, e.register(func(v *validator.Validate) error { return fn(v, trans) })
+}
+
+// replayTranslator is a translator whose messages can be added again, so
+// recorded translation registrations can be replayed on clones.
+type replayTranslator struct{ ut.Translator }
+
+func (t replayTranslator) Add(key any, text string, _ bool) error {
+	return t.Translator.Add(key, text, true)
+}
+
+func (t replayTranslator) AddCardinal(key any, text string, rule locales.PluralRule, _ bool) error {
+	return t.Translator.AddCardinal(key, text, rule, true)
+}
+
+func (t replayTranslator) AddOrdinal(key any, text string, rule locales.PluralRule, _ bool) error {
+	return t.Translator.AddOrdinal(key, text, rule, true)
+}
+
+func (t replayTranslator) AddRange(key any, text string, rule locales.PluralRule, _ bool) error {
+	return t.Translator.AddRange(key, text, rule, true)
+}
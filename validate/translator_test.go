@@ -0,0 +1,97 @@
+package validate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+func newTestTranslator(t *testing.T, e *DefaultEngine) ut.Translator {
+	t.Helper()
+	loc := en.New()
+	trans, _ := ut.New(loc, loc).GetTranslator("en")
+	if err := trans.Add("order_limit", "at most {0} orders", false); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	err := e.Validate().RegisterTranslation("required", trans,
+		func(ut ut.Translator) error { return ut.Add("required", "{0} is mandatory", false) },
+		func(ut ut.Translator, fe validator.FieldError) string {
+			msg, _ := ut.T("required", fe.Field())
+			return msg
+		})
+	if err != nil {
+		t.Fatalf("register translation: %v", err)
+	}
+	return trans
+}
+
+func TestWithTranslator_RoundTrip(t *testing.T) {
+	if TranslatorFromContext(context.Background()) != nil {
+		t.Fatalf("expected no translator")
+	}
+	trans := newTestTranslator(t, NewEngine())
+	ctx := WithTranslator(context.Background(), trans)
+	got := TranslatorFromContext(ctx)
+	if got == nil {
+		t.Fatalf("expected translator")
+	}
+	if msg, _ := got.T("order_limit", "3"); msg != "at most 3 orders" {
+		t.Fatalf("unexpected translation: %q", msg)
+	}
+}
+
+func TestToFieldErrorsWithContext_Translator(t *testing.T) {
+	e := NewEngine()
+	trans := newTestTranslator(t, e)
+	type form struct {
+		Name  string `json:"name" validate:"required"`
+		Email string `json:"email" validate:"email"`
+	}
+	err := e.Struct(form{Email: "nope"})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	ctx := WithEngine(WithTranslator(context.Background(), trans), e)
+	got := ToFieldErrorsWithContext(ctx, err)
+	if got["name"] != "name is mandatory" {
+		t.Fatalf("expected translated message, got %q", got["name"])
+	}
+	// No translation for "email": the built-in default is used.
+	if got["email"] != "must be a valid email" {
+		t.Fatalf("expected default message, got %q", got["email"])
+	}
+
+	// A message func from context takes precedence over the translator.
+	ctx = WithMessageFunc(ctx, func(validator.FieldError) string { return "MF" })
+	if got := ToFieldErrorsWithContext(ctx, err); got["name"] != "MF" {
+		t.Fatalf("expected message func to win, got %q", got["name"])
+	}
+}
+
+func TestDefaultEngine_RegisterTranslations_Clone(t *testing.T) {
+	e := NewEngine()
+	loc := en.New()
+	base, _ := ut.New(loc, loc).GetTranslator("en")
+	trans, err := e.RegisterTranslations(base, func(v *validator.Validate, trans ut.Translator) error {
+		return v.RegisterTranslation("required", trans,
+			func(ut ut.Translator) error { return ut.Add("required", "{0} is mandatory", false) },
+			func(ut ut.Translator, fe validator.FieldError) string {
+				msg, _ := ut.T("required", fe.Field())
+				return msg
+			})
+	})
+	if err != nil {
+		t.Fatalf("RegisterTranslations: %v", err)
+	}
+	c := e.Clone()
+	err = c.Struct(struct {
+		Name string `json:"name" validate:"required"`
+	}{})
+	ctx := WithEngine(WithTranslator(context.Background(), trans), c)
+	if got := ToFieldErrorsWithContext(ctx, err)["name"]; got != "name is mandatory" {
+		t.Fatalf("expected the clone to translate, got %q", got)
+	}
+}
@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+)
+
+// RegisterCommonTypes registers CustomTypeFuncs on the global Validator so
+// tags behave intuitively on the sql.Null* wrapper types: sql.NullString,
+// sql.NullInt64, sql.NullInt32, sql.NullInt16, sql.NullByte, sql.NullFloat64,
+// sql.NullBool and sql.NullTime validate their inner value, and an invalid
+// (NULL) value is nil, so `required` fails and `omitempty` skips.
+//
+// Decimal and UUID types are registered by the typesvalidate module, which
+// keeps their dependencies out of this one.
+//
+// time.Duration needs no registration: the validator compares durations
+// natively and accepts duration parameters such as `gt=0s,max=1h`.
+//
+// Call it once at startup, before validating.
+func RegisterCommonTypes() {
+	Default().RegisterCommonTypes()
+}
+
+// RegisterCommonTypes registers the common wrapper types on e. See the
+// package-level RegisterCommonTypes.
+func (e *DefaultEngine) RegisterCommonTypes() {
+	e.RegisterCustomTypeFunc(nullValue,
+		sql.NullString{}, sql.NullInt64{}, sql.NullInt32{}, sql.NullInt16{}, sql.NullByte{},
+		sql.NullFloat64{}, sql.NullBool{}, sql.NullTime{},
+	)
+}
+
+// nullValue unwraps sql.Null* types through driver.Valuer.
+func nullValue(field reflect.Value) any {
+	if v, ok := field.Interface().(driver.Valuer); ok {
+		if val, err := v.Value(); err == nil {
+			return val
+		}
+	}
+	return nil
+}
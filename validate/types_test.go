@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func init() { RegisterCommonTypes() }
+
+type account struct {
+	Nickname  sql.NullString  `json:"nickname" validate:"required,min=3"`
+	Age       sql.NullInt64   `json:"age" validate:"omitempty,gte=18"`
+	Confirmed sql.NullTime    `json:"confirmed" validate:"required"`
+	Limit     sql.NullFloat64 `json:"limit" validate:"omitempty,lte=1000"`
+	Timeout   time.Duration   `json:"timeout" validate:"gt=0s,max=1h"`
+}
+
+func TestRegisterCommonTypes_Invalid(t *testing.T) {
+	a := account{
+		Nickname: sql.NullString{String: "ab", Valid: true},
+		Age:      sql.NullInt64{Int64: 12, Valid: true},
+		Limit:    sql.NullFloat64{Float64: 5000, Valid: true},
+		Timeout:  2 * time.Hour,
+	}
+	m := ToFieldErrors(Struct(a))
+	want := map[string]string{
+		"nickname":  "must be at least 3",
+		"age":       "must be greater than or equal to 18",
+		"confirmed": "is required",
+		"limit":     "must be less than or equal to 1000",
+		"timeout":   "must be at most 1h",
+	}
+	for k, msg := range want {
+		if m[k] != msg {
+			t.Fatalf("field %q: expected %q, got %q (all: %v)", k, msg, m[k], m)
+		}
+	}
+	if len(m) != len(want) {
+		t.Fatalf("unexpected extra errors: %v", m)
+	}
+}
+
+func TestRegisterCommonTypes_Valid(t *testing.T) {
+	a := account{
+		Nickname:  sql.NullString{String: "abc", Valid: true},
+		Confirmed: sql.NullTime{Time: time.Now(), Valid: true},
+		Timeout:   time.Second,
+	}
+	if err := Struct(a); err != nil {
+		t.Fatalf("unexpected error: %v", ToFieldErrors(err))
+	}
+}
+
+func TestRegisterCommonTypes_NullStringRequired(t *testing.T) {
+	m := ToFieldErrors(Struct(account{}))
+	if m["nickname"] != "is required" {
+		t.Fatalf("expected NULL string to fail required, got %v", m)
+	}
+}
@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func init() {
+	// URL policy tags for callback and webhook fields. They accept only
+	// absolute URLs with a host, so they can replace `url` on such fields.
+	mustRegister("url_https", urlHTTPS, map[string]string{DefaultMessageLocale: "must be an HTTPS URL"})
+	mustRegister("url_hosts", urlHosts, map[string]string{DefaultMessageLocale: "must use an allowed host"})
+	mustRegister("url_no_userinfo", urlNoUserinfo, map[string]string{DefaultMessageLocale: "must not contain credentials"})
+}
+
+// absoluteURL parses the field as an absolute URL with a host.
+func absoluteURL(fl validator.FieldLevel) (*url.URL, bool) {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return nil, false
+	}
+	u, err := url.Parse(strings.TrimSpace(field.String()))
+	if err != nil || !u.IsAbs() || u.Hostname() == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+// urlHTTPS accepts absolute https URLs.
+func urlHTTPS(fl validator.FieldLevel) bool {
+	u, ok := absoluteURL(fl)
+	return ok && strings.EqualFold(u.Scheme, "https")
+}
+
+// urlHosts accepts URLs whose host matches one of the space-separated
+// patterns: an exact host name, or "*.example.com" for any subdomain of
+// example.com (not example.com itself). Ports are ignored.
+func urlHosts(fl validator.FieldLevel) bool {
+	u, ok := absoluteURL(fl)
+	if !ok {
+		return false
+	}
+	return hostAllowed(u.Hostname(), strings.Fields(fl.Param()))
+}
+
+func hostAllowed(host string, patterns []string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, p := range patterns {
+		p = strings.TrimSuffix(strings.ToLower(p), ".")
+		if suffix, ok := strings.CutPrefix(p, "*"); ok {
+			if strings.HasPrefix(suffix, ".") && strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return true
+			}
+			continue
+		}
+		if host == p {
+			return true
+		}
+	}
+	return false
+}
+
+// urlNoUserinfo accepts absolute URLs without "user:password@".
+func urlNoUserinfo(fl validator.FieldLevel) bool {
+	u, ok := absoluteURL(fl)
+	return ok && u.User == nil
+}
@@ -0,0 +1,37 @@
+package validate
+
+import "testing"
+
+type webhook struct {
+	Callback string `json:"callback" validate:"url_https,url_hosts=hooks.example.com *.trusted.com,url_no_userinfo"`
+}
+
+func TestURLPolicyTags(t *testing.T) {
+	for _, u := range []string{
+		"https://hooks.example.com/cb",
+		"HTTPS://HOOKS.EXAMPLE.COM:8443/cb",
+		"https://a.b.trusted.com/x?y=1",
+		"https://api.trusted.com./x",
+	} {
+		if err := Struct(webhook{Callback: u}); err != nil {
+			t.Fatalf("%q: unexpected error %v", u, ToFieldErrors(err))
+		}
+	}
+	cases := map[string]string{
+		"http://hooks.example.com/cb":          "must be an HTTPS URL",
+		"/relative":                            "must be an HTTPS URL",
+		"https://trusted.com/cb":               "must use an allowed host",
+		"https://eviltrusted.com/cb":           "must use an allowed host",
+		"https://hooks.example.com.evil.io/cb": "must use an allowed host",
+		"https://user:pw@hooks.example.com/cb": "must not contain credentials",
+		"https://169.254.169.254/latest/meta":  "must use an allowed host",
+	}
+	for u, want := range cases {
+		if m := ToFieldErrors(Struct(webhook{Callback: u})); m["callback"] != want {
+			t.Fatalf("%q: expected %q, got %v", u, want, m)
+		}
+	}
+	if Var("u", 1, "url_https") == nil || Var("u", "https://", "url_no_userinfo") == nil {
+		t.Fatalf("expected non-strings and host-less URLs to fail")
+	}
+}
@@ -2,16 +2,22 @@ package validate
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/goflash/flash/v2/ctx"
 )
 
-// Validator is the global validator instance for goflash validation helpers.
-// You can register custom tags and tag name functions on it.
+// Validator is the global validator instance for goflash validation helpers:
+// the validator of the default engine (see Default), configured with the
+// built-ins as the package initializes. You can register custom
+// tags and tag name functions on it; register through Default() instead when
+// the registrations should be carried over by Clone. SetDefault replaces it.
 var Validator = validator.New()
 
 // messageFunc, if set by the application, converts a FieldError to a human message.
@@ -52,21 +58,26 @@ func MessageFuncFromContext(ctx context.Context) func(validator.FieldError) stri
 
 func init() {
 	// Use `json` tag names in error messages instead of struct field names.
-	Validator.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		name := fld.Tag.Get("json")
-		if name == "" || name == "-" {
-			return ""
-		}
-		if idx := strings.Index(name, ","); idx >= 0 {
-			name = name[:idx]
-		}
-		return name
-	})
+	registerBuiltin(func(e *DefaultEngine) { e.RegisterTagNameFunc(jsonTagName) })
 }
 
-// Struct validates a struct using `validate` tags and the global Validator.
+// Struct validates a struct using `validate` tags and the current engine (the
+// global Validator unless replaced with SetEngine).
 // Returns a ValidationErrors error if validation fails.
-func Struct(s any) error { return Validator.Struct(s) }
+func Struct(s any) error { return CurrentEngine().Struct(s) }
+
+// IsStruct reports whether v is a struct or a non-nil pointer to one, the
+// values Struct accepts. Interceptors use it to let other messages through.
+func IsStruct(v any) bool {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return false
+		}
+		rv = rv.Elem()
+	}
+	return rv.Kind() == reflect.Struct
+}
 
 // FieldErrors is an error type that carries a map of field->message.
 // Useful for mapping JSON binding or custom validation errors to field errors.
@@ -74,11 +85,48 @@ type FieldErrors map[string]string
 
 func (e FieldErrors) Error() string { return "field validation errors" }
 
+// StatusCode returns the HTTP status of field errors: 422 Unprocessable Entity.
+func (e FieldErrors) StatusCode() int { return http.StatusUnprocessableEntity }
+
+// ValidationError wraps a validation error (validator.ValidationErrors,
+// FieldErrors, ...) with the HTTP status to respond with, for error handlers
+// that render errors by status (see validator.ErrorHandler):
+//
+//	if err := validate.Struct(in); err != nil {
+//		return validate.NewValidationError(err, http.StatusBadRequest)
+//	}
+type ValidationError struct {
+	Err    error
+	Status int
+}
+
+// NewValidationError wraps err with status; 0 means 422.
+func NewValidationError(err error, status int) *ValidationError {
+	return &ValidationError{Err: err, Status: status}
+}
+
+func (e *ValidationError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the wrapped error.
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+// StatusCode returns the HTTP status, 422 Unprocessable Entity by default.
+func (e *ValidationError) StatusCode() int {
+	if e.Status == 0 {
+		return http.StatusUnprocessableEntity
+	}
+	return e.Status
+}
+
 // ToFieldErrors converts various error types into a simple field->message map.
 // Supports:
 // - flash ctx.FieldErrors (BindJSON errors for unknown fields/type mismatches)
-// - go-playground validator.ValidationErrors
+// - go-playground validator.ValidationErrors, keyed by field path (see FieldKey)
 // - validate.FieldErrors (this package)
+// - *BindingError, with the messages of both its errors
+// - *SyntaxError, under the "_syntax" key
+// - errors understood by parsers added with RegisterBindingErrorParser
+// - mapstructure-style decoding messages
 // Falls back to {"_error": err.Error()} otherwise.
 func ToFieldErrors(err error) map[string]string { return ToFieldErrorsWith(err, messageFunc) }
 
@@ -86,6 +134,11 @@ func ToFieldErrors(err error) map[string]string { return ToFieldErrorsWith(err,
 // for this call (e.g., a request-scoped translator). If fn is nil, the global SetMessageFunc
 // (if any) and then the built-in fallback will be used.
 func ToFieldErrorsWith(err error, fn func(validator.FieldError) string) map[string]string {
+	return toFieldErrors(context.Background(), err, fn)
+}
+
+// toFieldErrors implements ToFieldErrorsWith for the locale and engine in c.
+func toFieldErrors(c context.Context, err error, fn func(validator.FieldError) string) map[string]string {
 	res := map[string]string{}
 	if err == nil {
 		return res
@@ -96,12 +149,35 @@ func ToFieldErrorsWith(err error, fn func(validator.FieldError) string) map[stri
 		_ = handleCtxFieldErrors(err, res)
 		return res
 	case validator.ValidationErrors:
-		_ = handleValidationErrors(err, res, fn)
+		_ = handleValidationErrors(c, err, res, fn)
 		return res
 	case FieldErrors:
 		_ = handleDirectFieldErrors(err, res)
 		return res
 	}
+	var be *BindingError
+	if errors.As(err, &be) {
+		res = toFieldErrors(c, be.Err, fn)
+		if be.Validation != nil {
+			for k, v := range toFieldErrors(c, be.Validation, fn) {
+				if _, exists := res[k]; !exists {
+					res[k] = v
+				}
+			}
+		}
+		return res
+	}
+	var se *SyntaxError
+	if errors.As(err, &se) {
+		res["_syntax"] = se.Error()
+		return res
+	}
+	if fields, ok := parseBindingError(err); ok {
+		for k, v := range fields {
+			res[k] = v
+		}
+		return res
+	}
 	if handled := handleStructuredErrorMessage(err, res); handled {
 		return res
 	}
@@ -134,19 +210,71 @@ func handleCtxFieldErrors(err error, res map[string]string) bool {
 }
 
 // handleValidationErrors maps go-playground validator.ValidationErrors into res.
-func handleValidationErrors(err error, res map[string]string, fn func(validator.FieldError) string) bool {
+func handleValidationErrors(c context.Context, err error, res map[string]string, fn func(validator.FieldError) string) bool {
 	vErrs, ok := err.(validator.ValidationErrors)
 	if !ok {
 		return false
 	}
+	if t := messageEngine(c).english.Load(); t != nil {
+		fillValidationErrors(vErrs, res, t.message)
+		return true
+	}
+	fillValidationErrors(vErrs, res, func(fe validator.FieldError) string { return localizedMessage(c, fe, fn) })
+	return true
+}
+
+// fillValidationErrors adds the messages of vErrs to res, keyed by field.
+func fillValidationErrors(vErrs validator.ValidationErrors, res map[string]string, message func(validator.FieldError) string) {
 	for _, fe := range vErrs {
-		field := fe.Field()
-		if field == "" {
-			field = fe.StructField()
+		field := FieldKey(fe)
+		if expand := subPathErrors[fe.Tag()]; expand != nil {
+			if sub, ok := expand(field, fe); ok {
+				for k, v := range sub {
+					res[k] = v
+				}
+				continue
+			}
 		}
-		res[field] = humanMessageWith(fe, fn)
+		res[field] = message(fe)
 	}
-	return true
+}
+
+// fieldKeys caches FieldKey by namespace and field name. Namespaces start
+// with the name of the validated struct type and hold only field names unless
+// they go through slices or maps, so the cache holds one entry per field path
+// of each type.
+var fieldKeys sync.Map // fieldPath -> key
+
+type fieldPath struct{ namespace, field string }
+
+// FieldKey returns the key ToFieldErrors reports fe under: its namespace
+// without the struct type, as named by the tag-name func (e.g.
+// "address.city" or "items[0].sku"), or the field name for errors whose
+// namespace is the field name itself, such as those of Var. Keys are cached
+// per field path.
+func FieldKey(fe validator.FieldError) string {
+	p := fieldPath{fe.Namespace(), fe.Field()}
+	if k, ok := fieldKeys.Load(p); ok {
+		return k.(string)
+	}
+	key := p.field
+	if key == "" {
+		key = fe.StructField()
+	}
+	if _, path, ok := strings.Cut(p.namespace, "."); ok && p.namespace != key && strings.HasSuffix(path, key) {
+		key = path
+	}
+	if p.field != "" && !strings.ContainsRune(p.namespace, '[') {
+		fieldKeys.Store(p, key)
+	}
+	return key
+}
+
+// subPathErrors expands failures of tags that validate whole documents into
+// messages keyed by locations inside the field, e.g. "template.body".
+var subPathErrors = map[string]func(field string, fe validator.FieldError) (map[string]string, bool){
+	"json_schema": schemaFieldErrors,
+	"geojson":     geoJSONFieldErrors,
 }
 
 // handleDirectFieldErrors copies this package's FieldErrors into res.
@@ -174,10 +302,26 @@ func handleStructuredErrorMessage(err error, res map[string]string) bool {
 }
 
 // ToFieldErrorsWithContext uses a request-scoped message function from context
-// (if set via WithMessageFunc). Falls back to global SetMessageFunc and then
-// built-in defaults.
+// (if set via WithMessageFunc, else the translator set via WithTranslator).
+// Falls back to global SetMessageFunc and then built-in defaults. Messages registered with RegisterValidationWithMessage are
+// selected using the locale from context (see WithLocale), on the engine from
+// context (see WithEngine) or the current engine. See SetLocaleCheck for
+// detecting contexts without a locale, and DefaultEngine.SetEnglishOnly for
+// skipping all of this.
 func ToFieldErrorsWithContext(ctx context.Context, err error) map[string]string {
-	return ToFieldErrorsWith(err, MessageFuncFromContext(ctx))
+	if t := messageEngine(ctx).english.Load(); t != nil {
+		// English-only fast path: no locale, label or message func lookups.
+		if vErrs, ok := err.(validator.ValidationErrors); ok {
+			res := make(map[string]string, len(vErrs))
+			fillValidationErrors(vErrs, res, t.message)
+			return res
+		}
+		return toFieldErrors(ctx, err, nil)
+	}
+	if err != nil {
+		checkLocale(ctx)
+	}
+	return toFieldErrors(ctx, err, contextMessageFunc(ctx))
 }
 
 // humanMessage returns a message for a FieldError using the global messageFunc if set.
@@ -186,17 +330,35 @@ func humanMessage(fe validator.FieldError) string { return humanMessageWith(fe,
 // humanMessageWith returns a message for a FieldError using the provided fn if not nil,
 // otherwise the global messageFunc, otherwise a default fallback.
 func humanMessageWith(fe validator.FieldError, fn func(validator.FieldError) string) string {
+	return localizedMessage(context.Background(), fe, fn)
+}
+
+// localizedMessage resolves a message for a FieldError in this order: literal
+// StructReporter.AddFieldError messages, messages registered for the tag (in
+// the locale of c, then the default locale), fn, the fallback of the engine
+// of c (see DefaultEngine.SetMessageFunc), the global messageFunc and finally
+// the built-in defaults.
+func localizedMessage(c context.Context, fe validator.FieldError, fn func(validator.FieldError) string) string {
+	if fe.Tag() == StructRuleTag {
+		return fe.Param()
+	}
+	if msg, ok := registeredMessage(c, fe); ok {
+		return msg
+	}
 	if fn != nil {
 		if msg := fn(fe); msg != "" {
 			return msg
 		}
 	}
+	if msg := messageEngine(c).fallbackMessage(fe); msg != "" {
+		return msg
+	}
 	if messageFunc != nil {
 		if msg := messageFunc(fe); msg != "" {
 			return msg
 		}
 	}
-	return defaultMessage(fe)
+	return defaultMessage(fe, labelFunc(c, DefaultMessageLocale))
 }
 
 // normalizeFieldKey cleans a field key coming from ctx.FieldErrors.
@@ -353,66 +515,87 @@ func parseInvalidKeys(line string) []string {
 	return keys
 }
 
-// defaultMessage provides a minimal, dependency-free fallback for common tags.
-func defaultMessage(fe validator.FieldError) string {
-	switch fe.Tag() {
-	case "required":
-		return "is required"
-	case "min":
-		return fmt.Sprintf("must be at least %s", fe.Param())
-	case "max":
-		return fmt.Sprintf("must be at most %s", fe.Param())
-	case "len":
-		return fmt.Sprintf("must be length %s", fe.Param())
-	case "email":
-		return "must be a valid email"
-	case "oneof":
-		return fmt.Sprintf("must be one of %s", fe.Param())
-	case "gte":
-		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
-	case "lte":
-		return fmt.Sprintf("must be less than or equal to %s", fe.Param())
-	case "url":
-		return "must be a valid URL"
-	case "uuid":
-		return "must be a valid UUID"
-	case "alpha":
-		return "must contain only letters"
-	case "alphanum":
-		return "must contain only letters and numbers"
-	case "numeric":
-		return "must contain only numbers"
-	case "contains":
-		return fmt.Sprintf("must contain %s", fe.Param())
-	case "excludes":
-		return fmt.Sprintf("must not contain %s", fe.Param())
-	case "startswith":
-		return fmt.Sprintf("must start with %s", fe.Param())
-	case "endswith":
-		return fmt.Sprintf("must end with %s", fe.Param())
-	case "base64":
-		return "must be a valid base64 string"
-	case "json":
-		return "must be valid JSON"
-	case "ip":
-		return "must be a valid IP address"
-	case "cidr":
-		return "must be a valid CIDR notation"
-	case "ascii":
-		return "must contain only ASCII characters"
-	case "printascii":
-		return "must contain only printable ASCII characters"
-	case "multibyte":
-		return "must contain multibyte characters"
-	case "iscolor":
-		return "must be a valid color"
-	case "isbn":
-		return "must be a valid ISBN"
-	case "isbn10":
-		return "must be a valid ISBN-10"
-	case "isbn13":
-		return "must be a valid ISBN-13"
-	default:
-		return fmt.Sprintf("failed %s", fe.Tag())
+// defaultMessage provides a minimal, dependency-free fallback for common tags,
+// naming other fields with label.
+func defaultMessage(fe validator.FieldError, label func(string) string) string {
+	return defaultMessageWith(fe.Tag(), fe.Param(), label)
+}
+
+// defaultMessages are the built-in fallback message templates; {param} is
+// replaced with the tag parameter.
+var defaultMessages = map[string]string{
+	"required":   "is required",
+	"min":        "must be at least {param}",
+	"max":        "must be at most {param}",
+	"len":        "must be length {param}",
+	"email":      "must be a valid email",
+	"oneof":      "must be one of {param}",
+	"gte":        "must be greater than or equal to {param}",
+	"lte":        "must be less than or equal to {param}",
+	"url":        "must be a valid URL",
+	"uuid":       "must be a valid UUID",
+	"alpha":      "must contain only letters",
+	"alphanum":   "must contain only letters and numbers",
+	"numeric":    "must contain only numbers",
+	"contains":   "must contain {param}",
+	"excludes":   "must not contain {param}",
+	"startswith": "must start with {param}",
+	"endswith":   "must end with {param}",
+	"base64":     "must be a valid base64 string",
+	"json":       "must be valid JSON",
+	"ip":         "must be a valid IP address",
+	"cidr":       "must be a valid CIDR notation",
+	"ascii":      "must contain only ASCII characters",
+	"printascii": "must contain only printable ASCII characters",
+	"multibyte":  "must contain multibyte characters",
+	"iscolor":    "must be a valid color",
+	"isbn":       "must be a valid ISBN",
+	"isbn10":     "must be a valid ISBN-10",
+	"isbn13":     "must be a valid ISBN-13",
+	"jwt":        "must be a valid JWT",
+}
+
+// defaultMessageFor returns the built-in fallback message for a tag and its parameter.
+func defaultMessageFor(tag, param string) string {
+	return defaultMessageWith(tag, param, defaultLabel)
+}
+
+// defaultTemplates are defaultMessages split around {param}.
+var defaultTemplates = splitTemplates(defaultMessages, "{param}")
+
+func defaultMessageWith(tag, param string, label func(string) string) string {
+	if tmpl, ok := defaultTemplates[tag]; ok {
+		return tmpl.render(param)
+	}
+	if msg, ok := conditionalMessage(tag, param, label); ok {
+		return msg
+	}
+	if msg, ok := crossFieldMessage(tag, param, label); ok {
+		return msg
+	}
+	return "failed " + tag
+}
+
+// messageTemplate is a message split around its placeholder, so rendering
+// costs one allocation with a placeholder and none without.
+type messageTemplate struct {
+	before, after string
+	hasParam      bool
+}
+
+func (t messageTemplate) render(param string) string {
+	if !t.hasParam {
+		return t.before
+	}
+	return t.before + param + t.after
+}
+
+// splitTemplates splits messages with at most one placeholder.
+func splitTemplates(msgs map[string]string, placeholder string) map[string]messageTemplate {
+	out := make(map[string]messageTemplate, len(msgs))
+	for tag, msg := range msgs {
+		before, after, found := strings.Cut(msg, placeholder)
+		out[tag] = messageTemplate{before: before, after: after, hasParam: found}
 	}
+	return out
 }
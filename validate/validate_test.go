@@ -152,6 +152,7 @@ func TestDefaultMessage_ManyTags(t *testing.T) {
 		ISBN       string `json:"isbn" validate:"isbn"`
 		ISBN10     string `json:"isbn10" validate:"isbn10"`
 		ISBN13     string `json:"isbn13" validate:"isbn13"`
+		JWT        string `json:"jwt" validate:"jwt"`
 	}
 
 	bad := DM{
@@ -181,6 +182,7 @@ func TestDefaultMessage_ManyTags(t *testing.T) {
 		ISBN:       "foo",
 		ISBN10:     "123",
 		ISBN13:     "123",
+		JWT:        "not.a.jwt!",
 	}
 
 	err := Struct(bad)
@@ -214,6 +216,7 @@ func TestDefaultMessage_ManyTags(t *testing.T) {
 	assert.Equal(t, "must be a valid ISBN", m["isbn"])
 	assert.Equal(t, "must be a valid ISBN-10", m["isbn10"])
 	assert.Equal(t, "must be a valid ISBN-13", m["isbn13"])
+	assert.Equal(t, "must be a valid JWT", m["jwt"])
 }
 
 func TestDefaultMessage_StartsWithEndsWith(t *testing.T) {
@@ -983,7 +986,7 @@ func Test_handleCtxFieldErrors_NotMatchingType(t *testing.T) {
 
 func Test_handleValidationErrors_NotMatchingType(t *testing.T) {
 	res := map[string]string{}
-	ok := handleValidationErrors(assert.AnError, res, nil)
+	ok := handleValidationErrors(context.Background(), assert.AnError, res, nil)
 	assert.False(t, ok)
 	assert.Empty(t, res)
 }
@@ -1029,3 +1032,128 @@ func Test_handleValidationErrors_FieldEmpty_FallbackToStructField(t *testing.T)
 	m := ToFieldErrorsWith(err, func(fe globalValidator.FieldError) string { return "OK" })
 	assert.Equal(t, map[string]string{"S": "OK"}, m)
 }
+
+// BenchmarkToFieldErrors measures error mapping for a typical request type,
+// with nested field keys served from the per-type cache.
+func BenchmarkToFieldErrors(b *testing.B) {
+	type address struct {
+		City string `json:"city" validate:"required"`
+	}
+	type signup struct {
+		Name    string  `json:"name" validate:"required,min=2"`
+		Email   string  `json:"email" validate:"required,email"`
+		Address address `json:"address"`
+	}
+	err := Struct(signup{})
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ToFieldErrorsWithContext(ctx, err)
+	}
+}
+
+func TestValidationError_Status(t *testing.T) {
+	if (FieldErrors{}).StatusCode() != 422 {
+		t.Fatalf("expected 422 for FieldErrors")
+	}
+	inner := FieldErrors{"a": "b"}
+	err := NewValidationError(inner, 0)
+	if err.StatusCode() != 422 || NewValidationError(inner, 400).StatusCode() != 400 {
+		t.Fatalf("unexpected status")
+	}
+	var fe FieldErrors
+	if !errors.As(err, &fe) || err.Error() != inner.Error() {
+		t.Fatalf("expected ValidationError to unwrap to FieldErrors")
+	}
+}
+
+// TestDefaultMessage_Allocs guards the allocation budget of the built-in
+// messages: none for static ones, one for parameterized ones.
+func TestDefaultMessage_Allocs(t *testing.T) {
+	cases := []struct {
+		tag, param, want string
+		allocs           float64
+	}{
+		{"required", "", "is required", 0},
+		{"email", "", "must be a valid email", 0},
+		{"min", "3", "must be at least 3", 1},
+		{"oneof", "red green", "must be one of red green", 1},
+		{"unknown_tag", "", "failed unknown_tag", 1},
+	}
+	for _, c := range cases {
+		if got := defaultMessageFor(c.tag, c.param); got != c.want {
+			t.Fatalf("%s: expected %q, got %q", c.tag, c.want, got)
+		}
+		n := testing.AllocsPerRun(100, func() { _ = defaultMessageFor(c.tag, c.param) })
+		if n > c.allocs {
+			t.Fatalf("%s: expected at most %v allocations, got %v", c.tag, c.allocs, n)
+		}
+	}
+	label := func(s string) string { return s }
+	if got := defaultMessageWith("eqcsfield", "Password", label); got != "must match Password" {
+		t.Fatalf("unexpected cross-field message %q", got)
+	}
+	if n := testing.AllocsPerRun(100, func() { _ = defaultMessageWith("eqcsfield", "Password", label) }); n > 1 {
+		t.Fatalf("expected at most 1 allocation for cross-field messages, got %v", n)
+	}
+}
+
+func BenchmarkDefaultMessage(b *testing.B) {
+	label := func(s string) string { return s }
+	for _, c := range []struct{ name, tag, param string }{
+		{"static", "required", ""},
+		{"param", "min", "3"},
+		{"crossfield", "eqfield", "Password"},
+		{"unknown", "unknown_tag", ""},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = defaultMessageWith(c.tag, c.param, label)
+			}
+		})
+	}
+}
+
+func TestIsStruct(t *testing.T) {
+	var nilUser *user
+	u := &user{}
+	for v, want := range map[any]bool{user{}: true, u: true, &u: true, nilUser: false, "x": false, 3: false} {
+		if IsStruct(v) != want {
+			t.Fatalf("IsStruct(%#v): expected %v", v, want)
+		}
+	}
+	if IsStruct(nil) {
+		t.Fatalf("expected nil not to be a struct")
+	}
+}
+
+func TestFieldKey(t *testing.T) {
+	type address struct {
+		City string `json:"city" validate:"required"`
+	}
+	type item struct {
+		SKU string `json:"sku" validate:"required"`
+	}
+	type order struct {
+		Name     string  `json:"name" validate:"required"`
+		Address  address `json:"address"`
+		Billing  address `json:"billing"`
+		Items    []item  `json:"items" validate:"dive"`
+		Shipping *address
+	}
+	err := Struct(order{Items: []item{{}}, Shipping: &address{}})
+	want := map[string]string{
+		"name": "is required", "address.city": "is required", "billing.city": "is required",
+		"items[0].sku": "is required", "Shipping.city": "is required",
+	}
+	for i := 0; i < 2; i++ { // the second run is served from the cache
+		if got := ToFieldErrors(err); !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if got := ToFieldErrors(Validator.Var("", "required")); !reflect.DeepEqual(got, map[string]string{"": "is required"}) {
+		t.Fatalf("expected Var errors keyed by their empty field, got %v", got)
+	}
+}
@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Var validates a single value against tag with the current engine and names
+// the resulting errors, so they map to name in ToFieldErrors (plain
+// validator Var errors have an empty field name):
+//
+//	if err := validate.Var("page", page, "gte=1"); err != nil {
+//		return c.Status(422).JSON(validate.ToFieldErrors(err)) // {"page": "..."}
+//	}
+func Var(name string, value any, tag string) error {
+	return VarCtx(context.Background(), name, value, tag)
+}
+
+// VarCtx is like Var but validates with the engine from ctx (see WithEngine)
+// and passes ctx to context-aware validation functions. Tags skipped in ctx (see SkipTagIf) are not reported.
+func VarCtx(ctx context.Context, name string, value any, tag string) error {
+	err := filterSkipped(ctx, engineFor(ctx).VarCtx(ctx, value, tag))
+	var vErrs validator.ValidationErrors
+	if errors.As(err, &vErrs) {
+		return nameErrors(vErrs, name)
+	}
+	return err
+}
+
+// NamedVar is a single named value and its tag, checked by Vars.
+type NamedVar struct {
+	Name  string
+	Value any
+	Tag   string
+}
+
+// Vars validates several named values and returns all failures as one
+// validator.ValidationErrors (or nil):
+//
+//	err := validate.Vars(
+//		validate.NamedVar{Name: "page", Value: page, Tag: "gte=1"},
+//		validate.NamedVar{Name: "sort", Value: sort, Tag: "oneof=asc desc"},
+//	)
+func Vars(checks ...NamedVar) error { return VarsCtx(context.Background(), checks...) }
+
+// VarsCtx is like Vars but passes ctx to context-aware validation functions.
+// A non-validation error from the engine is returned as is.
+func VarsCtx(ctx context.Context, checks ...NamedVar) error {
+	var all validator.ValidationErrors
+	for _, c := range checks {
+		err := VarCtx(ctx, c.Name, c.Value, c.Tag)
+		if err == nil {
+			continue
+		}
+		var vErrs validator.ValidationErrors
+		if !errors.As(err, &vErrs) {
+			return err
+		}
+		all = append(all, vErrs...)
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	return all
+}
+
+// namedFieldError gives a Var error (which has no field name) a name, so it
+// maps to a key in ToFieldErrors and messages can reference {field}.
+type namedFieldError struct {
+	validator.FieldError
+	name string
+}
+
+func (e namedFieldError) Field() string           { return e.name }
+func (e namedFieldError) StructField() string     { return e.name }
+func (e namedFieldError) Namespace() string       { return e.name }
+func (e namedFieldError) StructNamespace() string { return e.name }
+
+// nameErrors attaches name to every error in errs.
+func nameErrors(errs validator.ValidationErrors, name string) validator.ValidationErrors {
+	out := make(validator.ValidationErrors, len(errs))
+	for i, fe := range errs {
+		out[i] = namedFieldError{FieldError: fe, name: name}
+	}
+	return out
+}
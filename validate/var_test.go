@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestVar_NamesErrors(t *testing.T) {
+	err := Var("page", 0, "gte=1")
+	fe := ToFieldErrors(err)
+	if len(fe) != 1 || fe["page"] != "must be greater than or equal to 1" {
+		t.Fatalf("unexpected errors: %v", fe)
+	}
+	var vErrs validator.ValidationErrors
+	if !errors.As(err, &vErrs) || vErrs[0].Namespace() != "page" || vErrs[0].Tag() != "gte" {
+		t.Fatalf("expected named ValidationErrors, got %v", err)
+	}
+	if Var("page", 2, "gte=1") != nil {
+		t.Fatalf("expected valid value")
+	}
+}
+
+func TestVars_AggregatesAndLocalizes(t *testing.T) {
+	RegisterMessages("oneof", map[string]string{"es": "{field} debe ser uno de {param}"})
+	defer RegisterMessages("oneof", nil)
+
+	err := VarsCtx(context.Background(),
+		NamedVar{Name: "page", Value: 0, Tag: "gte=1"},
+		NamedVar{Name: "limit", Value: 10, Tag: "lte=100"},
+		NamedVar{Name: "sort", Value: "up", Tag: "oneof=asc desc"},
+	)
+	fe := ToFieldErrorsWithContext(WithLocale(context.Background(), "es"), err)
+	if len(fe) != 2 || fe["page"] == "" || fe["sort"] != "sort debe ser uno de asc desc" {
+		t.Fatalf("unexpected errors: %v", fe)
+	}
+	if Vars(NamedVar{Name: "a", Value: "x", Tag: "required"}) != nil {
+		t.Fatalf("expected nil for valid values")
+	}
+}
+
+func TestVars_PassesThroughEngineErrors(t *testing.T) {
+	boom := errors.New("boom")
+	SetEngine(errEngine{boom})
+	defer SetEngine(nil)
+	if err := Vars(NamedVar{Name: "a", Value: 1, Tag: "gte=1"}); !errors.Is(err, boom) {
+		t.Fatalf("expected engine error, got %v", err)
+	}
+}
+
+// errEngine returns err from every call.
+type errEngine struct{ err error }
+
+func (e errEngine) Struct(any) error                          { return e.err }
+func (e errEngine) StructCtx(context.Context, any) error      { return e.err }
+func (e errEngine) Var(any, string) error                     { return e.err }
+func (e errEngine) VarCtx(context.Context, any, string) error { return e.err }
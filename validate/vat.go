@@ -0,0 +1,135 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// vatPatterns holds the VAT number structure of each EU member state (and
+// Northern Ireland, XI) after the two-letter prefix. Greece uses EL.
+var vatPatterns = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^(\d{7}[A-W][A-I]?|\d[A-Z+*]\d{5}[A-W])$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": regexp.MustCompile(`^\d{2,10}$`),
+	"SE": regexp.MustCompile(`^\d{10}01$`),
+	"SI": regexp.MustCompile(`^\d{8}$`),
+	"SK": regexp.MustCompile(`^\d{10}$`),
+	"XI": regexp.MustCompile(`^(\d{9}|\d{12}|GD\d{3}|HA\d{3})$`),
+}
+
+func init() {
+	mustRegister("vat", isVAT, map[string]string{DefaultMessageLocale: "must be a valid VAT number"})
+}
+
+// isVAT checks the structure of an EU VAT number, including its country
+// prefix. Spaces, dots and dashes are ignored. `vat=DE` also requires the
+// given prefix.
+func isVAT(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.String {
+		return false
+	}
+	country, number, ok := SplitVAT(field.String())
+	if !ok {
+		return false
+	}
+	if p := fl.Param(); p != "" && !strings.EqualFold(p, country) {
+		return false
+	}
+	return vatPatterns[country].MatchString(number)
+}
+
+// SplitVAT normalizes a VAT number and splits it into its country prefix and
+// national part, e.g. "de 123.456.789" -> "DE", "123456789". ok is false for
+// unknown prefixes.
+func SplitVAT(vat string) (country, number string, ok bool) {
+	vat = strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "").Replace(vat))
+	if len(vat) < 3 {
+		return "", "", false
+	}
+	country, number = vat[:2], vat[2:]
+	_, ok = vatPatterns[country]
+	return country, number, ok
+}
+
+// VIES checks VAT numbers against the European Commission's VIES service. It
+// implements RemoteValidator for use with RegisterRemote, next to the `vat`
+// structure check.
+type VIES struct {
+	// Client sends the requests. Default: http.DefaultClient.
+	Client *http.Client
+	// Endpoint is the VIES REST check-vat-number URL.
+	Endpoint string
+}
+
+// DefaultVIESEndpoint is the public VIES REST API.
+const DefaultVIESEndpoint = "https://ec.europa.eu/taxation_customs/vies/rest-api/check-vat-number"
+
+// NewVIES returns a VIES checker using client (nil for http.DefaultClient).
+func NewVIES(client *http.Client) *VIES {
+	return &VIES{Client: client, Endpoint: DefaultVIESEndpoint}
+}
+
+// Check reports whether VIES knows vat as a valid, registered number.
+// Malformed numbers are reported invalid without a request.
+func (v *VIES) Check(ctx context.Context, vat string) (bool, error) {
+	country, number, ok := SplitVAT(vat)
+	if !ok {
+		return false, nil
+	}
+	body, _ := json.Marshal(map[string]string{"countryCode": country, "vatNumber": number})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("vies: unexpected status %d", resp.StatusCode)
+	}
+	var out struct {
+		Valid bool `json:"valid"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return false, fmt.Errorf("vies: %w", err)
+	}
+	return out.Valid, nil
+}
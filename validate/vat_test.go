@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVATTag(t *testing.T) {
+	for _, v := range []string{"DE123456789", "de 123.456.789", "ATU12345678", "NL123456789B01", "FRXX123456789", "EL123456789", "IE1234567WA", "SE123456789001"} {
+		if err := Var("vat", v, "vat"); err != nil {
+			t.Fatalf("%q: unexpected error %v", v, err)
+		}
+	}
+	for _, v := range []string{"DE12345678", "GR123456789", "US123456789", "NL123456789", "X", "ATU1234567"} {
+		if m := ToFieldErrors(Var("vat", v, "vat")); m["vat"] != "must be a valid VAT number" {
+			t.Fatalf("%q: expected VAT error, got %v", v, m)
+		}
+	}
+	if Var("vat", "DE123456789", "vat=FR") == nil || Var("vat", "DE123456789", "vat=de") != nil {
+		t.Fatalf("expected country parameter to be enforced case-insensitively")
+	}
+	if Var("vat", 123, "vat") == nil {
+		t.Fatalf("expected non-string to fail")
+	}
+}
+
+func TestVIES(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got["vatNumber"] == "500" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"valid":` + map[bool]string{true: "true", false: "false"}[got["vatNumber"] == "123456789"] + `}`))
+	}))
+	defer srv.Close()
+
+	v := NewVIES(srv.Client())
+	v.Endpoint = srv.URL
+	ok, err := v.Check(context.Background(), "de 123 456 789")
+	if err != nil || !ok || got["countryCode"] != "DE" {
+		t.Fatalf("expected valid VAT, got %v %v (request %v)", ok, err, got)
+	}
+	if ok, err := v.Check(context.Background(), "DE987654321"); err != nil || ok {
+		t.Fatalf("expected unknown VAT, got %v %v", ok, err)
+	}
+	if _, err := v.Check(context.Background(), "DE500"); err == nil {
+		t.Fatalf("expected error on server failure")
+	}
+	if ok, err := v.Check(context.Background(), "??"); ok || err != nil {
+		t.Fatalf("expected malformed VAT to be invalid without error")
+	}
+}
@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"context"
+	"sync"
+)
+
+// Context key for the per-request warnings collector.
+type ctxKeyWarnings struct{}
+
+// warningSet collects non-fatal messages reported during validation.
+type warningSet struct {
+	mu sync.Mutex
+	m  map[string]string
+}
+
+// CollectWarnings returns a context that collects warnings reported during
+// validation (see StructReporter.AddWarning and RegisterAddressVerifier), so
+// checks that must not reject a request can still tell the client:
+//
+//	ctx := validate.CollectWarnings(c.Context())
+//	err := validate.StructCtx(ctx, in)
+//	warnings := validate.ContextWarnings(ctx)
+//
+// Enforce installs a collector and merges its warnings into Warnings.
+func CollectWarnings(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(ctxKeyWarnings{}).(*warningSet); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKeyWarnings{}, &warningSet{m: map[string]string{}})
+}
+
+// ContextWarnings returns a copy of the warnings collected in ctx, keyed by
+// field, or nil if ctx has no collector or none were reported.
+func ContextWarnings(ctx context.Context) map[string]string {
+	ws, _ := ctx.Value(ctxKeyWarnings{}).(*warningSet)
+	if ws == nil {
+		return nil
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if len(ws.m) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(ws.m))
+	for k, v := range ws.m {
+		res[k] = v
+	}
+	return res
+}
+
+// addWarning records a warning in the collector of ctx, if any. The first
+// warning for a field wins.
+func addWarning(ctx context.Context, field, message string) {
+	if ctx == nil {
+		return
+	}
+	ws, _ := ctx.Value(ctxKeyWarnings{}).(*warningSet)
+	if ws == nil {
+		return
+	}
+	ws.mu.Lock()
+	if _, ok := ws.m[field]; !ok {
+		ws.m[field] = message
+	}
+	ws.mu.Unlock()
+}
@@ -0,0 +1,42 @@
+package validate
+
+import (
+	"context"
+	"testing"
+)
+
+type warnedSignup struct {
+	Nickname string `json:"nickname"`
+}
+
+func init() {
+	RegisterStructRule(func(s warnedSignup, rep *StructReporter) {
+		if len(s.Nickname) > 10 {
+			rep.AddWarning("nickname", "will be truncated")
+		}
+	})
+}
+
+func TestCollectWarnings(t *testing.T) {
+	if w := ContextWarnings(context.Background()); w != nil {
+		t.Fatalf("expected no warnings without a collector, got %v", w)
+	}
+	ctx := CollectWarnings(context.Background())
+	if CollectWarnings(ctx) != ctx {
+		t.Fatalf("expected an existing collector to be reused")
+	}
+	if err := StructCtx(ctx, warnedSignup{Nickname: "short"}); err != nil || ContextWarnings(ctx) != nil {
+		t.Fatalf("unexpected result: %v, %v", err, ContextWarnings(ctx))
+	}
+	if err := StructCtx(ctx, warnedSignup{Nickname: "a very long nickname"}); err != nil {
+		t.Fatalf("warnings must not fail validation, got %v", err)
+	}
+	w := ContextWarnings(ctx)
+	if w["nickname"] != "will be truncated" {
+		t.Fatalf("expected warning, got %v", w)
+	}
+	w["nickname"] = "changed"
+	if ContextWarnings(ctx)["nickname"] != "will be truncated" {
+		t.Fatalf("expected a copy of the warnings")
+	}
+}
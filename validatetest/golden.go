@@ -0,0 +1,117 @@
+// Package validatetest provides helpers for snapshot-testing validation error
+// responses against golden files.
+package validatetest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goflash/validator/v2/validate"
+)
+
+// Update rewrites golden files instead of comparing against them. It defaults
+// to true when the UPDATE_GOLDEN environment variable is set to a non-empty
+// value, e.g. UPDATE_GOLDEN=1 go test ./...
+var Update = os.Getenv("UPDATE_GOLDEN") != ""
+
+// Dir is the directory golden files are read from and written to, relative to
+// the package under test. Default: "testdata".
+var Dir = "testdata"
+
+// Render converts err into the JSON error body used by the goflash examples
+// ({"message": "validation failed", "fields": {...}}) with messages resolved
+// for locale (validate.DefaultMessageLocale when empty). Keys are sorted and
+// the output is indented, so it is stable across runs.
+func Render(err error, locale string) []byte {
+	if locale == "" {
+		locale = validate.DefaultMessageLocale
+	}
+	ctx := validate.WithLocale(context.Background(), locale)
+	body := map[string]any{
+		"message": "validation failed",
+		"fields":  validate.ToFieldErrorsWithContext(ctx, err),
+	}
+	b, mErr := json.MarshalIndent(body, "", "  ")
+	if mErr != nil {
+		// A map of strings always marshals; keep the signature simple.
+		panic(mErr)
+	}
+	return append(b, '\n')
+}
+
+// AssertGolden compares got with the golden file Dir/name.golden and fails the
+// test with a line diff when they differ. With Update set, the file is
+// (re)written instead.
+func AssertGolden(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join(Dir, name+".golden")
+	if Update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("validatetest: create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("validatetest: write golden file: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("validatetest: read golden file (set UPDATE_GOLDEN=1 to create it): %v", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Fatalf("validatetest: %s does not match golden file:\n%s", name, Diff(string(want), string(got)))
+	}
+}
+
+// AssertErrorGolden renders err for locale and compares it with the golden
+// file Dir/name.golden.
+func AssertErrorGolden(t testing.TB, name string, err error, locale string) {
+	t.Helper()
+	AssertGolden(t, name, Render(err, locale))
+}
+
+// Diff returns a line diff of want and got: removed lines are prefixed with
+// "- ", added lines with "+ " and unchanged lines with "  ".
+func Diff(want, got string) string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// Longest common subsequence table.
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			fmt.Fprintf(&sb, "  %s\n", a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&sb, "- %s\n", a[i])
+			i++
+		default:
+			fmt.Fprintf(&sb, "+ %s\n", b[j])
+			j++
+		}
+	}
+	return sb.String()
+}
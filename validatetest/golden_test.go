@@ -0,0 +1,59 @@
+package validatetest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goflash/validator/v2/validate"
+)
+
+type signup struct {
+	Email string `json:"email" validate:"required,email"`
+	Name  string `json:"name" validate:"required,min=2"`
+	Age   int    `json:"age" validate:"gte=18"`
+}
+
+func TestAssertErrorGolden(t *testing.T) {
+	AssertErrorGolden(t, "signup_en", validate.Struct(signup{Email: "x", Age: 3}), "")
+}
+
+func TestRender_StableAndLocalized(t *testing.T) {
+	err := validate.Struct(signup{})
+	if string(Render(err, "en")) != string(Render(err, "")) {
+		t.Fatalf("expected empty locale to default to en")
+	}
+	validate.RegisterMessages("min", map[string]string{"de": "{field} ist zu kurz"})
+	defer validate.RegisterMessages("min", nil)
+	got := string(Render(validate.Struct(signup{Email: "a@b.co", Name: "x", Age: 20}), "de"))
+	if !strings.Contains(got, `"name": "name ist zu kurz"`) {
+		t.Fatalf("expected localized message, got %s", got)
+	}
+	if !strings.Contains(string(Render(errors.New("boom"), "")), `"_error": "boom"`) {
+		t.Fatalf("expected non-validation error under _error")
+	}
+}
+
+func TestAssertGolden_UpdateWritesFile(t *testing.T) {
+	dir, update := Dir, Update
+	defer func() { Dir, Update = dir, update }()
+	Dir, Update = t.TempDir(), true
+
+	AssertGolden(t, "nested/out", []byte("a\n"))
+	b, err := os.ReadFile(filepath.Join(Dir, "nested", "out.golden"))
+	if err != nil || string(b) != "a\n" {
+		t.Fatalf("expected golden file to be written, got %q %v", b, err)
+	}
+	Update = false
+	AssertGolden(t, "nested/out", []byte("a\n"))
+}
+
+func TestDiff(t *testing.T) {
+	got := Diff("a\nb\nc\n", "a\nx\nc\nd\n")
+	want := "  a\n- b\n+ x\n  c\n+ d\n"
+	if got != want {
+		t.Fatalf("unexpected diff:\n%s", got)
+	}
+}
@@ -0,0 +1,100 @@
+package validatetest
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/goflash/validator/v2/validate"
+)
+
+// Call records one invocation of a Mock engine.
+type Call struct {
+	// Method is "Struct", "StructCtx", "Var" or "VarCtx".
+	Method string
+	// Value is the struct or field passed to the engine.
+	Value any
+	// Tag is the tag passed to Var/VarCtx.
+	Tag string
+}
+
+// Mock is a scriptable validate.Engine that records every call. Outcomes are
+// configured per value type with ReturnFor or for all calls with Return; by
+// default every value is valid. It is safe for concurrent use.
+type Mock struct {
+	mu     sync.Mutex
+	calls  []Call
+	err    error
+	byType map[reflect.Type]error
+}
+
+var _ validate.Engine = (*Mock)(nil)
+
+// NewMock returns a Mock that accepts every value.
+func NewMock() *Mock { return &Mock{byType: map[reflect.Type]error{}} }
+
+// Return sets the error returned for values without a ReturnFor outcome.
+func (m *Mock) Return(err error) *Mock {
+	m.mu.Lock()
+	m.err = err
+	m.mu.Unlock()
+	return m
+}
+
+// ReturnFor sets the error returned for values of the same type as v
+// (pointers and values share an outcome). Use validate.FieldErrors to script
+// per-field failures:
+//
+//	mock.ReturnFor(signupReq{}, validate.FieldErrors{"email": "is taken"})
+func (m *Mock) ReturnFor(v any, err error) *Mock {
+	m.mu.Lock()
+	m.byType[baseType(v)] = err
+	m.mu.Unlock()
+	return m
+}
+
+// Calls returns the recorded calls in order.
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// Reset clears recorded calls and configured outcomes.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	m.calls, m.err, m.byType = nil, nil, map[reflect.Type]error{}
+	m.mu.Unlock()
+}
+
+// Struct records the call and returns the configured outcome.
+func (m *Mock) Struct(s any) error { return m.record("Struct", s, "") }
+
+// StructCtx records the call and returns the configured outcome.
+func (m *Mock) StructCtx(_ context.Context, s any) error { return m.record("StructCtx", s, "") }
+
+// Var records the call and returns the configured outcome.
+func (m *Mock) Var(field any, tag string) error { return m.record("Var", field, tag) }
+
+// VarCtx records the call and returns the configured outcome.
+func (m *Mock) VarCtx(_ context.Context, field any, tag string) error {
+	return m.record("VarCtx", field, tag)
+}
+
+func (m *Mock) record(method string, v any, tag string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Value: v, Tag: tag})
+	if err, ok := m.byType[baseType(v)]; ok {
+		return err
+	}
+	return m.err
+}
+
+func baseType(v any) reflect.Type {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
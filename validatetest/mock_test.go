@@ -0,0 +1,40 @@
+package validatetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goflash/validator/v2/validate"
+)
+
+func TestMock_ScriptsOutcomesAndRecordsCalls(t *testing.T) {
+	m := NewMock().ReturnFor(signup{}, validate.FieldErrors{"email": "is taken"})
+	validate.SetEngine(m)
+	defer validate.SetEngine(nil)
+
+	err := validate.Struct(&signup{Email: "a@b.co", Name: "Ann", Age: 30})
+	if fe := validate.ToFieldErrors(err); fe["email"] != "is taken" {
+		t.Fatalf("expected scripted error, got %v", fe)
+	}
+	if err := validate.Var("email", "x", "email"); err != nil {
+		t.Fatalf("expected default outcome to pass, got %v", err)
+	}
+	boom := errors.New("boom")
+	m.Return(boom)
+	if err := validate.VarCtx(context.Background(), "n", 1, "gte=2"); !errors.Is(err, boom) {
+		t.Fatalf("expected global outcome, got %v", err)
+	}
+	if err := validate.StructCtx(context.Background(), signup{}); err == nil || errors.Is(err, boom) {
+		t.Fatalf("expected per-type outcome to win, got %v", err)
+	}
+
+	calls := m.Calls()
+	if len(calls) != 4 || calls[0].Method != "Struct" || calls[1].Tag != "email" || calls[2].Method != "VarCtx" || calls[3].Method != "StructCtx" {
+		t.Fatalf("unexpected calls: %+v", calls)
+	}
+	m.Reset()
+	if len(m.Calls()) != 0 || validate.Struct(signup{}) != nil {
+		t.Fatalf("expected reset mock")
+	}
+}
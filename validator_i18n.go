@@ -1,52 +1,134 @@
 package validator
 
 import (
+	"log/slog"
 	"strings"
+	"sync"
 
 	"github.com/goflash/validator/v2/validate"
 
+	ut "github.com/go-playground/universal-translator"
 	globalValidator "github.com/go-playground/validator/v10"
 	"github.com/goflash/flash/v2"
 )
 
 // ValidatorI18nConfig configures the ValidatorI18n middleware.
-// The application supplies how to get a message function for a given locale.
-// No locale or translation packages are imported by the framework.
+// The application supplies how to get a message function for a given locale,
+// or uses NewI18n to build a config from go-playground's default translations.
 type ValidatorI18nConfig struct {
 	// DefaultLocale used when none is derived from the request.
 	DefaultLocale string
+	// DefaultLocaleFor optionally returns the default locale of a request,
+	// e.g. from tenant settings, so each tenant falls back to its own
+	// language. An empty result means DefaultLocale.
+	DefaultLocaleFor func(c flash.Ctx) string
 	// LocaleFromCtx returns the desired locale for a request.
 	// Default: use lowercased route param ":lang" if present.
 	LocaleFromCtx func(c flash.Ctx) string
 	// MessageFuncFor returns a function that translates a FieldError into a message
 	// for a given locale. The application typically closes over its prepared
 	// translators and returns: func(fe validator.FieldError) string { return fe.Translate(trans) }.
-	// Required.
+	// Results are cached per locale, so it is called once for each locale it
+	// has a function for. Required unless TranslatorFor is set.
 	MessageFuncFor func(locale string) func(globalValidator.FieldError) string
-	// SetGlobal optionally sets the global fallback message function to DefaultLocale,
-	// used when no per-request function was provided.
+	// TranslatorFor returns the translator of a locale, attached to requests
+	// with validate.WithTranslator so handlers can translate their own keys.
+	// Without MessageFuncFor, field errors are translated with it too.
+	// Optional; one of MessageFuncFor and TranslatorFor is required.
+	TranslatorFor func(locale string) ut.Translator
+	// Engine scopes the middleware to one engine, e.g. one per app in a
+	// binary serving several: requests validate with it (validate.WithEngine)
+	// and SetGlobal installs the fallback on it only. Default: none.
+	Engine *validate.DefaultEngine
+	// SetGlobal sets the fallback message function to DefaultLocale's, used
+	// when no per-request function was provided, such as validation outside
+	// requests. With Engine it is set on that engine
+	// (validate.DefaultEngine.SetMessageFunc).
+	//
+	// Deprecated: without Engine, SetGlobal changes the process-wide
+	// validate.SetMessageFunc, which breaks apps with different default
+	// locales in one binary. Set Engine as well.
 	SetGlobal bool
+	// SupportedLocales optionally lists the locales the application serves.
+	// They are passed to validate.SetSupportedLocales, so the bcp47_supported
+	// tag accepts the same locales as the middleware.
+	SupportedLocales []string
+	// OrderingCheck turns on warnings for misordered middleware, which
+	// otherwise silently produces English messages: ValidatorI18n warns when
+	// it runs after Enforce on a route, and enables validate.SetLocaleCheck,
+	// which warns when Enforce converts errors for a request without a
+	// locale. Off by default.
+	OrderingCheck bool
+	// Logger receives the ordering warnings, once per route.
+	// Default: slog.Default().
+	Logger *slog.Logger
 }
 
 // ValidatorI18n returns middleware that attaches a request-scoped validator message
 // function to the request context based on a locale.
 func ValidatorI18n(cfg ValidatorI18nConfig) flash.Middleware {
-	if cfg.MessageFuncFor == nil {
+	if cfg.MessageFuncFor == nil && cfg.TranslatorFor == nil {
 		// No-op middleware if misconfigured
 		return func(next flash.Handler) flash.Handler { return next }
 	}
+	if cfg.MessageFuncFor == nil {
+		cfg.MessageFuncFor = func(locale string) func(globalValidator.FieldError) string {
+			if trans := cfg.TranslatorFor(locale); trans != nil {
+				return validate.TranslatorMessageFunc(trans)
+			}
+			return nil
+		}
+	}
 	if cfg.DefaultLocale == "" {
 		cfg.DefaultLocale = "en"
 	}
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.OrderingCheck {
+		validate.SetLocaleCheck(cfg.Logger)
+	}
+	if len(cfg.SupportedLocales) > 0 {
+		validate.SetSupportedLocales(cfg.SupportedLocales...)
+	}
 	if cfg.SetGlobal {
 		if mf := cfg.MessageFuncFor(cfg.DefaultLocale); mf != nil {
-			validate.SetMessageFunc(mf)
+			if cfg.Engine != nil {
+				cfg.Engine.SetMessageFunc(mf)
+			} else {
+				validate.SetMessageFunc(mf)
+			}
+		}
+	}
+
+	// Resolved message funcs are cached per locale. Only locales with a
+	// function of their own are cached, so arbitrary locales from requests
+	// cannot grow the cache.
+	var messageFuncs sync.Map // locale -> func(globalValidator.FieldError) string
+	var misordered sync.Map   // route -> struct{}
+	messageFuncFor := func(locale string) func(globalValidator.FieldError) string {
+		if mf, ok := messageFuncs.Load(locale); ok {
+			return mf.(func(globalValidator.FieldError) string)
 		}
+		mf := cfg.MessageFuncFor(locale)
+		if mf != nil {
+			messageFuncs.Store(locale, mf)
+		}
+		return mf
 	}
 
 	return func(next flash.Handler) flash.Handler {
 		return func(c flash.Ctx) error {
-			locale := cfg.DefaultLocale
+			if cfg.OrderingCheck && Payload(c) != nil {
+				warnMisordered(c, cfg.Logger, &misordered)
+			}
+			defaultLocale := cfg.DefaultLocale
+			if cfg.DefaultLocaleFor != nil {
+				if l := cfg.DefaultLocaleFor(c); l != "" {
+					defaultLocale = strings.ToLower(l)
+				}
+			}
+			locale := defaultLocale
 			if cfg.LocaleFromCtx != nil {
 				if l := cfg.LocaleFromCtx(c); l != "" {
 					locale = strings.ToLower(l)
@@ -57,17 +139,77 @@ func ValidatorI18n(cfg ValidatorI18nConfig) flash.Middleware {
 				}
 			}
 
-			mf := cfg.MessageFuncFor(locale)
-			if mf == nil && locale != cfg.DefaultLocale {
-				mf = cfg.MessageFuncFor(cfg.DefaultLocale)
+			mf, served, result := messageFuncFor(locale), locale, "requested"
+			if mf == nil && locale != defaultLocale {
+				mf, served, result = messageFuncFor(defaultLocale), defaultLocale, "default"
+			}
+			if mf == nil && defaultLocale != cfg.DefaultLocale {
+				mf, served, result = messageFuncFor(cfg.DefaultLocale), cfg.DefaultLocale, "default"
+			}
+			if mf == nil {
+				served, result = "", "builtin"
+			}
+			if validate.MetricsEnabled() {
+				requested := locale
+				if result != "requested" {
+					requested = metricLocale(locale)
+				}
+				validate.ReportMetric(validate.MetricLocaleServed, map[string]string{
+					"requested": requested, "served": served, "result": result,
+				})
+			}
+			// The locale selects messages registered with RegisterValidationWithMessage.
+			ctx := validate.WithLocale(c.Context(), locale)
+			if cfg.Engine != nil {
+				ctx = validate.WithEngine(ctx, cfg.Engine)
 			}
 			if mf != nil {
-				ctx := validate.WithMessageFunc(c.Context(), mf)
-				// propagate context to request
-				r2 := c.Request().WithContext(ctx)
-				c.SetRequest(r2)
+				ctx = validate.WithMessageFunc(ctx, mf)
+			}
+			if cfg.TranslatorFor != nil {
+				trans := cfg.TranslatorFor(locale)
+				if trans == nil && locale != defaultLocale {
+					trans = cfg.TranslatorFor(defaultLocale)
+				}
+				if trans == nil && defaultLocale != cfg.DefaultLocale {
+					trans = cfg.TranslatorFor(cfg.DefaultLocale)
+				}
+				if trans != nil {
+					ctx = validate.WithTranslator(ctx, trans)
+				}
 			}
+			// propagate context to request
+			r2 := c.Request().WithContext(ctx)
+			c.SetRequest(r2)
 			return next(c)
 		}
 	}
 }
+
+// warnMisordered logs, once per route, that the request was bound and
+// validated before ValidatorI18n ran, so its errors were not localized.
+func warnMisordered(c flash.Ctx, logger *slog.Logger, seen *sync.Map) {
+	route := c.Method() + " " + c.Route()
+	if _, ok := seen.LoadOrStore(route, struct{}{}); ok {
+		return
+	}
+	logger.WarnContext(c.Context(), "validator.ValidatorI18n runs after Enforce: validation messages fall back to English; "+
+		"install ValidatorI18n first (app.Use, or before Enforce in the route's middleware)",
+		"route", route)
+}
+
+// metricLocale reduces a locale without messages to its base language, or
+// "invalid", so arbitrary locales from requests cannot inflate metric label
+// cardinality.
+func metricLocale(locale string) string {
+	base, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	if len(base) < 2 || len(base) > 3 {
+		return "invalid"
+	}
+	for _, r := range base {
+		if r < 'a' || r > 'z' {
+			return "invalid"
+		}
+	}
+	return base
+}
@@ -1,10 +1,16 @@
 package validator
 
 import (
+	"bytes"
+	"context"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
 	validator "github.com/go-playground/validator/v10"
 	"github.com/goflash/flash/v2"
 	"github.com/goflash/validator/v2/validate"
@@ -193,6 +199,25 @@ func TestValidatorI18n_EmptyDefaultLocaleBecomesEN(t *testing.T) {
 	}
 }
 
+func TestValidatorI18n_SetsLocaleOnContext(t *testing.T) {
+	validate.SetMessageFunc(nil)
+	app := flash.New()
+	app.Use(ValidatorI18n(ValidatorI18nConfig{
+		DefaultLocale:  "en",
+		MessageFuncFor: func(locale string) func(validator.FieldError) string { return nil },
+	}))
+	app.GET("/:lang/locale", func(c flash.Ctx) error {
+		return c.JSON(map[string]string{"locale": validate.LocaleFromContext(c.Context())})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/PT/locale", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	if body := rec.Body.String(); !contains(body, `"locale":"pt"`) {
+		t.Fatalf("expected locale pt on context, got %q", body)
+	}
+}
+
 func contains(s, sub string) bool {
 	return len(s) >= len(sub) && (s == sub || (len(sub) > 0 && (indexOf(s, sub) >= 0)))
 }
@@ -205,3 +230,223 @@ func indexOf(s, sub string) int {
 	}
 	return -1
 }
+
+func TestValidatorI18n_SupportedLocales(t *testing.T) {
+	defer validate.SetSupportedLocales()
+	ValidatorI18n(ValidatorI18nConfig{
+		MessageFuncFor:   func(string) func(validator.FieldError) string { return nil },
+		SupportedLocales: []string{"en", "es"},
+	})
+	if got := validate.SupportedLocales(); len(got) != 2 || got[0] != "en" || got[1] != "es" {
+		t.Fatalf("expected middleware locales to be registered, got %v", got)
+	}
+	if validate.Var("lang", "es-MX", "bcp47_supported") != nil || validate.Var("lang", "de", "bcp47_supported") == nil {
+		t.Fatalf("expected bcp47_supported to use the middleware locales")
+	}
+}
+
+func TestValidatorI18n_EngineScopedFallback(t *testing.T) {
+	type U struct {
+		Name string `json:"name" validate:"required"`
+	}
+	apps := map[string]*validate.DefaultEngine{"en": validate.NewEngine(), "de": validate.NewEngine()}
+	for locale, e := range apps {
+		ValidatorI18n(ValidatorI18nConfig{
+			DefaultLocale: locale,
+			Engine:        e,
+			MessageFuncFor: func(l string) func(validator.FieldError) string {
+				return func(validator.FieldError) string { return "MSG_" + l }
+			},
+			SetGlobal: true,
+		})
+	}
+	for locale, e := range apps {
+		ctx := validate.WithEngine(context.Background(), e)
+		if m := validate.ToFieldErrorsWithContext(ctx, validate.StructCtx(ctx, U{})); m["name"] != "MSG_"+locale {
+			t.Fatalf("expected %s fallback on its engine, got %v", locale, m)
+		}
+	}
+	if m := validate.ToFieldErrors(validate.Struct(U{})); m["name"] != "is required" {
+		t.Fatalf("expected process-wide fallback to stay untouched, got %v", m)
+	}
+}
+
+func TestValidatorI18n_TranslatorFor(t *testing.T) {
+	loc := en.New()
+	uni := ut.New(loc, loc)
+	trans, _ := uni.GetTranslator("en")
+	if err := trans.Add("greeting", "hello {0}", false); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	app := flash.New()
+	app.Use(ValidatorI18n(ValidatorI18nConfig{
+		DefaultLocale: "en",
+		TranslatorFor: func(locale string) ut.Translator {
+			if locale == "en" {
+				return trans
+			}
+			return nil
+		},
+	}))
+	app.GET("/t", func(c flash.Ctx) error {
+		tr := validate.TranslatorFromContext(c.Context())
+		if tr == nil {
+			return c.String(http.StatusOK, "none")
+		}
+		msg, _ := tr.T("greeting", "bob")
+		return c.String(http.StatusOK, msg)
+	})
+
+	for _, url := range []string{"/t", "/t?lang=fr"} {
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		if rec.Body.String() != "hello bob" {
+			t.Fatalf("%s: expected default-locale translator, got %q", url, rec.Body.String())
+		}
+	}
+}
+
+func TestValidatorI18n_CachesMessageFuncs(t *testing.T) {
+	calls := map[string]int{}
+	app := flash.New()
+	app.Use(ValidatorI18n(ValidatorI18nConfig{
+		DefaultLocale: "en",
+		MessageFuncFor: func(locale string) func(validator.FieldError) string {
+			calls[locale]++
+			if locale == "xx" {
+				return nil
+			}
+			return func(validator.FieldError) string { return "MSG_" + locale }
+		},
+	}))
+	app.GET("/:lang/u", validateHandler)
+	for i := 0; i < 3; i++ {
+		for _, lang := range []string{"en", "es", "xx"} {
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+lang+"/u", nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("unexpected status %d", rec.Code)
+			}
+		}
+	}
+	if calls["en"] != 1 || calls["es"] != 1 {
+		t.Fatalf("expected one call per supported locale, got %v", calls)
+	}
+	// Locales without a function are not cached.
+	if calls["xx"] != 3 {
+		t.Fatalf("expected unsupported locale to be resolved per request, got %v", calls)
+	}
+}
+
+func TestValidatorI18n_LocaleServedMetric(t *testing.T) {
+	var got []map[string]string
+	validate.SetMetrics(func(metric string, labels map[string]string) {
+		if metric == validate.MetricLocaleServed {
+			got = append(got, labels)
+		}
+	})
+	defer validate.SetMetrics(nil)
+
+	app := flash.New()
+	app.Use(ValidatorI18n(ValidatorI18nConfig{
+		DefaultLocale: "en",
+		MessageFuncFor: func(locale string) func(validator.FieldError) string {
+			if locale == "en" || locale == "es" {
+				return func(validator.FieldError) string { return locale }
+			}
+			return nil
+		},
+	}))
+	app.GET("/:lang/u", validateHandler)
+	for _, lang := range []string{"es", "pt-br", "x1"} {
+		app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/"+lang+"/u", nil))
+	}
+
+	want := []map[string]string{
+		{"requested": "es", "served": "es", "result": "requested"},
+		{"requested": "pt", "served": "en", "result": "default"},
+		{"requested": "invalid", "served": "en", "result": "default"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d metrics, got %v", len(want), got)
+	}
+	for i := range want {
+		for k, v := range want[i] {
+			if got[i][k] != v {
+				t.Fatalf("metric %d: expected %s=%s, got %v", i, k, v, got[i])
+			}
+		}
+	}
+}
+
+func TestValidatorI18n_DefaultLocaleFor(t *testing.T) {
+	app := flash.New()
+	app.Use(ValidatorI18n(ValidatorI18nConfig{
+		DefaultLocale: "en",
+		DefaultLocaleFor: func(c flash.Ctx) string {
+			return c.Request().Header.Get("X-Tenant-Locale")
+		},
+		LocaleFromCtx: func(c flash.Ctx) string { return c.Query("lang") },
+		MessageFuncFor: func(locale string) func(validator.FieldError) string {
+			switch locale {
+			case "en", "de", "fr":
+				return func(validator.FieldError) string { return "MSG_" + locale }
+			}
+			return nil
+		},
+	}))
+	app.GET("/u", validateHandler)
+
+	for _, tc := range []struct{ url, tenant, want string }{
+		{"/u", "DE", "MSG_de"},         // no locale requested: tenant default
+		{"/u?lang=fr", "de", "MSG_fr"}, // requested locale wins
+		{"/u?lang=xx", "de", "MSG_de"}, // unsupported: tenant default
+		{"/u?lang=xx", "", "MSG_en"},   // no tenant default: DefaultLocale
+		{"/u?lang=xx", "zz", "MSG_en"}, // unsupported tenant default: DefaultLocale
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+		req.Header.Set("X-Tenant-Locale", tc.tenant)
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		if want := `{"name":"` + tc.want + `"}`; rec.Body.String() != want {
+			t.Fatalf("%s (tenant %q): expected %s, got %s", tc.url, tc.tenant, want, rec.Body.String())
+		}
+	}
+}
+
+func TestValidatorI18n_OrderingCheck(t *testing.T) {
+	defer validate.SetLocaleCheck(nil)
+	var logs bytes.Buffer
+	reg := NewRouteRegistry()
+	reg.ForRoute("/users/:org", http.MethodPost, &createUser{})
+
+	app := flash.New()
+	app.Use(Enforce(EnforceConfig{Registry: reg}), ValidatorI18n(ValidatorI18nConfig{
+		MessageFuncFor: func(string) func(validator.FieldError) string {
+			return func(validator.FieldError) string { return "MSG" }
+		},
+		Logger:        slog.New(slog.NewTextHandler(&logs, nil)),
+		OrderingCheck: true,
+	}))
+	app.POST("/users/:org", func(c flash.Ctx) error { return c.String(http.StatusCreated, "ok") })
+
+	for i := 0; i < 2; i++ {
+		body := `{"name":"Ann","email":"ann@example.com"}`
+		app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users/acme", strings.NewReader(body)))
+	}
+	if n := strings.Count(logs.String(), "runs after Enforce"); n != 1 || !strings.Contains(logs.String(), "route=\"POST /users/:org\"") {
+		t.Fatalf("expected one misordering warning for the route, got %q", logs.String())
+	}
+
+	logs.Reset()
+	quiet := flash.New()
+	quiet.Use(Enforce(EnforceConfig{Registry: reg}), ValidatorI18n(ValidatorI18nConfig{
+		MessageFuncFor: func(string) func(validator.FieldError) string { return nil },
+		Logger:         slog.New(slog.NewTextHandler(&logs, nil)),
+	}))
+	quiet.POST("/users/:org", func(c flash.Ctx) error { return c.String(http.StatusCreated, "ok") })
+	quiet.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users/acme", strings.NewReader(`{"name":"Ann","email":"ann@example.com"}`)))
+	if logs.Len() != 0 {
+		t.Fatalf("expected no warnings without OrderingCheck, got %q", logs.String())
+	}
+}
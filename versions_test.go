@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goflash/flash/v2"
+	"github.com/goflash/validator/v2/validate"
+)
+
+type profileReq struct {
+	FullName string `json:"full_name"`
+	Name     string `json:"name"`
+}
+
+func TestAPIVersion_SelectsRuleSet(t *testing.T) {
+	vr := NewVersionedRules()
+	vr.Rules("v1", profileReq{}, map[string]string{"FullName": "required"})
+	vr.Rules("v2", profileReq{}, map[string]string{"Name": "required"})
+	vr.Deprecate("v1", "v1 is deprecated, use v2")
+
+	routes := NewRouteRegistry()
+	routes.ForRoute("/api/:version/profile", http.MethodPost, profileReq{})
+	routes.ForRoute("/profile", http.MethodPost, profileReq{})
+
+	app := flash.New()
+	app.Use(APIVersion(APIVersionConfig{Rules: vr, Default: "v2"}), Enforce(EnforceConfig{Registry: routes}))
+	ok := func(c flash.Ctx) error { return c.String(http.StatusOK, APIVersionFrom(c)) }
+	app.POST("/api/:version/profile", ok)
+	app.POST("/profile", ok)
+
+	serve := func(path, header, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if header != "" {
+			req.Header.Set("X-API-Version", header)
+		}
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/api/v1/profile", "", `{"name":"Ann"}`)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), `"full_name":"is required"`) ||
+		!strings.Contains(rec.Body.String(), `"deprecation":"v1 is deprecated, use v2"`) {
+		t.Fatalf("expected v1 rules with deprecation note, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec = serve("/api/v1/profile", "", `{"full_name":"Ann"}`); rec.Code != http.StatusOK || rec.Body.String() != "v1" {
+		t.Fatalf("expected v1 request to pass, got %d %s", rec.Code, rec.Body.String())
+	}
+	rec = serve("/profile", "", `{"full_name":"Ann"}`)
+	if rec.Code != http.StatusUnprocessableEntity || strings.Contains(rec.Body.String(), "deprecation") {
+		t.Fatalf("expected default v2 rules without note, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec = serve("/profile", "v1", `{"full_name":"Ann"}`); rec.Code != http.StatusOK || rec.Body.String() != "v1" {
+		t.Fatalf("expected header to select v1, got %d %s", rec.Code, rec.Body.String())
+	}
+	if rec = serve("/api/v9/profile", "", `{}`); rec.Code != http.StatusOK || rec.Body.String() != "" {
+		t.Fatalf("expected unknown version to use the current engine, got %d %s", rec.Code, rec.Body.String())
+	}
+	if got := vr.Versions(); len(got) != 2 || got[0] != "v1" {
+		t.Fatalf("unexpected versions: %v", got)
+	}
+}
+
+func TestAPIVersion_KeepsTranslations(t *testing.T) {
+	defer validate.SetSupportedLocales()
+	cfg, err := NewI18n("en", "es")
+	if err != nil {
+		t.Fatalf("NewI18n: %v", err)
+	}
+	cfg.LocaleFromCtx = func(c flash.Ctx) string { return c.Request().Header.Get("Accept-Language") }
+	vr := NewVersionedRules()
+	vr.Rules("v2", profileReq{}, map[string]string{"Name": "required"})
+	routes := NewRouteRegistry()
+	routes.ForRoute("/api/:version/profile", http.MethodPost, profileReq{})
+
+	app := flash.New()
+	app.Use(ValidatorI18n(cfg), APIVersion(APIVersionConfig{Rules: vr}), Enforce(EnforceConfig{Registry: routes}))
+	app.POST("/api/:version/profile", func(c flash.Ctx) error { return c.String(http.StatusOK, "ok") })
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/profile", strings.NewReader(`{}`))
+	req.Header.Set("Accept-Language", "es")
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), `"name":"name es un campo requerido"`) {
+		t.Fatalf("expected the v2 engine to translate to es, got %d %s", rec.Code, rec.Body.String())
+	}
+}